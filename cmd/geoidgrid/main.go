@@ -0,0 +1,99 @@
+// Команда geoidgrid прореживает исходную сетку EGM96 NGA (WW15MGH.GRD, шаг 15′)
+// до шага 1° и записывает её в формате WW15MGH.GRD для встраивания в пакет tracker:
+//
+//	go run ./cmd/geoidgrid -src WW15MGH.GRD -out internal/tracker/data/egm96-1deg.grd
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/art-injener/satellite-scout/internal/tracker"
+)
+
+const (
+	slogKeyError = "error"
+
+	// stepDeg — шаг результирующей сетки, градусы.
+	stepDeg = 1
+	// valuesPerLine — число значений в строке файла, как в WW15MGH.GRD.
+	valuesPerLine = 8
+)
+
+func main() {
+	src := flag.String("src", "WW15MGH.GRD", "исходная сетка EGM96 NGA")
+	out := flag.String("out", "internal/tracker/data/egm96-1deg.grd", "файл результирующей сетки")
+	flag.Parse()
+
+	if err := run(*src, *out); err != nil {
+		slog.Error("failed to build geoid grid", slogKeyError, err)
+		os.Exit(1)
+	}
+}
+
+// run читает исходную сетку src и записывает прореженную в out.
+func run(src, out string) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("opening source grid: %w", err)
+	}
+
+	defer func() {
+		_ = in.Close()
+	}()
+
+	geoid, err := tracker.ParseWW15MGH(in)
+	if err != nil {
+		return fmt.Errorf("parsing source grid: %w", err)
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("creating output grid: %w", err)
+	}
+
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	w := bufio.NewWriter(f)
+	if err := writeGrid(w, geoid); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+// writeGrid записывает узлы сетки шагом stepDeg в формате WW15MGH.GRD.
+// Шаг 1° кратен 15′, поэтому значения в узлах берутся из исходной сетки без интерполяции.
+func writeGrid(w io.Writer, geoid tracker.GeoidModel) error {
+	if _, err := fmt.Fprintf(w, "%12.6f%12.6f%12.6f%12.6f%12.6f%12.6f\n\n",
+		-90.0, 90.0, 0.0, 360.0, float64(stepDeg), float64(stepDeg)); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+
+	for lat := 90; lat >= -90; lat -= stepDeg {
+		for lon := 0; lon <= 360; lon += stepDeg {
+			sep := " "
+			if (lon/stepDeg+1)%valuesPerLine == 0 || lon == 360 {
+				sep = "\n"
+			}
+
+			if _, err := fmt.Fprintf(w, "%9.3f%s", geoid.UndulationM(float64(lat), float64(lon)), sep); err != nil {
+				return fmt.Errorf("writing row %d: %w", lat, err)
+			}
+		}
+
+		if _, err := fmt.Fprintln(w); err != nil {
+			return fmt.Errorf("writing row %d: %w", lat, err)
+		}
+	}
+
+	return nil
+}
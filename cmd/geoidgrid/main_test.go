@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/art-injener/satellite-scout/internal/tracker"
+)
+
+func TestWriteGrid(t *testing.T) {
+	src, err := tracker.NewGridGeoid(90, [][]float64{
+		{0, 0, 0, 0},
+		{0, 50, 0, -100},
+		{0, 0, 0, 0},
+	})
+	if err != nil {
+		t.Fatalf("NewGridGeoid() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeGrid(&buf, src); err != nil {
+		t.Fatalf("writeGrid() error = %v", err)
+	}
+
+	got, err := tracker.ParseWW15MGH(&buf)
+	if err != nil {
+		t.Fatalf("ParseWW15MGH() error = %v", err)
+	}
+
+	for _, p := range [][2]float64{{0, 90}, {0, 270}, {45, 90}, {-12, 300}, {89, 359}} {
+		if want, have := src.UndulationM(p[0], p[1]), got.UndulationM(p[0], p[1]); want-have > 1e-3 || have-want > 1e-3 {
+			t.Errorf("UndulationM(%v, %v) = %.4f, want %.4f", p[0], p[1], have, want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/art-injener/satellite-scout/internal/config"
 	"github.com/art-injener/satellite-scout/internal/handlers"
+	"github.com/art-injener/satellite-scout/internal/tracker"
 )
 
 const (
@@ -44,6 +46,11 @@ func main() {
 
 	apiHandler := handlers.NewAPIHandler(cfg)
 
+	// Каталог TLE: встроенный архивный набор доступен сразу, загрузка с Celestrak
+	// и периодическое обновление идут в фоне.
+	storeCtx, storeCancel := context.WithCancel(context.Background())
+	store := newTLEStore(storeCtx, logger)
+
 	mux := http.NewServeMux()
 
 	// Статические файлы
@@ -59,6 +66,7 @@ func main() {
 	// API маршруты
 	mux.HandleFunc("GET /api/health", apiHandler.HealthCheck)
 	mux.HandleFunc("GET /api/config", apiHandler.GetConfig)
+	registerSatelliteRoutes(mux, store, cfg)
 
 	// Частичные шаблоны (HTMX)
 	mux.HandleFunc("GET /partials/passes", func(w http.ResponseWriter, r *http.Request) {
@@ -69,14 +77,7 @@ func main() {
 		}
 	})
 
-	// Создание сервера с таймаутами
-	server := &http.Server{
-		Addr:         cfg.Addr(),
-		Handler:      loggingMiddleware(mux),
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
-	}
+	server := newServer(cfg.Addr(), loggingMiddleware(mux))
 
 	// Канал для сигнализации об ошибках сервера
 	serverErr := make(chan error, 1)
@@ -102,6 +103,9 @@ func main() {
 
 	slog.Info("shutting down server...")
 
+	storeCancel()
+	store.Stop()
+
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 
 	if err := server.Shutdown(shutdownCtx); err != nil {
@@ -114,6 +118,59 @@ func main() {
 	slog.Info("server stopped gracefully")
 }
 
+// newTLEStore создаёт каталог TLE, заполняет его встроенным архивным набором
+// (устаревшие элементы только для демонстрации, см. tracker.LoadEmbeddedCatalog) и запускает
+// загрузку с Celestrak в фоне; ошибка загрузки не мешает работе сервера.
+func newTLEStore(ctx context.Context, logger *slog.Logger) *tracker.TLEStore {
+	store := tracker.NewTLEStore(tracker.WithLogger(logger))
+
+	embedded, err := tracker.LoadEmbeddedCatalog()
+	if err != nil {
+		slog.Warn("failed to load embedded TLE catalog", slogKeyError, err)
+	}
+
+	for _, tle := range embedded {
+		store.Add(tle)
+	}
+
+	go func() {
+		if err := store.Start(ctx); err != nil {
+			slog.Warn("initial TLE load failed, using stale archived embedded catalog", slogKeyError, err)
+		}
+	}()
+
+	return store
+}
+
+// newServer создаёт HTTP сервер с таймаутами. Shutdown не отменяет контексты
+// активных запросов, поэтому потоки SSE (см. handlers.SSEHandler.Positions) завершаются
+// через базовый контекст запросов, который отменяется в начале остановки сервера.
+func newServer(addr string, handler http.Handler) *http.Server {
+	baseCtx, cancel := context.WithCancel(context.Background())
+
+	server := &http.Server{
+		Addr:         addr,
+		Handler:      handler,
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
+	}
+	server.RegisterOnShutdown(cancel)
+
+	return server
+}
+
+// registerSatelliteRoutes регистрирует API спутников из каталога store.
+func registerSatelliteRoutes(mux *http.ServeMux, store *tracker.TLEStore, cfg *config.Config) {
+	mux.HandleFunc("GET /api/satellite/{norad}/state", handlers.NewStateHandler(store).GetState)
+	mux.HandleFunc("GET /api/satellite/{norad}/track", handlers.NewTrackHandler(store).GetTrack)
+	mux.HandleFunc("GET /api/satellite/{norad}/passes", handlers.NewPassesHandler(store).GetPasses)
+	mux.HandleFunc("GET /api/positions/stream", handlers.NewSSEHandler(store, cfg).Positions)
+}
+
 // loggingMiddleware логирует HTTP запросы.
 func loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -149,3 +206,9 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.status = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap возвращает исходный ResponseWriter для http.ResponseController
+// (Flush и снятие таймаута записи в потоке SSE).
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -1,9 +1,16 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+
+	"github.com/art-injener/satellite-scout/internal/config"
+	"github.com/art-injener/satellite-scout/internal/tracker"
 )
 
 func TestLoggingMiddleware(t *testing.T) {
@@ -120,3 +127,90 @@ func TestSlogKeyError(t *testing.T) {
 		t.Errorf("Expected slogKeyError to be 'error', got '%s'", slogKeyError)
 	}
 }
+
+func TestRegisterSatelliteRoutes(t *testing.T) {
+	mux := http.NewServeMux()
+	registerSatelliteRoutes(mux, tracker.NewTLEStore(), &config.Config{})
+	handler := loggingMiddleware(mux)
+
+	// Некорректный NORAD ID отклоняется самим обработчиком (400), а не ServeMux (404).
+	for _, path := range []string{
+		"/api/satellite/iss/state",
+		"/api/satellite/iss/track",
+		"/api/satellite/iss/passes?lat=0&lon=0",
+		"/api/positions/stream?norad=iss",
+	} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want %d", path, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestResponseWriter_Unwrap(t *testing.T) {
+	w := httptest.NewRecorder()
+	rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+	// Поток SSE за loggingMiddleware сбрасывает данные через ResponseController.
+	if err := http.NewResponseController(rw).Flush(); err != nil {
+		t.Fatalf("Flush() through wrapper error = %v", err)
+	}
+
+	if !w.Flushed {
+		t.Error("underlying recorder was not flushed")
+	}
+}
+
+func TestNewServer_ShutdownWithOpenStream(t *testing.T) {
+	store := tracker.NewTLEStore()
+
+	tle, err := tracker.ParseTLE([]string{
+		"ISS (ZARYA)",
+		"1 25544U 98067A   24001.50000000  .00016717  00000-0  10270-3 0  9997",
+		"2 25544  51.6400 247.4627 0006703 130.5360 325.0288 15.49815571423401",
+	})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	store.Add(tle)
+
+	mux := http.NewServeMux()
+	registerSatelliteRoutes(mux, store, &config.Config{})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+
+	server := newServer(ln.Addr().String(), loggingMiddleware(mux))
+
+	go func() {
+		_ = server.Serve(ln)
+	}()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/api/positions/stream?norad=25544")
+	if err != nil {
+		t.Fatalf("GET stream error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Дожидаемся первого события: поток точно открыт.
+	if _, err := bufio.NewReader(resp.Body).ReadString('\n'); err != nil {
+		t.Fatalf("reading first event error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	started := time.Now()
+	if err := server.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("Shutdown() took %v with an open stream, want prompt return", elapsed)
+	}
+}
@@ -2,6 +2,20 @@ module github.com/art-injener/satellite-scout
 
 go 1.25.5
 
-require github.com/joshuaferrara/go-satellite v0.0.0-20220611180459-512638c64e5b
+require (
+	github.com/joshuaferrara/go-satellite v0.0.0-20220611180459-512638c64e5b
+	modernc.org/sqlite v1.59.0
+)
 
-require github.com/pkg/errors v0.9.1 // indirect
+require (
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/mattn/go-isatty v0.0.24 // indirect
+	github.com/ncruces/go-strftime v1.0.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/remyoudompheng/bigfft v0.0.0-20230129092748-24d4a6f8daec // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	modernc.org/libc v1.75.7 // indirect
+	modernc.org/mathutil v1.7.1 // indirect
+	modernc.org/memory v1.12.1 // indirect
+)
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/art-injener/satellite-scout/internal/tracker"
+)
+
+// Параметры запроса пролётов.
+const (
+	defaultPassHours = 24.0
+	maxPassHours     = 72.0 // Больший интервал урезается до этого значения.
+	defaultPassMinEl = 10.0
+)
+
+// Ошибки разбора параметров пролётов.
+var (
+	errObserverRequired = errors.New("lat and lon are required")
+	errPassHoursRange   = errors.New("hours must be positive")
+	errPassMinElRange   = errors.New("minEl must be between 0 and 90")
+)
+
+// PassesHandler возвращает расписание пролётов спутников над наблюдателем.
+type PassesHandler struct {
+	store *tracker.TLEStore
+	now   func() time.Time
+}
+
+// NewPassesHandler создаёт обработчик пролётов для спутников из store.
+func NewPassesHandler(store *tracker.TLEStore) *PassesHandler {
+	return &PassesHandler{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// GetPasses отвечает на GET /api/satellite/{norad}/passes?lat=&lon=&alt=&hours=&minEl=
+// списком пролётов (см. tracker.Pass.MarshalJSON) от текущего момента на hours часов вперёд;
+// у каждого пролёта задан признак визуальной видимости optically_visible.
+// lat и lon обязательны, alt задаётся в метрах; hours по умолчанию 24 и урезается до 72,
+// minEl по умолчанию 10°. Отвечает 400 для некорректных параметров, 404 для неизвестного спутника.
+func (h *PassesHandler) GetPasses(w http.ResponseWriter, r *http.Request) {
+	noradID, err := strconv.Atoi(r.PathValue("norad"))
+	if err != nil {
+		http.Error(w, "invalid norad parameter", http.StatusBadRequest)
+		return
+	}
+
+	observer, err := parseObserver(r)
+	if err == nil && observer == nil {
+		err = errObserverRequired
+	}
+
+	if err != nil {
+		http.Error(w, "invalid observer parameters: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hours, minEl, err := parsePassWindow(r)
+	if err != nil {
+		http.Error(w, "invalid pass parameters: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tle, ok := h.store.Get(noradID)
+	if !ok {
+		http.Error(w, "satellite not found", http.StatusNotFound)
+		return
+	}
+
+	start := h.now().UTC().Truncate(time.Second)
+	end := start.Add(time.Duration(hours * float64(time.Hour)))
+
+	passes, err := observer.PassesWithVisibility(tle, start, end, minEl)
+	if err != nil {
+		http.Error(w, "propagation failed", http.StatusInternalServerError)
+		return
+	}
+
+	if passes == nil {
+		passes = []*tracker.Pass{}
+	}
+
+	writeJSON(w, http.StatusOK, passes)
+}
+
+// parsePassWindow читает длительность окна hours и порог minEl, подставляя значения по умолчанию.
+func parsePassWindow(r *http.Request) (hours, minEl float64, err error) {
+	query := r.URL.Query()
+	hours, minEl = defaultPassHours, defaultPassMinEl
+
+	if s := query.Get("hours"); s != "" {
+		if hours, err = strconv.ParseFloat(s, 64); err != nil {
+			return 0, 0, fmt.Errorf("hours: %w", err)
+		}
+	}
+
+	if s := query.Get("minEl"); s != "" {
+		if minEl, err = strconv.ParseFloat(s, 64); err != nil {
+			return 0, 0, fmt.Errorf("minEl: %w", err)
+		}
+	}
+
+	// Сравнения записаны так, чтобы NaN тоже отклонялся.
+	if !(hours > 0) {
+		return 0, 0, fmt.Errorf("%w: %g", errPassHoursRange, hours)
+	}
+
+	if !(minEl >= 0 && minEl < 90) {
+		return 0, 0, fmt.Errorf("%w: %g", errPassMinElRange, minEl)
+	}
+
+	return min(hours, maxPassHours), minEl, nil
+}
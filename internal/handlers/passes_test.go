@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satellite-scout/internal/tracker"
+)
+
+// passTestEpoch — эпоха тестового TLE ISS (1 января 2024, 12:00 UTC).
+var passTestEpoch = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// newPassesTestHandler создаёт обработчик с ISS в каталоге и часами, остановленными на эпохе TLE.
+func newPassesTestHandler(t *testing.T) *PassesHandler {
+	t.Helper()
+
+	tle, err := tracker.ParseTLE([]string{
+		"ISS (ZARYA)",
+		"1 25544U 98067A   24001.50000000  .00016717  00000-0  10270-3 0  9997",
+		"2 25544  51.6400 247.4627 0006703 130.5360 325.0288 15.49815571423401",
+	})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	store := tracker.NewTLEStore()
+	store.Add(tle)
+
+	handler := NewPassesHandler(store)
+	handler.now = func() time.Time { return passTestEpoch }
+
+	return handler
+}
+
+// servePasses выполняет запрос через ServeMux, чтобы заполнить параметр пути {norad}.
+func servePasses(handler *PassesHandler, target string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/satellite/{norad}/passes", handler.GetPasses)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+	return w
+}
+
+func TestPassesHandler_GetPasses(t *testing.T) {
+	handler := newPassesTestHandler(t)
+
+	// Москва, высота 150 м.
+	w := servePasses(handler, "/api/satellite/25544/passes?lat=55.7558&lon=37.6173&alt=150&hours=24&minEl=10")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != contentTypeJSON {
+		t.Errorf("Content-Type = %q, want %q", ct, contentTypeJSON)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	tle, _ := handler.store.Get(25544)
+
+	want, err := tracker.NewObserver(55.7558, 37.6173, 0.15).PassesWithVisibility(tle,
+		passTestEpoch, passTestEpoch.Add(24*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("PassesWithVisibility() error = %v", err)
+	}
+
+	if len(want) == 0 || len(got) != len(want) {
+		t.Fatalf("got %d passes, want %d (non-zero)", len(got), len(want))
+	}
+
+	for i, pass := range got {
+		for _, field := range []string{"aos", "los", "max_elevation", "rise_azimuth", "set_azimuth", "optically_visible"} {
+			if _, ok := pass[field]; !ok {
+				t.Errorf("pass[%d] lacks field %q", i, field)
+			}
+		}
+
+		if aos := pass["aos"]; aos != want[i].AOS.UTC().Format(time.RFC3339) {
+			t.Errorf("pass[%d] aos = %v, want %v", i, aos, want[i].AOS)
+		}
+
+		if el, _ := pass["max_elevation"].(float64); el != want[i].MaxElDeg || el < 10 || el > 90 {
+			t.Errorf("pass[%d] max_elevation = %v, want %v degrees", i, el, want[i].MaxElDeg)
+		}
+
+		if visible, _ := pass["optically_visible"].(bool); visible != want[i].OpticallyVisible {
+			t.Errorf("pass[%d] optically_visible = %v, want %v", i, visible, want[i].OpticallyVisible)
+		}
+	}
+}
+
+func TestPassesHandler_GetPasses_ClampsHours(t *testing.T) {
+	handler := newPassesTestHandler(t)
+
+	var clamped, limit []json.RawMessage
+
+	for target, out := range map[string]*[]json.RawMessage{
+		"/api/satellite/25544/passes?lat=55.7558&lon=37.6173&hours=1000": &clamped,
+		"/api/satellite/25544/passes?lat=55.7558&lon=37.6173&hours=72":   &limit,
+	} {
+		w := servePasses(handler, target)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d, want 200", target, w.Code)
+		}
+
+		if err := json.Unmarshal(w.Body.Bytes(), out); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+	}
+
+	if len(clamped) == 0 || len(clamped) != len(limit) {
+		t.Errorf("hours=1000 returned %d passes, want %d as for hours=72", len(clamped), len(limit))
+	}
+}
+
+func TestPassesHandler_GetPasses_Errors(t *testing.T) {
+	handler := newPassesTestHandler(t)
+
+	tests := []struct {
+		name   string
+		target string
+		status int
+	}{
+		{name: "unknown satellite", target: "/api/satellite/99999/passes?lat=55&lon=37", status: http.StatusNotFound},
+		{name: "invalid norad", target: "/api/satellite/iss/passes?lat=55&lon=37", status: http.StatusBadRequest},
+		{name: "missing observer", target: "/api/satellite/25544/passes", status: http.StatusBadRequest},
+		{name: "lon out of range", target: "/api/satellite/25544/passes?lat=55&lon=200", status: http.StatusBadRequest},
+		{name: "NaN lat", target: "/api/satellite/25544/passes?lat=NaN&lon=37", status: http.StatusBadRequest},
+		{name: "infinite alt", target: "/api/satellite/25544/passes?lat=55&lon=37&alt=-Inf", status: http.StatusBadRequest},
+		{name: "malformed hours", target: "/api/satellite/25544/passes?lat=55&lon=37&hours=day", status: http.StatusBadRequest},
+		{name: "zero hours", target: "/api/satellite/25544/passes?lat=55&lon=37&hours=0", status: http.StatusBadRequest},
+		{name: "minEl above zenith", target: "/api/satellite/25544/passes?lat=55&lon=37&minEl=95", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := servePasses(handler, tt.target)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
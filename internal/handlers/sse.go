@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/art-injener/satellite-scout/internal/config"
+	"github.com/art-injener/satellite-scout/internal/tracker"
+)
+
+const (
+	contentTypeEventStream = "text/event-stream"
+
+	// defaultSSEInterval период отправки положений спутника.
+	defaultSSEInterval = time.Second
+
+	// metersPerKm перевод высоты наблюдателя из конфигурации (метры) в км.
+	metersPerKm = 1000.0
+)
+
+// positionEvent — положение спутника в событии SSE.
+type positionEvent struct {
+	Lat float64 `json:"lat"` // Широта подспутниковой точки, градусы.
+	Lon float64 `json:"lon"` // Долгота подспутниковой точки, градусы.
+	Alt float64 `json:"alt"` // Высота над эллипсоидом, км.
+	Az  float64 `json:"az"`  // Азимут от наблюдателя, градусы.
+	El  float64 `json:"el"`  // Угол места от наблюдателя, градусы.
+}
+
+// SSEHandler передаёт положения спутника в реальном времени через Server-Sent Events.
+type SSEHandler struct {
+	store    *tracker.TLEStore
+	observer *tracker.Observer
+	interval time.Duration
+}
+
+// NewSSEHandler создаёт обработчик потока положений для спутников из store
+// и наблюдателя из конфигурации.
+func NewSSEHandler(store *tracker.TLEStore, cfg *config.Config) *SSEHandler {
+	return &SSEHandler{
+		store:    store,
+		observer: tracker.NewObserver(cfg.ObserverLat, cfg.ObserverLon, cfg.ObserverAlt/metersPerKm),
+		interval: defaultSSEInterval,
+	}
+}
+
+// Positions отправляет положение спутника (параметр norad) раз в секунду, пока клиент
+// не отключится или не будет отменён контекст запроса. Отвечает 400 для некорректного NORAD ID и 404, если спутника нет в хранилище.
+func (h *SSEHandler) Positions(w http.ResponseWriter, r *http.Request) {
+	noradID, err := strconv.Atoi(r.URL.Query().Get("norad"))
+	if err != nil {
+		http.Error(w, "invalid norad parameter", http.StatusBadRequest)
+		return
+	}
+
+	tle, ok := h.store.Get(noradID)
+	if !ok {
+		http.Error(w, "satellite not found", http.StatusNotFound)
+		return
+	}
+
+	// ResponseController находит Flush и снимает таймаут записи сервера
+	// (WriteTimeout) и через обёртки ResponseWriter с методом Unwrap.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		slog.Warn("failed to clear write deadline", "error", err)
+	}
+
+	prop, err := tracker.NewPropagator(tle)
+	if err != nil {
+		http.Error(w, "propagation failed", http.StatusInternalServerError)
+		return
+	}
+
+	// Поток завершается с закрытием канала при отключении клиента или остановке
+	// сервера (отмена r.Context()).
+	positions, err := prop.Stream(r.Context(), h.interval)
+	if err != nil {
+		http.Error(w, "propagation failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypeEventStream)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if err := rc.Flush(); err != nil {
+		slog.Error("streaming unsupported", "error", err)
+		return
+	}
+
+	for pos := range positions {
+		lla := tracker.ECEFToLLA(tracker.ECIToECEF(pos))
+		aer := h.observer.GetAER(pos)
+
+		data, err := json.Marshal(positionEvent{
+			Lat: lla.LatDeg(),
+			Lon: lla.LonDeg(),
+			Alt: lla.Alt,
+			Az:  aer.AzDeg(),
+			El:  aer.ElDeg(),
+		})
+		if err != nil {
+			slog.Error("failed to encode position event", "error", err)
+			return
+		}
+
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+			return
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
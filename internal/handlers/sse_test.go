@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satellite-scout/internal/config"
+	"github.com/art-injener/satellite-scout/internal/tracker"
+)
+
+// newSSETestStore создаёт хранилище с геостационарным спутником (NORAD 25544):
+// SGP4 рассчитывает его положение на любую дату, поэтому тест не зависит от текущего времени.
+func newSSETestStore(t *testing.T) *tracker.TLEStore {
+	t.Helper()
+
+	tle, err := tracker.ParseTLE([]string{
+		"GEO TEST",
+		"1 25544U 98067A   24001.50000000  .00016717  00000-0  10270-3 0  9997",
+		"2 25544  51.6400 247.4627 0006703 130.5360 325.0288 15.49815571423401",
+	})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	tle.MeanMotion, tle.Eccentricity, tle.Inclination = 1.00273791, 0.0001, 0.05
+	tle.MeanMotionDot, tle.Bstar = 0, 0
+
+	// Средняя долгота λ = RAAN + ω + M − GMST; спутник стоит над 0° долготы.
+	tle.RAAN, tle.ArgOfPerigee = 0, 0
+	tle.MeanAnomaly = math.Mod(tracker.GMST(tle.Epoch)*tracker.Rad2Deg+360, 360)
+
+	if tle.Line1, tle.Line2, err = tle.ToLines(); err != nil {
+		t.Fatalf("ToLines() error = %v", err)
+	}
+
+	store := tracker.NewTLEStore()
+	store.Add(tle)
+
+	return store
+}
+
+// currentSubLon возвращает долготу подспутниковой точки на текущий момент.
+func currentSubLon(t *testing.T, prop *tracker.Propagator) float64 {
+	t.Helper()
+
+	pos, err := prop.Propagate(time.Now().UTC().Truncate(time.Second))
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	return tracker.ECEFToLLA(tracker.ECIToECEF(pos)).LonDeg()
+}
+
+func TestSSEHandler_Positions(t *testing.T) {
+	store := newSSETestStore(t)
+	handler := NewSSEHandler(store, &config.Config{ObserverLat: 0, ObserverLon: 0, ObserverAlt: 70})
+
+	tle, _ := store.Get(25544)
+
+	prop, err := tracker.NewPropagator(tle)
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	handler.interval = 20 * time.Millisecond
+
+	finished := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.Positions(w, r)
+		close(finished)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/api/positions/stream?norad=25544", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != contentTypeEventStream {
+		t.Errorf("Content-Type = %q, want %q", ct, contentTypeEventStream)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	events := 0
+
+	for events < 2 && scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok {
+			t.Fatalf("unexpected SSE line %q", line)
+		}
+
+		var event positionEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			t.Fatalf("Unmarshal(%q) error = %v", data, err)
+		}
+
+		// Геостационарный спутник над экватором виден с экватора выше горизонта.
+		if event.Alt < 35000 || event.El < 0 || event.Lat < -1 || event.Lat > 1 {
+			t.Errorf("event = %+v, want GEO position above the horizon", event)
+		}
+
+		// Подспутниковая точка ГСО за время теста практически неподвижна; сравнение
+		// с прямым расчётом выявляет ошибку времени (например, местный часовой пояс).
+		if want := currentSubLon(t, prop); math.Abs(tracker.NormalizeLongitude(event.Lon-want)) > 0.1 {
+			t.Errorf("event lon = %.3f, want %.3f", event.Lon, want)
+		}
+
+		events++
+	}
+
+	if events != 2 {
+		t.Fatalf("received %d events, want 2 (scanner error: %v)", events, scanner.Err())
+	}
+
+	// Отключение клиента завершает обработчик.
+	cancel()
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("handler did not return after client disconnect")
+	}
+}
+
+func TestSSEHandler_Positions_Errors(t *testing.T) {
+	handler := NewSSEHandler(newSSETestStore(t), &config.Config{})
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{name: "unknown satellite", query: "?norad=99999", status: http.StatusNotFound},
+		{name: "invalid norad", query: "?norad=iss", status: http.StatusBadRequest},
+		{name: "missing norad", query: "", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/positions/stream"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.Positions(w, req)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
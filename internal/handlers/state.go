@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/art-injener/satellite-scout/internal/tracker"
+)
+
+// Ошибки разбора параметров наблюдателя.
+var (
+	errObserverIncomplete = errors.New("lat and lon must be supplied together")
+	errObserverRange      = errors.New("observer coordinates out of range")
+)
+
+// eciState — положение и скорость спутника в ECI.
+type eciState struct {
+	X  float64 `json:"x"`  // км.
+	Y  float64 `json:"y"`  // км.
+	Z  float64 `json:"z"`  // км.
+	Vx float64 `json:"vx"` // км/с.
+	Vy float64 `json:"vy"` // км/с.
+	Vz float64 `json:"vz"` // км/с.
+}
+
+// llaState — подспутниковая точка и высота.
+type llaState struct {
+	Lat float64 `json:"lat"` // Градусы.
+	Lon float64 `json:"lon"` // Градусы.
+	Alt float64 `json:"alt"` // Высота над эллипсоидом, км.
+}
+
+// aerState — направление на спутник от наблюдателя.
+type aerState struct {
+	Az    float64 `json:"az"`    // Градусы.
+	El    float64 `json:"el"`    // Градусы.
+	Range float64 `json:"range"` // км.
+}
+
+// satelliteState — ответ GET /api/satellite/{norad}/state.
+type satelliteState struct {
+	NoradID   int       `json:"norad_id"`
+	Name      string    `json:"name"`
+	Timestamp time.Time `json:"timestamp"`
+	Altitude  float64   `json:"altitude"` // Высота над эллипсоидом, км.
+	ECI       eciState  `json:"eci"`
+	LLA       llaState  `json:"lla"`
+	AER       *aerState `json:"aer,omitempty"` // Только при заданном наблюдателе.
+}
+
+// StateHandler возвращает текущее состояние спутника из каталога.
+type StateHandler struct {
+	store *tracker.TLEStore
+	now   func() time.Time
+}
+
+// NewStateHandler создаёт обработчик состояния спутников из store.
+func NewStateHandler(store *tracker.TLEStore) *StateHandler {
+	return &StateHandler{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// GetState отвечает на GET /api/satellite/{norad}/state текущими ECI и LLA спутника.
+// Если заданы параметры lat и lon (градусы) и необязательный alt (метры, как в конфигурации),
+// в ответ добавляются азимут, угол места и дальность от этого наблюдателя.
+// Отвечает 400 для некорректных NORAD ID и параметров наблюдателя, 404 для неизвестного спутника.
+func (h *StateHandler) GetState(w http.ResponseWriter, r *http.Request) {
+	noradID, err := strconv.Atoi(r.PathValue("norad"))
+	if err != nil {
+		http.Error(w, "invalid norad parameter", http.StatusBadRequest)
+		return
+	}
+
+	observer, err := parseObserver(r)
+	if err != nil {
+		http.Error(w, "invalid observer parameters: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tle, ok := h.store.Get(noradID)
+	if !ok {
+		http.Error(w, "satellite not found", http.StatusNotFound)
+		return
+	}
+
+	prop, err := tracker.NewPropagator(tle)
+	if err != nil {
+		http.Error(w, "propagation failed", http.StatusInternalServerError)
+		return
+	}
+
+	pos, err := prop.Propagate(h.now().UTC().Truncate(time.Second))
+	if err != nil {
+		http.Error(w, "propagation failed", http.StatusInternalServerError)
+		return
+	}
+
+	lla := tracker.ECEFToLLA(tracker.ECIToECEF(pos))
+
+	state := satelliteState{
+		NoradID:   tle.NoradID,
+		Name:      tle.Name,
+		Timestamp: pos.Time,
+		Altitude:  lla.Alt,
+		ECI:       eciState{X: pos.X, Y: pos.Y, Z: pos.Z, Vx: pos.Vx, Vy: pos.Vy, Vz: pos.Vz},
+		LLA:       llaState{Lat: lla.LatDeg(), Lon: lla.LonDeg(), Alt: lla.Alt},
+	}
+
+	if observer != nil {
+		aer := observer.GetAER(pos)
+		state.AER = &aerState{Az: aer.AzDeg(), El: aer.ElDeg(), Range: aer.Range}
+	}
+
+	writeJSON(w, http.StatusOK, state)
+}
+
+// parseObserver читает наблюдателя из параметров lat, lon и alt.
+// Без lat и lon возвращает nil: наблюдатель не задан.
+func parseObserver(r *http.Request) (*tracker.Observer, error) {
+	query := r.URL.Query()
+	latStr, lonStr, altStr := query.Get("lat"), query.Get("lon"), query.Get("alt")
+
+	if latStr == "" && lonStr == "" && altStr == "" {
+		return nil, nil //nolint:nilnil // Отсутствие наблюдателя — не ошибка.
+	}
+
+	if latStr == "" || lonStr == "" {
+		return nil, errObserverIncomplete
+	}
+
+	lat, err := strconv.ParseFloat(latStr, 64)
+	if err != nil {
+		return nil, fmt.Errorf("lat: %w", err)
+	}
+
+	lon, err := strconv.ParseFloat(lonStr, 64)
+	if err != nil {
+		return nil, fmt.Errorf("lon: %w", err)
+	}
+
+	var alt float64
+	if altStr != "" {
+		if alt, err = strconv.ParseFloat(altStr, 64); err != nil {
+			return nil, fmt.Errorf("alt: %w", err)
+		}
+	}
+
+	// Сравнения записаны так, чтобы NaN тоже отклонялся.
+	if !(lat >= -90 && lat <= 90) || !(lon >= -180 && lon <= 180) {
+		return nil, fmt.Errorf("%w: lat %g, lon %g", errObserverRange, lat, lon)
+	}
+
+	if math.IsNaN(alt) || math.IsInf(alt, 0) {
+		return nil, fmt.Errorf("%w: alt %g", errObserverRange, alt)
+	}
+
+	return tracker.NewObserver(lat, lon, alt/metersPerKm), nil
+}
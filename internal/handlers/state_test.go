@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satellite-scout/internal/tracker"
+)
+
+// serveState выполняет запрос через ServeMux, чтобы заполнить параметр пути {norad}.
+func serveState(handler *StateHandler, target string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/satellite/{norad}/state", handler.GetState)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+	return w
+}
+
+func TestStateHandler_GetState(t *testing.T) {
+	store := newSSETestStore(t)
+	now := time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)
+
+	handler := NewStateHandler(store)
+	handler.now = func() time.Time { return now }
+
+	w := serveState(handler, "/api/satellite/25544/state?lat=10&lon=20&alt=150")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != contentTypeJSON {
+		t.Errorf("Content-Type = %q, want %q", ct, contentTypeJSON)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	for _, field := range []string{"norad_id", "name", "timestamp", "altitude", "eci", "lla", "aer"} {
+		if _, ok := raw[field]; !ok {
+			t.Errorf("response lacks field %q: %s", field, w.Body.String())
+		}
+	}
+
+	var got satelliteState
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	tle, _ := store.Get(25544)
+
+	prop, err := tracker.NewPropagator(tle)
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	pos, err := prop.Propagate(now)
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	lla := tracker.ECEFToLLA(tracker.ECIToECEF(pos))
+	aer := tracker.NewObserver(10, 20, 0.15).GetAER(pos)
+
+	if !got.Timestamp.Equal(now) {
+		t.Errorf("timestamp = %v, want %v", got.Timestamp, now)
+	}
+
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{name: "eci.x", got: got.ECI.X, want: pos.X},
+		{name: "eci.vz", got: got.ECI.Vz, want: pos.Vz},
+		{name: "lla.lat", got: got.LLA.Lat, want: lla.Lat * tracker.Rad2Deg},
+		{name: "lla.lon", got: got.LLA.Lon, want: lla.Lon * tracker.Rad2Deg},
+		{name: "lla.alt", got: got.LLA.Alt, want: lla.Alt},
+		{name: "altitude", got: got.Altitude, want: lla.Alt},
+		{name: "aer.az", got: got.AER.Az, want: aer.Az * tracker.Rad2Deg},
+		{name: "aer.el", got: got.AER.El, want: aer.El * tracker.Rad2Deg},
+		{name: "aer.range", got: got.AER.Range, want: aer.Range},
+	}
+
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+
+	// Угол места ГСО из (10°, 20°) заметно выше горизонта; в радианах он был бы меньше π/2.
+	if got.AER.El < 10 {
+		t.Errorf("aer.el = %.3f, want degrees above 10", got.AER.El)
+	}
+}
+
+func TestStateHandler_GetState_WithoutObserver(t *testing.T) {
+	w := serveState(NewStateHandler(newSSETestStore(t)), "/api/satellite/25544/state")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if _, ok := raw["aer"]; ok {
+		t.Errorf("aer present without observer: %s", w.Body.String())
+	}
+}
+
+func TestStateHandler_GetState_Errors(t *testing.T) {
+	handler := NewStateHandler(newSSETestStore(t))
+
+	tests := []struct {
+		name   string
+		target string
+		status int
+	}{
+		{name: "unknown satellite", target: "/api/satellite/99999/state", status: http.StatusNotFound},
+		{name: "invalid norad", target: "/api/satellite/iss/state", status: http.StatusBadRequest},
+		{name: "lat without lon", target: "/api/satellite/25544/state?lat=10", status: http.StatusBadRequest},
+		{name: "malformed lat", target: "/api/satellite/25544/state?lat=north&lon=20", status: http.StatusBadRequest},
+		{name: "malformed alt", target: "/api/satellite/25544/state?lat=10&lon=20&alt=high", status: http.StatusBadRequest},
+		{name: "lat out of range", target: "/api/satellite/25544/state?lat=91&lon=20", status: http.StatusBadRequest},
+		{name: "alt only", target: "/api/satellite/25544/state?alt=100", status: http.StatusBadRequest},
+		{name: "NaN lat", target: "/api/satellite/25544/state?lat=NaN&lon=0", status: http.StatusBadRequest},
+		{name: "NaN lon", target: "/api/satellite/25544/state?lat=0&lon=NaN", status: http.StatusBadRequest},
+		{name: "infinite alt", target: "/api/satellite/25544/state?lat=0&lon=0&alt=Inf", status: http.StatusBadRequest},
+		{name: "NaN alt", target: "/api/satellite/25544/state?lat=0&lon=0&alt=NaN", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveState(handler, tt.target)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/art-injener/satellite-scout/internal/tracker"
+)
+
+// Ограничения параметров запроса трассы.
+const (
+	minTrackStep = time.Second
+	maxTrackStep = 600 * time.Second
+
+	// maxTrackPeriods — предельная суммарная длина трассы past+future в витках.
+	maxTrackPeriods = 10.0
+
+	// maxTrackPoints — предельное число точек трассы; ограничивает расчёт для
+	// высоких орбит, где виток длится сутки.
+	maxTrackPoints = 10000
+)
+
+// Ошибки разбора параметров трассы.
+var (
+	errTrackStepRange     = errors.New("step must be between 1s and 600s")
+	errTrackPeriodsRange  = errors.New("past and future must be non-negative and bounded")
+	errTrackTooManyPoints = errors.New("track would exceed the point limit")
+)
+
+// trackParams — параметры запроса трассы.
+type trackParams struct {
+	past   float64 // Витков назад.
+	future float64 // Витков вперёд.
+	step   time.Duration
+}
+
+// TrackHandler возвращает подспутниковые трассы спутников из каталога.
+type TrackHandler struct {
+	store *tracker.TLEStore
+	now   func() time.Time
+}
+
+// NewTrackHandler создаёт обработчик трасс для спутников из store.
+func NewTrackHandler(store *tracker.TLEStore) *TrackHandler {
+	return &TrackHandler{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// GetTrack отвечает на GET /api/satellite/{norad}/track?past=1&future=2&step=30s трассой GroundTrack.
+// past и future задаются в витках, step — как длительность Go; без параметров используются
+// значения GenerateDefaultGroundTrack. Отвечает 400 для некорректных параметров и слишком
+// длинной трассы, 404 для неизвестного спутника.
+func (h *TrackHandler) GetTrack(w http.ResponseWriter, r *http.Request) {
+	noradID, err := strconv.Atoi(r.PathValue("norad"))
+	if err != nil {
+		http.Error(w, "invalid norad parameter", http.StatusBadRequest)
+		return
+	}
+
+	params, err := parseTrackParams(r)
+	if err != nil {
+		http.Error(w, "invalid track parameters: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tle, ok := h.store.Get(noradID)
+	if !ok {
+		http.Error(w, "satellite not found", http.StatusNotFound)
+		return
+	}
+
+	period := time.Duration(tle.OrbitalPeriod() * float64(time.Minute))
+	if period <= 0 {
+		http.Error(w, "propagation failed", http.StatusInternalServerError)
+		return
+	}
+
+	past := time.Duration(params.past * float64(period))
+	future := time.Duration(params.future * float64(period))
+
+	if (past+future)/params.step > maxTrackPoints {
+		http.Error(w, fmt.Sprintf("invalid track parameters: %v: max %d", errTrackTooManyPoints, maxTrackPoints),
+			http.StatusBadRequest)
+
+		return
+	}
+
+	track, err := tracker.GenerateGroundTrack(tle, h.now().UTC(), past, future, params.step)
+	if err != nil {
+		http.Error(w, "propagation failed", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, track)
+}
+
+// parseTrackParams читает past, future и step, подставляя значения по умолчанию.
+func parseTrackParams(r *http.Request) (trackParams, error) {
+	query := r.URL.Query()
+	params := trackParams{
+		past:   tracker.DefaultTrackPastPeriods,
+		future: tracker.DefaultTrackFuturePeriods,
+		step:   tracker.DefaultTrackStep,
+	}
+
+	var err error
+
+	if s := query.Get("past"); s != "" {
+		if params.past, err = strconv.ParseFloat(s, 64); err != nil {
+			return params, fmt.Errorf("past: %w", err)
+		}
+	}
+
+	if s := query.Get("future"); s != "" {
+		if params.future, err = strconv.ParseFloat(s, 64); err != nil {
+			return params, fmt.Errorf("future: %w", err)
+		}
+	}
+
+	if s := query.Get("step"); s != "" {
+		if params.step, err = time.ParseDuration(s); err != nil {
+			return params, fmt.Errorf("step: %w", err)
+		}
+	}
+
+	if params.step < minTrackStep || params.step > maxTrackStep {
+		return params, fmt.Errorf("%w: %v", errTrackStepRange, params.step)
+	}
+
+	// Отрицательная проверка записана так, чтобы NaN тоже отклонялся.
+	if !(params.past >= 0 && params.future >= 0 && params.past+params.future <= maxTrackPeriods) {
+		return params, fmt.Errorf("%w: past %g, future %g, max total %g",
+			errTrackPeriodsRange, params.past, params.future, maxTrackPeriods)
+	}
+
+	return params, nil
+}
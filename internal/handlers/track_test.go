@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/art-injener/satellite-scout/internal/tracker"
+)
+
+// serveTrack выполняет запрос через ServeMux, чтобы заполнить параметр пути {norad}.
+func serveTrack(handler *TrackHandler, target string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/satellite/{norad}/track", handler.GetTrack)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+	return w
+}
+
+func TestTrackHandler_GetTrack(t *testing.T) {
+	now := time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)
+
+	handler := NewTrackHandler(newSSETestStore(t))
+	handler.now = func() time.Time { return now }
+
+	// Виток ГСО — сутки: 0.25 витка назад и 0.5 вперёд с шагом 10 минут.
+	w := serveTrack(handler, "/api/satellite/25544/track?past=0.25&future=0.5&step=600s")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != contentTypeJSON {
+		t.Errorf("Content-Type = %q, want %q", ct, contentTypeJSON)
+	}
+
+	var track tracker.GroundTrack
+	if err := json.Unmarshal(w.Body.Bytes(), &track); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if track.NoradID != 25544 || !track.Current.Time.Equal(now) {
+		t.Errorf("track = norad %d at %v, want 25544 at %v", track.NoradID, track.Current.Time, now)
+	}
+
+	count := func(segments [][]tracker.TrackPoint) (n int, first, last time.Time) {
+		for _, segment := range segments {
+			n += len(segment)
+		}
+
+		if n > 0 {
+			first = segments[0][0].Time
+			tail := segments[len(segments)-1]
+			last = tail[len(tail)-1].Time
+		}
+
+		return n, first, last
+	}
+
+	tle, _ := handler.store.Get(25544)
+	period := time.Duration(tle.OrbitalPeriod() * float64(time.Minute))
+
+	pastN, pastFirst, pastLast := count(track.Past)
+	if pastN == 0 || !pastLast.After(pastFirst) || pastLast.After(now) ||
+		pastFirst.Before(now.Add(-period/4-time.Second)) {
+		t.Errorf("past = %d points [%v, %v], want within quarter period before %v", pastN, pastFirst, pastLast, now)
+	}
+
+	futureN, futureFirst, futureLast := count(track.Future)
+	if futureN <= pastN || futureFirst.Before(now) || futureLast.After(now.Add(period/2)) {
+		t.Errorf("future = %d points [%v, %v], want more than past within half period after %v",
+			futureN, futureFirst, futureLast, now)
+	}
+}
+
+func TestTrackHandler_GetTrack_Errors(t *testing.T) {
+	handler := NewTrackHandler(newSSETestStore(t))
+
+	tests := []struct {
+		name   string
+		target string
+		status int
+	}{
+		{name: "unknown satellite", target: "/api/satellite/99999/track", status: http.StatusNotFound},
+		{name: "invalid norad", target: "/api/satellite/iss/track", status: http.StatusBadRequest},
+		{name: "absurd step", target: "/api/satellite/25544/track?step=1000h", status: http.StatusBadRequest},
+		{name: "sub-second step", target: "/api/satellite/25544/track?step=100ms", status: http.StatusBadRequest},
+		{name: "malformed step", target: "/api/satellite/25544/track?step=fast", status: http.StatusBadRequest},
+		{name: "negative past", target: "/api/satellite/25544/track?past=-1", status: http.StatusBadRequest},
+		{name: "too many periods", target: "/api/satellite/25544/track?past=5&future=6", status: http.StatusBadRequest},
+		{name: "too many points", target: "/api/satellite/25544/track?past=3&future=3&step=1s", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveTrack(handler, tt.target)
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
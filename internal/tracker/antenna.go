@@ -0,0 +1,170 @@
+package tracker
+
+import (
+	"math"
+	"time"
+)
+
+// MeanLookAngle возвращает приблизительное направление (азимут и угол места, градусы)
+// для неподвижной антенны, нацеленной на «типичное» положение спутника.
+//
+// Это приближение, а не расчёт пролёта: спутник считается находящимся на сфере
+// радиуса большой полуоси, на меридиане наблюдателя, на широте наблюдателя,
+// ограниченной наклонением орбиты. Для ГСО это точка экватора (на юг в северном
+// полушарии), для наблюдателя внутри полосы наклонения — зенит.
+func (obs *Observer) MeanLookAngle(tle *TLE) (azDeg, elDeg float64) {
+	if obs == nil || tle == nil || tle.MeanMotion <= 0 {
+		return math.NaN(), math.NaN()
+	}
+
+	// Максимальная широта подспутниковой точки (для ретроградных орбит 180° − i).
+	maxLat := tle.Inclination
+	if maxLat > 90 {
+		maxLat = 180 - maxLat
+	}
+
+	targetLat := math.Max(-maxLat, math.Min(maxLat, obs.Lat))
+	target := LLAToECEF(NewLLAFromDegrees(targetLat, obs.Lon, tle.SemiMajorAxis()-WGS84A))
+
+	aer := ECEFToAER(target, ObserverToECEF(obs), obs.ToLLA())
+
+	return aer.AzDeg(), aer.ElDeg()
+}
+
+// MaxSlewRate возвращает максимальные угловые скорости по азимуту и углу места (градусы/с)
+// на временном ряде AER, упорядоченном по времени. Скачок азимута через 0°/360°
+// учитывается по кратчайшему направлению. Пары точек с неположительным интервалом
+// времени пропускаются. Вблизи зенита скорость по азимуту резко возрастает.
+func MaxSlewRate(aers []*AER) (azRateDegS, elRateDegS float64) {
+	for i := 1; i < len(aers); i++ {
+		prev, cur := aers[i-1], aers[i]
+		if prev == nil || cur == nil {
+			continue
+		}
+
+		dt := cur.Time.Sub(prev.Time).Seconds()
+		if dt <= 0 {
+			continue
+		}
+
+		dAz := math.Remainder(cur.Az-prev.Az, 2*math.Pi)
+		dEl := cur.El - prev.El
+
+		azRateDegS = math.Max(azRateDegS, math.Abs(dAz)*Rad2Deg/dt)
+		elRateDegS = math.Max(elRateDegS, math.Abs(dEl)*Rad2Deg/dt)
+	}
+
+	return azRateDegS, elRateDegS
+}
+
+// AngularAcceleration возвращает максимальные угловые ускорения по азимуту и углу места
+// (градусы/с²) на временном ряде AER, упорядоченном по времени. Ускорение оценивается
+// второй конечной разностью по трём соседним точкам; скачок азимута через 0°/360°
+// учитывается по кратчайшему направлению. Нужно для настройки быстрых опорно-поворотных
+// устройств: вблизи зенита ускорение по азимуту резко возрастает.
+func AngularAcceleration(aers []*AER) (azAccel, elAccel float64) {
+	for i := 2; i < len(aers); i++ {
+		a, b, c := aers[i-2], aers[i-1], aers[i]
+		if a == nil || b == nil || c == nil {
+			continue
+		}
+
+		dt1 := b.Time.Sub(a.Time).Seconds()
+		dt2 := c.Time.Sub(b.Time).Seconds()
+
+		if dt1 <= 0 || dt2 <= 0 {
+			continue
+		}
+
+		azRate1 := math.Remainder(b.Az-a.Az, 2*math.Pi) / dt1
+		azRate2 := math.Remainder(c.Az-b.Az, 2*math.Pi) / dt2
+		elRate1 := (b.El - a.El) / dt1
+		elRate2 := (c.El - b.El) / dt2
+		dt := (dt1 + dt2) / 2
+
+		azAccel = math.Max(azAccel, math.Abs(azRate2-azRate1)*Rad2Deg/dt)
+		elAccel = math.Max(elAccel, math.Abs(elRate2-elRate1)*Rad2Deg/dt)
+	}
+
+	return azAccel, elAccel
+}
+
+// keyholeSampleStep — шаг выборки азимута при поиске зенитной «замочной скважины».
+const keyholeSampleStep = time.Second
+
+// KeyholeGap возвращает часть пролёта, на которой требуемая скорость поворота по азимуту
+// превышает возможности поворотного устройства maxAzRateDegS (градусы/с). Такая часть
+// лежит вблизи зенита, где азимут разворачивается почти мгновенно, и оператор теряет
+// сопровождение. Скорость оценивается по соседним точкам с шагом 1 с (см. MaxSlewRate).
+// Если ограничение нигде не превышено или рассчитать пролёт не удалось, возвращает nil, false.
+func (pass *Pass) KeyholeGap(obs *Observer, prop *Propagator, maxAzRateDegS float64) (*TimeWindow, bool) {
+	if pass == nil || obs == nil || prop == nil || !pass.LOS.After(pass.AOS) {
+		return nil, false
+	}
+
+	var (
+		gap   *TimeWindow
+		prev  *AER
+		prevT time.Time
+	)
+
+	for t := pass.AOS; ; t = t.Add(keyholeSampleStep) {
+		t = minTime(t, pass.LOS)
+
+		pos, err := prop.propagatePrecise(t)
+		if err != nil {
+			return nil, false
+		}
+
+		aer := obs.GetAER(pos)
+
+		if prev != nil {
+			dAz := math.Remainder(aer.Az-prev.Az, 2*math.Pi)
+			if math.Abs(dAz)*Rad2Deg/t.Sub(prevT).Seconds() > maxAzRateDegS {
+				if gap == nil {
+					gap = &TimeWindow{Start: prevT}
+				}
+
+				gap.End = t
+			}
+		}
+
+		if !t.Before(pass.LOS) {
+			break
+		}
+
+		prev, prevT = aer, t
+	}
+
+	return gap, gap != nil
+}
+
+// prePositionLead — упреждение предварительного наведения антенны относительно AOS.
+const prePositionLead = 10 * time.Second
+
+// PrePositionAzimuth возвращает направление, в которое следует заранее развернуть антенну
+// перед пролётом: положение спутника за 10 с до AOS. Азимут близок к азимуту восхода,
+// а угол места немного ниже порога пролёта (для порога 0° — чуть под горизонтом),
+// так что сопровождение начинается без задержки на разворот.
+func (p *Propagator) PrePositionAzimuth(obs *Observer, pass *Pass) (azDeg, elDeg float64, err error) {
+	if p == nil {
+		return 0, 0, ErrNilPropagator
+	}
+
+	if obs == nil {
+		return 0, 0, ErrNilObserver
+	}
+
+	if pass == nil {
+		return 0, 0, ErrNilPass
+	}
+
+	pos, err := p.propagatePrecise(pass.AOS.Add(-prePositionLead))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	aer := obs.GetAER(pos)
+
+	return aer.AzDeg(), aer.ElDeg(), nil
+}
@@ -0,0 +1,221 @@
+package tracker
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestObserver_MeanLookAngle проверяет направление на геостационарную дугу.
+func TestObserver_MeanLookAngle(t *testing.T) {
+	geo := &TLE{Inclination: 0.05, MeanMotion: 1.00273791}
+
+	tests := []struct {
+		name  string
+		obs   *Observer
+		azDeg float64
+		elMin float64
+		elMax float64
+	}{
+		{name: "northern hemisphere looks south", obs: NewObserver(55.75, 37.62, 0), azDeg: 180, elMin: 25, elMax: 28},
+		{name: "southern hemisphere looks north", obs: NewObserver(-33.87, 151.21, 0), azDeg: 0, elMin: 48, elMax: 52},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			az, el := tt.obs.MeanLookAngle(geo)
+
+			azErr := math.Abs(NormalizeLongitude(az - tt.azDeg))
+			if azErr > 0.5 {
+				t.Errorf("az = %.2f°, want ~%.0f°", az, tt.azDeg)
+			}
+
+			if el < tt.elMin || el > tt.elMax {
+				t.Errorf("el = %.2f°, want %.0f-%.0f°", el, tt.elMin, tt.elMax)
+			}
+		})
+	}
+
+	// Наблюдатель внутри полосы наклонения LEO — антенна в зенит.
+	leo := &TLE{Inclination: 51.6, MeanMotion: 15.5}
+	if _, el := NewObserver(45, 10, 0).MeanLookAngle(leo); el < 89 {
+		t.Errorf("el inside inclination band = %.2f°, want ~90°", el)
+	}
+
+	if az, _ := NewObserver(45, 10, 0).MeanLookAngle(nil); !math.IsNaN(az) {
+		t.Error("MeanLookAngle(nil) should return NaN")
+	}
+}
+
+// slewTestSamples возвращает ряды AER МКС для зенитного и низкого пролётов.
+func slewTestSamples(t *testing.T) (overheadAERs, grazingAERs []*AER) {
+	t.Helper()
+
+	prop := createTestPropagator(t)
+
+	pos, err := prop.Propagate(passTestStart)
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	sub := ECEFToLLA(ECIToECEF(pos))
+	overhead := NewObserver(sub.LatDeg(), sub.LonDeg(), 0)
+	grazing := NewObserver(sub.LatDeg()-17, sub.LonDeg(), 0)
+
+	positions, err := prop.PropagateRange(passTestStart.Add(-10*time.Minute), passTestStart.Add(10*time.Minute), time.Second)
+	if err != nil {
+		t.Fatalf("PropagateRange() error = %v", err)
+	}
+
+	sample := func(obs *Observer) []*AER {
+		var aers []*AER
+		for _, p := range positions {
+			if aer := obs.GetAER(p); aer.El > 0 {
+				aers = append(aers, aer)
+			}
+		}
+
+		if len(aers) < 3 {
+			t.Fatalf("observer %+v: satellite not above horizon", obs)
+		}
+
+		return aers
+	}
+
+	return sample(overhead), sample(grazing)
+}
+
+// TestMaxSlewRate проверяет, что зенитный пролёт требует намного большей
+// скорости поворота по азимуту, чем низкий пролёт у горизонта.
+func TestMaxSlewRate(t *testing.T) {
+	t.Parallel()
+
+	overheadAERs, grazingAERs := slewTestSamples(t)
+
+	overheadAz, overheadEl := MaxSlewRate(overheadAERs)
+	grazingAz, _ := MaxSlewRate(grazingAERs)
+
+	if overheadAz < 10*grazingAz {
+		t.Errorf("overhead az rate %.3f°/s should be much higher than grazing %.3f°/s", overheadAz, grazingAz)
+	}
+
+	if overheadEl <= 0 || overheadEl > 5 {
+		t.Errorf("overhead el rate = %.3f°/s, expected 0-5°/s", overheadEl)
+	}
+
+	if az, el := MaxSlewRate(nil); az != 0 || el != 0 {
+		t.Errorf("MaxSlewRate(nil) = %v, %v, want 0, 0", az, el)
+	}
+}
+
+// TestAngularAcceleration проверяет всплеск ускорения по азимуту при зенитном пролёте
+// и его отсутствие при низком пролёте.
+func TestAngularAcceleration(t *testing.T) {
+	t.Parallel()
+
+	overheadAERs, grazingAERs := slewTestSamples(t)
+
+	overheadAz, _ := AngularAcceleration(overheadAERs)
+	grazingAz, grazingEl := AngularAcceleration(grazingAERs)
+
+	if grazingAz > 0.1 || grazingEl > 0.1 {
+		t.Errorf("grazing pass acceleration = %.4f, %.4f °/s², expected smooth motion", grazingAz, grazingEl)
+	}
+
+	if overheadAz < 100*grazingAz {
+		t.Errorf("overhead az acceleration %.3f°/s² should spike far above grazing %.5f°/s²", overheadAz, grazingAz)
+	}
+
+	if az, el := AngularAcceleration(overheadAERs[:2]); az != 0 || el != 0 {
+		t.Errorf("AngularAcceleration() with two samples = %v, %v, want 0, 0", az, el)
+	}
+}
+
+// TestPass_KeyholeGap проверяет, что почти зенитный пролёт содержит участок,
+// недоступный поворотному устройству, а низкий — нет.
+func TestPass_KeyholeGap(t *testing.T) {
+	t.Parallel()
+
+	const maxAzRateDegS = 3.0 // Типичное любительское ОПУ.
+
+	prop := createTestPropagator(t)
+
+	// Наблюдатель в 1° от трассы МКС: максимальный угол места ~83°.
+	at := passTestStart.Add(100 * time.Minute)
+
+	pos, err := prop.Propagate(at)
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	sub := ECEFToLLA(ECIToECEF(pos))
+	overheadObs := NewObserver(sub.Lat*Rad2Deg, sub.Lon*Rad2Deg+1, 0)
+
+	overhead, err := prop.NextPass(overheadObs, at.Add(-15*time.Minute), 0)
+	if err != nil {
+		t.Fatalf("NextPass() error = %v", err)
+	}
+
+	low, err := prop.NextPass(passTestMoscow, passTestStart.Add(3*time.Hour), 0)
+	if err != nil {
+		t.Fatalf("NextPass() error = %v", err)
+	}
+
+	if overhead.MaxElDeg < 80 || low.MaxElDeg > 30 {
+		t.Fatalf("max elevations %.1f° and %.1f°, want > 80° and < 30°", overhead.MaxElDeg, low.MaxElDeg)
+	}
+
+	gap, ok := overhead.KeyholeGap(overheadObs, prop, maxAzRateDegS)
+	if !ok {
+		t.Fatal("KeyholeGap() for overhead pass = false, want gap")
+	}
+
+	if gap.Start.After(overhead.TCA) || gap.End.Before(overhead.TCA) {
+		t.Errorf("gap %v–%v does not contain TCA %v", gap.Start, gap.End, overhead.TCA)
+	}
+
+	if gap.Duration() <= 0 || gap.Duration() > time.Minute {
+		t.Errorf("gap duration = %v, want (0, 1m]", gap.Duration())
+	}
+
+	if gap, ok := low.KeyholeGap(passTestMoscow, prop, maxAzRateDegS); ok {
+		t.Errorf("KeyholeGap() for low pass = %v–%v, want none", gap.Start, gap.End)
+	}
+
+	if _, ok := overhead.KeyholeGap(overheadObs, prop, 90); ok {
+		t.Error("KeyholeGap() with 90°/s rotator should find no gap")
+	}
+}
+
+// TestPropagator_PrePositionAzimuth проверяет, что направление предварительного наведения
+// совпадает с азимутом восхода, а угол места немного ниже порога пролёта.
+func TestPropagator_PrePositionAzimuth(t *testing.T) {
+	t.Parallel()
+
+	const minElDeg = 10.0
+
+	prop := createTestPropagator(t)
+
+	pass, err := prop.NextPass(passTestMoscow, passTestStart, minElDeg)
+	if err != nil {
+		t.Fatalf("NextPass() error = %v", err)
+	}
+
+	azDeg, elDeg, err := prop.PrePositionAzimuth(passTestMoscow, pass)
+	if err != nil {
+		t.Fatalf("PrePositionAzimuth() error = %v", err)
+	}
+
+	if dAz := math.Abs(math.Remainder(azDeg-pass.RiseAzDeg, 360)); dAz > 2 {
+		t.Errorf("azimuth = %.2f°, rise azimuth %.2f°, difference %.2f° > 2°", azDeg, pass.RiseAzDeg, dAz)
+	}
+
+	if elDeg >= minElDeg || elDeg < minElDeg-2 {
+		t.Errorf("elevation = %.2f°, want slightly below %.0f°", elDeg, minElDeg)
+	}
+
+	if _, _, err := prop.PrePositionAzimuth(passTestMoscow, nil); !errors.Is(err, ErrNilPass) {
+		t.Errorf("PrePositionAzimuth(nil pass) error = %v, want ErrNilPass", err)
+	}
+}
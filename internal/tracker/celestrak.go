@@ -1,11 +1,14 @@
 package tracker
 
 import (
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 )
@@ -26,6 +29,10 @@ const (
 
 	// errMsgParsingTLE сообщение об ошибке парсинга TLE.
 	errMsgParsingTLE = "parsing TLE: %w"
+
+	// maxResponseBytes предельный размер ответа после распаковки (защита от zip-бомб).
+	// Полный каталог active в формате TLE занимает несколько МБ.
+	maxResponseBytes = 64 << 20
 )
 
 // Ошибки Celestrak клиента.
@@ -35,6 +42,21 @@ var (
 	ErrCelestrakServerError      = errors.New("server error")
 	ErrCelestrakUnexpectedStatus = errors.New("unexpected HTTP status")
 	ErrCelestrakFetchGroups      = errors.New("errors fetching groups")
+	ErrUnsupportedFormat         = errors.New("unsupported Celestrak format")
+	ErrResponseTooLarge          = errors.New("response exceeds size limit")
+	ErrUnsupportedEncoding       = errors.New("unsupported Content-Encoding")
+	ErrNotModified               = errors.New("not modified since last fetch (304)")
+)
+
+// CelestrakFormat формат данных, запрашиваемый у Celestrak (параметр FORMAT).
+type CelestrakFormat string
+
+// Поддерживаемые форматы Celestrak.
+const (
+	FormatTLE  CelestrakFormat = "TLE"  // Классический двухстрочный формат.
+	FormatJSON CelestrakFormat = "JSON" // OMM в JSON.
+	FormatXML  CelestrakFormat = "XML"  // OMM в XML (NDM).
+	FormatCSV  CelestrakFormat = "CSV"  // OMM в CSV.
 )
 
 // SatelliteGroup предустановленные группы спутников Celestrak.
@@ -84,8 +106,18 @@ type CelestrakClient struct {
 	baseURL     string
 	rateLimit   time.Duration
 	maxRetries  int
+	format      CelestrakFormat
 	lastRequest time.Time
 	mu          sync.Mutex
+
+	validatorsMu sync.Mutex
+	validators   map[SatelliteGroup]httpValidators // Валидаторы последнего ответа 200 по группе.
+}
+
+// httpValidators — валидаторы HTTP кэша для условного запроса.
+type httpValidators struct {
+	etag         string
+	lastModified string
 }
 
 // CelestrakOption функция настройки клиента.
@@ -119,6 +151,14 @@ func WithBaseURL(url string) CelestrakOption {
 	}
 }
 
+// WithFormat устанавливает формат загрузки (по умолчанию FormatTLE).
+// Форматы OMM (JSON, XML, CSV) содержат дополнительные поля и проще валидируются.
+func WithFormat(format CelestrakFormat) CelestrakOption {
+	return func(c *CelestrakClient) {
+		c.format = format
+	}
+}
+
 // NewCelestrakClient создаёт новый клиент Celestrak.
 func NewCelestrakClient(opts ...CelestrakOption) *CelestrakClient {
 	c := &CelestrakClient{
@@ -128,6 +168,8 @@ func NewCelestrakClient(opts ...CelestrakOption) *CelestrakClient {
 		baseURL:    CelestrakBaseURL,
 		rateLimit:  DefaultRateLimit,
 		maxRetries: DefaultMaxRetries,
+		format:     FormatTLE,
+		validators: make(map[SatelliteGroup]httpValidators),
 	}
 
 	for _, opt := range opts {
@@ -139,14 +181,14 @@ func NewCelestrakClient(opts ...CelestrakOption) *CelestrakClient {
 
 // FetchByNoradID загружает TLE по NORAD ID.
 func (c *CelestrakClient) FetchByNoradID(ctx context.Context, noradID int) (*TLE, error) {
-	url := fmt.Sprintf("%s?CATNR=%d&FORMAT=TLE", c.baseURL, noradID)
+	url := fmt.Sprintf("%s?CATNR=%d&FORMAT=%s", c.baseURL, noradID, c.format)
 
-	data, err := c.fetch(ctx, url)
+	data, _, err := c.fetch(ctx, url, httpValidators{})
 	if err != nil {
 		return nil, fmt.Errorf("fetching NORAD ID %d: %w", noradID, err)
 	}
 
-	tles, err := ParseTLEBatch(data)
+	tles, err := c.parse(data)
 	if err != nil {
 		return nil, fmt.Errorf(errMsgParsingTLE, err)
 	}
@@ -158,31 +200,62 @@ func (c *CelestrakClient) FetchByNoradID(ctx context.Context, noradID int) (*TLE
 	return tles[0], nil
 }
 
-// FetchGroup загружает TLE для группы спутников.
+// FetchGroup загружает TLE для группы спутников. Запрос безусловный;
+// валидаторы ответа доступны через GroupValidators.
 func (c *CelestrakClient) FetchGroup(ctx context.Context, group SatelliteGroup) ([]*TLE, error) {
-	url := fmt.Sprintf("%s?GROUP=%s&FORMAT=TLE", c.baseURL, group)
+	return c.fetchGroup(ctx, group, httpValidators{})
+}
+
+// FetchGroupIfModified загружает группу условным запросом (If-None-Match /
+// If-Modified-Since) с валидаторами ранее полученных данных. Если данные на сервере
+// не изменились, возвращается ErrNotModified — вызывающий должен иметь эти данные.
+func (c *CelestrakClient) FetchGroupIfModified(ctx context.Context, group SatelliteGroup, etag, lastModified string) ([]*TLE, error) {
+	return c.fetchGroup(ctx, group, httpValidators{etag: etag, lastModified: lastModified})
+}
 
-	data, err := c.fetch(ctx, url)
+// fetchGroup загружает группу и запоминает валидаторы успешного ответа.
+func (c *CelestrakClient) fetchGroup(ctx context.Context, group SatelliteGroup, cond httpValidators) ([]*TLE, error) {
+	data, validators, err := c.fetch(ctx, c.groupURL(group), cond)
 	if err != nil {
 		return nil, fmt.Errorf("fetching group %s: %w", group, err)
 	}
 
-	tles, err := ParseTLEBatch(data)
+	tles, err := c.parse(data)
 	if err != nil {
 		return nil, fmt.Errorf(errMsgParsingTLE, err)
 	}
 
+	c.validatorsMu.Lock()
+	c.validators[group] = validators
+	c.validatorsMu.Unlock()
+
 	return tles, nil
 }
 
+// GroupValidators возвращает ETag и Last-Modified последнего успешного ответа для группы.
+// Пустые строки — группа ещё не загружалась или сервер не прислал валидаторы.
+func (c *CelestrakClient) GroupValidators(group SatelliteGroup) (etag, lastModified string) {
+	c.validatorsMu.Lock()
+	defer c.validatorsMu.Unlock()
+
+	v := c.validators[group]
+
+	return v.etag, v.lastModified
+}
+
+// groupURL возвращает URL запроса группы в текущем формате.
+func (c *CelestrakClient) groupURL(group SatelliteGroup) string {
+	return fmt.Sprintf("%s?GROUP=%s&FORMAT=%s", c.baseURL, group, c.format)
+}
+
 // FetchURL загружает TLE по произвольному URL.
 func (c *CelestrakClient) FetchURL(ctx context.Context, url string) ([]*TLE, error) {
-	data, err := c.fetch(ctx, url)
+	data, _, err := c.fetch(ctx, url, httpValidators{})
 	if err != nil {
 		return nil, fmt.Errorf("fetching URL %s: %w", url, err)
 	}
 
-	tles, err := ParseTLEBatch(data)
+	tles, err := c.parse(data)
 	if err != nil {
 		return nil, fmt.Errorf(errMsgParsingTLE, err)
 	}
@@ -225,8 +298,25 @@ func (c *CelestrakClient) FetchMultipleGroups(ctx context.Context, groups []Sate
 	return allTLEs, nil
 }
 
-// fetch выполняет HTTP запрос с rate limiting и retry.
-func (c *CelestrakClient) fetch(ctx context.Context, url string) (string, error) {
+// parse разбирает ответ Celestrak в соответствии с выбранным форматом.
+func (c *CelestrakClient) parse(data string) ([]*TLE, error) {
+	switch c.format {
+	case FormatTLE:
+		return ParseTLEBatch(data)
+	case FormatJSON:
+		return ParseOMMJSON([]byte(data))
+	case FormatXML:
+		return ParseOMMXML([]byte(data))
+	case FormatCSV:
+		return ParseOMMCSV([]byte(data))
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, c.format)
+	}
+}
+
+// fetch выполняет HTTP запрос с rate limiting и retry. Непустые валидаторы cond
+// делают запрос условным; возвращаются тело и валидаторы ответа.
+func (c *CelestrakClient) fetch(ctx context.Context, url string, cond httpValidators) (string, httpValidators, error) {
 	c.waitForRateLimit()
 
 	var lastErr error
@@ -240,25 +330,25 @@ func (c *CelestrakClient) fetch(ctx context.Context, url string) (string, error)
 			backoff := time.Duration(1<<uint(attemptVal)) * time.Second //nolint:gosec // attemptVal проверен выше
 			select {
 			case <-ctx.Done():
-				return "", ctx.Err()
+				return "", httpValidators{}, ctx.Err()
 			case <-time.After(backoff):
 			}
 		}
 
-		data, err := c.doRequest(ctx, url)
+		data, validators, err := c.doRequest(ctx, url, cond)
 		if err == nil {
-			return data, nil
+			return data, validators, nil
 		}
 
 		lastErr = err
 
-		// Не повторяем при 404
-		if errors.Is(err, ErrCelestrakNotFound) {
-			return "", err
+		// Не повторяем при 404 и 304
+		if errors.Is(err, ErrCelestrakNotFound) || errors.Is(err, ErrNotModified) {
+			return "", httpValidators{}, err
 		}
 	}
 
-	return "", fmt.Errorf("after %d retries: %w", c.maxRetries, lastErr)
+	return "", httpValidators{}, fmt.Errorf("after %d retries: %w", c.maxRetries, lastErr)
 }
 
 // waitForRateLimit ждёт соблюдения rate limit.
@@ -274,17 +364,26 @@ func (c *CelestrakClient) waitForRateLimit() {
 }
 
 // doRequest выполняет один HTTP запрос.
-func (c *CelestrakClient) doRequest(ctx context.Context, url string) (string, error) {
+func (c *CelestrakClient) doRequest(ctx context.Context, url string, cond httpValidators) (string, httpValidators, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return "", fmt.Errorf("creating request: %w", err)
+		return "", httpValidators{}, fmt.Errorf("creating request: %w", err)
 	}
 
 	req.Header.Set("User-Agent", "Satellite Scout/1.0 (https://github.com/art-injener/satellite-scout)")
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	if cond.etag != "" {
+		req.Header.Set("If-None-Match", cond.etag)
+	}
+
+	if cond.lastModified != "" {
+		req.Header.Set("If-Modified-Since", cond.lastModified)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("executing request: %w", err)
+		return "", httpValidators{}, fmt.Errorf("executing request: %w", err)
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
@@ -296,29 +395,74 @@ func (c *CelestrakClient) doRequest(ctx context.Context, url string) (string, er
 	switch resp.StatusCode {
 	case http.StatusOK:
 		// OK
+	case http.StatusNotModified:
+		return "", httpValidators{}, ErrNotModified
 	case http.StatusNotFound:
-		return "", ErrCelestrakNotFound
+		return "", httpValidators{}, ErrCelestrakNotFound
 	case http.StatusTooManyRequests:
-		return "", ErrCelestrakRateLimit
+		return "", httpValidators{}, ErrCelestrakRateLimit
 	default:
 		if resp.StatusCode >= 500 {
-			return "", fmt.Errorf("%w: %d", ErrCelestrakServerError, resp.StatusCode)
+			return "", httpValidators{}, fmt.Errorf("%w: %d", ErrCelestrakServerError, resp.StatusCode)
 		}
 
-		return "", fmt.Errorf("%w: %d", ErrCelestrakUnexpectedStatus, resp.StatusCode)
+		return "", httpValidators{}, fmt.Errorf("%w: %d", ErrCelestrakUnexpectedStatus, resp.StatusCode)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := readResponseBody(resp)
 	if err != nil {
-		return "", fmt.Errorf("reading response: %w", err)
+		return "", httpValidators{}, err
 	}
 
 	// Celestrak возвращает "No GP data found" при отсутствии данных
 	if string(body) == "No GP data found" {
-		return "", ErrCelestrakNotFound
+		return "", httpValidators{}, ErrCelestrakNotFound
+	}
+
+	return string(body), httpValidators{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// readResponseBody читает тело ответа, распаковывая gzip и deflate по Content-Encoding.
+// Размер распакованных данных ограничен maxResponseBytes.
+func readResponseBody(resp *http.Response) ([]byte, error) {
+	var reader io.Reader = resp.Body
+
+	switch encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))); encoding {
+	case "", "identity":
+		// Без сжатия.
+	case "gzip":
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing gzip response: %w", err)
+		}
+		defer func() { _ = gz.Close() }()
+
+		reader = gz
+	case "deflate":
+		zr, err := zlib.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing deflate response: %w", err)
+		}
+		defer func() { _ = zr.Close() }()
+
+		reader = zr
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedEncoding, encoding)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(reader, maxResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	if len(body) > maxResponseBytes {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, maxResponseBytes)
 	}
 
-	return string(body), nil
+	return body, nil
 }
 
 // GetGroupURL возвращает URL для загрузки группы.
@@ -1,7 +1,12 @@
 package tracker
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -265,3 +270,140 @@ func TestCelestrakClient_RateLimitHeader(t *testing.T) {
 		t.Errorf("NoradID = %d, want 25544", tle.NoradID)
 	}
 }
+
+// compressTestBody сжимает данные в формате encoding (gzip или deflate).
+func compressTestBody(t *testing.T, encoding string, data []byte) []byte {
+	t.Helper()
+
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+	)
+
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	default:
+		t.Fatalf("unknown encoding %q", encoding)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+// TestCelestrakClient_CompressedResponse проверяет распаковку ответов gzip и deflate.
+func TestCelestrakClient_CompressedResponse(t *testing.T) {
+	t.Parallel()
+
+	for _, encoding := range []string{"gzip", "deflate"} {
+		t.Run(encoding, func(t *testing.T) {
+			t.Parallel()
+
+			body := compressTestBody(t, encoding, []byte(issTLE))
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.Contains(r.Header.Get("Accept-Encoding"), encoding) {
+					http.Error(w, "compression not negotiated", http.StatusBadRequest)
+					return
+				}
+
+				w.Header().Set("Content-Encoding", encoding)
+				_, _ = w.Write(body)
+			}))
+			defer server.Close()
+
+			client := NewCelestrakClient(WithBaseURL(server.URL), WithRateLimit(0), WithMaxRetries(0))
+
+			tle, err := client.FetchByNoradID(context.Background(), 25544)
+			if err != nil {
+				t.Fatalf("FetchByNoradID() error = %v", err)
+			}
+
+			if tle.NoradID != 25544 || tle.Name != "ISS (ZARYA)" {
+				t.Errorf("got %d %q, want 25544 ISS (ZARYA)", tle.NoradID, tle.Name)
+			}
+		})
+	}
+}
+
+// TestReadResponseBody_SizeLimit проверяет защиту от zip-бомбы: распакованный
+// ответ больше maxResponseBytes отклоняется.
+func TestReadResponseBody_SizeLimit(t *testing.T) {
+	t.Parallel()
+
+	bomb := compressTestBody(t, "gzip", make([]byte, maxResponseBytes+1))
+
+	resp := &http.Response{
+		Header: http.Header{"Content-Encoding": {"gzip"}},
+		Body:   io.NopCloser(bytes.NewReader(bomb)),
+	}
+
+	if _, err := readResponseBody(resp); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("readResponseBody() error = %v, want ErrResponseTooLarge", err)
+	}
+
+	resp = &http.Response{
+		Header: http.Header{"Content-Encoding": {"br"}},
+		Body:   io.NopCloser(strings.NewReader("")),
+	}
+
+	if _, err := readResponseBody(resp); !errors.Is(err, ErrUnsupportedEncoding) {
+		t.Errorf("readResponseBody(br) error = %v, want ErrUnsupportedEncoding", err)
+	}
+}
+
+// TestCelestrakClient_NotModified проверяет, что условный запрос группы отправляет
+// валидаторы и возвращает ErrNotModified на ответ 304, а обычные запросы безусловны.
+func TestCelestrakClient_NotModified(t *testing.T) {
+	t.Parallel()
+
+	const lastModified = "Mon, 01 Jan 2024 00:00:00 GMT"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte(issTLE))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewCelestrakClient(WithBaseURL(server.URL), WithRateLimit(0), WithMaxRetries(2))
+	ctx := context.Background()
+
+	if _, err := client.FetchGroup(ctx, GroupStations); err != nil {
+		t.Fatalf("FetchGroup() error = %v", err)
+	}
+
+	etag, gotLastModified := client.GroupValidators(GroupStations)
+	if gotLastModified != lastModified {
+		t.Fatalf("GroupValidators() = %q, %q, want Last-Modified %q", etag, gotLastModified, lastModified)
+	}
+
+	if _, err := client.FetchGroupIfModified(ctx, GroupStations, etag, gotLastModified); !errors.Is(err, ErrNotModified) {
+		t.Errorf("FetchGroupIfModified() error = %v, want ErrNotModified", err)
+	}
+
+	// Без явных валидаторов запросы безусловны и всегда возвращают данные.
+	if _, err := client.FetchGroup(ctx, GroupStations); err != nil {
+		t.Errorf("FetchGroup() second error = %v", err)
+	}
+
+	for range 2 {
+		if _, err := client.FetchByNoradID(ctx, 25544); err != nil {
+			t.Errorf("FetchByNoradID() error = %v", err)
+		}
+	}
+}
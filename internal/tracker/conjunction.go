@@ -0,0 +1,102 @@
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrNoConjunction возвращается, если сближение на небе не найдено в окне поиска.
+var ErrNoConjunction = errors.New("no sky conjunction found within search window")
+
+// Параметры поиска сближений на небе.
+const (
+	// conjunctionScanStep — шаг грубого поиска сближения.
+	conjunctionScanStep = 10 * time.Second
+
+	// conjunctionSearchHorizon — максимальная глубина поиска.
+	conjunctionSearchHorizon = 48 * time.Hour
+)
+
+// NextSkyConjunction находит ближайший момент после after, когда угловое расстояние
+// между спутниками a и b на небе наблюдателя становится не больше maxSepDeg,
+// причём оба спутника находятся над горизонтом. Возвращает момент и расстояние в градусах.
+// Поиск ограничен 48 часами.
+func (obs *Observer) NextSkyConjunction(a, b *Propagator, after time.Time, maxSepDeg float64) (time.Time, float64, error) {
+	if obs == nil {
+		return time.Time{}, 0, ErrNilObserver
+	}
+
+	if a == nil || b == nil {
+		return time.Time{}, 0, ErrNilPropagator
+	}
+
+	prev := after
+	until := after.Add(conjunctionSearchHorizon)
+
+	for t := after; !t.After(until); t = t.Add(conjunctionScanStep) {
+		sep, err := obs.skySeparationDeg(a, b, t)
+		if err != nil {
+			return time.Time{}, 0, err
+		}
+
+		if sep <= maxSepDeg {
+			if t.Equal(after) {
+				return t, sep, nil
+			}
+
+			return obs.bisectConjunction(a, b, prev, t, maxSepDeg)
+		}
+
+		prev = t
+	}
+
+	return time.Time{}, 0, fmt.Errorf("%w: %v after %v", ErrNoConjunction, conjunctionSearchHorizon, after)
+}
+
+// bisectConjunction уточняет первый момент на (lo, hi], когда расстояние не больше maxSepDeg.
+func (obs *Observer) bisectConjunction(a, b *Propagator, lo, hi time.Time, maxSepDeg float64) (time.Time, float64, error) {
+	for iter := 0; !a.refine.converged(hi.Sub(lo), iter); iter++ {
+		mid := lo.Add(hi.Sub(lo) / 2)
+
+		sep, err := obs.skySeparationDeg(a, b, mid)
+		if err != nil {
+			return time.Time{}, 0, err
+		}
+
+		if sep <= maxSepDeg {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	sep, err := obs.skySeparationDeg(a, b, hi)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	return hi, sep, nil
+}
+
+// skySeparationDeg возвращает угловое расстояние между спутниками в градусах.
+// Если хотя бы один спутник под горизонтом, возвращает +Inf.
+func (obs *Observer) skySeparationDeg(a, b *Propagator, t time.Time) (float64, error) {
+	posA, err := a.propagatePrecise(t)
+	if err != nil {
+		return 0, err
+	}
+
+	posB, err := b.propagatePrecise(t)
+	if err != nil {
+		return 0, err
+	}
+
+	aerA, aerB := obs.GetAER(posA), obs.GetAER(posB)
+	if aerA.El < 0 || aerB.El < 0 {
+		return math.Inf(1), nil
+	}
+
+	return AngularSeparation(aerA, aerB) * Rad2Deg, nil
+}
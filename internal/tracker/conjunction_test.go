@@ -0,0 +1,62 @@
+package tracker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestObserver_NextSkyConjunction проверяет поиск сближения двух спутников
+// в одной орбитальной плоскости, разнесённых на 1° по средней аномалии.
+func TestObserver_NextSkyConjunction(t *testing.T) {
+	t.Parallel()
+
+	lead := createTestPropagator(t)
+
+	trailingLine2 := makeTLELine("2 25544  51.6400 247.4627 0006703 130.5360 324.0288 15.4981557142340")
+
+	trailingTLE, err := ParseTLE([]string{issLine1, trailingLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	trailing, err := NewPropagator(trailingTLE)
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	const maxSep = 15.0
+
+	// В эпоху ISS уже над горизонтом Москвы, начинаем поиск после окончания этого пролёта.
+	after := passTestStart.Add(30 * time.Minute)
+
+	at, sep, err := passTestMoscow.NextSkyConjunction(lead, trailing, after, maxSep)
+	if err != nil {
+		t.Fatalf("NextSkyConjunction() error = %v", err)
+	}
+
+	if sep > maxSep {
+		t.Errorf("separation = %.2f°, want <= %.0f°", sep, maxSep)
+	}
+
+	// Следующий пролёт ISS над Москвой начинается около 13:26 UTC.
+	if at.Before(passTestStart.Add(80*time.Minute)) || at.After(passTestStart.Add(100*time.Minute)) {
+		t.Errorf("conjunction at %v, expected during the 13:26 pass", at)
+	}
+
+	for _, prop := range []*Propagator{lead, trailing} {
+		pos, err := prop.propagatePrecise(at)
+		if err != nil {
+			t.Fatalf("propagatePrecise() error = %v", err)
+		}
+
+		if el := passTestMoscow.GetAER(pos).ElDeg(); el < 0 {
+			t.Errorf("satellite below horizon at conjunction: el = %.2f°", el)
+		}
+	}
+
+	// Недостижимо малое расстояние — сближение не найдено.
+	if _, _, err := passTestMoscow.NextSkyConjunction(lead, trailing, after, 0); !errors.Is(err, ErrNoConjunction) {
+		t.Errorf("NextSkyConjunction(0°) error = %v, want ErrNoConjunction", err)
+	}
+}
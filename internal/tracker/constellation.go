@@ -0,0 +1,219 @@
+package tracker
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Параметры группировки спутников по орбитальным плоскостям.
+const (
+	// planeTolDeg — допуск по наклонению и долготе восходящего узла для одной плоскости (см. CoPlanar).
+	planeTolDeg = 2.0
+
+	// phaseGapFactor — во сколько раз интервал должен превышать медианный, чтобы считаться пропуском.
+	phaseGapFactor = 1.5
+)
+
+// PhaseInfo описывает положение спутника в орбитальной плоскости группировки.
+type PhaseInfo struct {
+	NoradID       int     `json:"norad_id"`
+	Name          string  `json:"name"`
+	Plane         int     `json:"plane"`           // Номер плоскости (по возрастанию RAAN).
+	RAAN          float64 `json:"raan"`            // Долгота восходящего узла на опорный момент, градусы.
+	ArgOfLatitude float64 `json:"arg_of_latitude"` // Аргумент широты на опорный момент, градусы.
+	SpacingDeg    float64 `json:"spacing_deg"`     // Интервал до следующего спутника плоскости, градусы.
+	Gap           bool    `json:"gap"`             // Интервал заметно больше медианного — вероятен пропуск.
+}
+
+// ConstellationPhase группирует спутники по орбитальным плоскостям (близкие наклонение
+// и RAAN) и рассчитывает интервалы по аргументу широты между соседями в плоскости.
+// Интервал больше медианного в 1,5 раза помечается как пропуск (отсутствующий
+// или вышедший из строя спутник). Элементы приводятся к самой поздней эпохе набора
+// с учётом прецессии узла и движения по орбите. Результат упорядочен по плоскостям
+// и аргументу широты.
+func ConstellationPhase(tles []*TLE) []PhaseInfo {
+	var valid []*TLE
+
+	for _, tle := range tles {
+		if tle != nil && tle.MeanMotion > 0 {
+			valid = append(valid, tle)
+		}
+	}
+
+	if len(valid) == 0 {
+		return nil
+	}
+
+	ref := valid[0].Epoch
+	for _, tle := range valid[1:] {
+		if tle.Epoch.After(ref) {
+			ref = tle.Epoch
+		}
+	}
+
+	infos := make([]PhaseInfo, 0, len(valid))
+	incl := make([]float64, 0, len(valid))
+
+	for _, tle := range valid {
+		raan, u := meanPhaseAt(tle, ref)
+		infos = append(infos, PhaseInfo{NoradID: tle.NoradID, Name: tle.Name, RAAN: raan, ArgOfLatitude: u})
+		incl = append(incl, tle.Inclination)
+	}
+
+	planes := groupPlanes(infos, incl)
+
+	result := make([]PhaseInfo, 0, len(infos))
+	for plane, members := range planes {
+		result = append(result, planeSpacing(plane, members)...)
+	}
+
+	return result
+}
+
+// meanPhaseAt возвращает RAAN и аргумент широты спутника на момент ref (градусы)
+// с учётом вековых возмущений J2.
+func meanPhaseAt(tle *TLE, ref time.Time) (raan, u float64) {
+	dt := ref.Sub(tle.Epoch).Seconds()
+	raanDot, argpDot := tle.j2SecularRates()
+	n := tle.MeanMotion * 2 * math.Pi / 86400
+
+	raan = normalizeDegrees(tle.RAAN + raanDot*dt*Rad2Deg)
+	u = normalizeDegrees(tle.ArgumentOfLatitude() + (n+argpDot)*dt*Rad2Deg)
+
+	return raan, u
+}
+
+// groupPlanes разбивает спутники на плоскости жадной кластеризацией: спутник попадает
+// в первую плоскость, с первым спутником которой он компланарен (CoPlanar) на момент приведения.
+// Плоскости нумеруются по возрастанию RAAN первого спутника.
+func groupPlanes(infos []PhaseInfo, incl []float64) [][]PhaseInfo {
+	order := make([]int, len(infos))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.Slice(order, func(a, b int) bool { return infos[order[a]].RAAN < infos[order[b]].RAAN })
+
+	var (
+		planes    [][]PhaseInfo
+		planeRefs []*TLE
+	)
+
+	for _, idx := range order {
+		info := infos[idx]
+		// Для CoPlanar достаточно наклонения и приведённого к общему моменту RAAN.
+		ref := &TLE{Inclination: incl[idx], RAAN: info.RAAN}
+		assigned := false
+
+		for p := range planes {
+			if CoPlanar(ref, planeRefs[p], planeTolDeg) {
+				planes[p] = append(planes[p], info)
+				assigned = true
+
+				break
+			}
+		}
+
+		if !assigned {
+			planes = append(planes, []PhaseInfo{info})
+			planeRefs = append(planeRefs, ref)
+		}
+	}
+
+	return planes
+}
+
+// planeSpacing упорядочивает спутники плоскости по аргументу широты,
+// рассчитывает интервалы до следующего спутника и помечает пропуски.
+func planeSpacing(plane int, members []PhaseInfo) []PhaseInfo {
+	sort.Slice(members, func(a, b int) bool { return members[a].ArgOfLatitude < members[b].ArgOfLatitude })
+
+	spacings := make([]float64, len(members))
+
+	for i := range members {
+		members[i].Plane = plane
+
+		if len(members) == 1 {
+			members[i].SpacingDeg = 360
+			continue
+		}
+
+		next := members[(i+1)%len(members)]
+		members[i].SpacingDeg = normalizeDegrees(next.ArgOfLatitude - members[i].ArgOfLatitude)
+		spacings[i] = members[i].SpacingDeg
+	}
+
+	if len(members) < 3 {
+		return members
+	}
+
+	sorted := append([]float64(nil), spacings...)
+	sort.Float64s(sorted)
+	median := sorted[len(sorted)/2]
+
+	for i := range members {
+		members[i].Gap = members[i].SpacingDeg > phaseGapFactor*median
+	}
+
+	return members
+}
+
+// constellationRule описывает признаки принадлежности спутника группировке:
+// группы Celestrak и префиксы имени (в верхнем регистре).
+type constellationRule struct {
+	name     string
+	groups   []SatelliteGroup
+	prefixes []string
+}
+
+// constellationRules — известные группировки. Имя проверяется раньше групп,
+// поэтому спутник из общей группы (например, active) тоже классифицируется.
+var constellationRules = []constellationRule{
+	{name: "Starlink", groups: []SatelliteGroup{GroupStarlink}, prefixes: []string{"STARLINK"}},
+	{name: "OneWeb", groups: []SatelliteGroup{GroupOneWeb}, prefixes: []string{"ONEWEB"}},
+	{name: "Iridium", groups: []SatelliteGroup{GroupIridium, GroupIridiumNEXT}, prefixes: []string{"IRIDIUM"}},
+	{name: "Globalstar", groups: []SatelliteGroup{GroupGlobalstar}, prefixes: []string{"GLOBALSTAR"}},
+	{name: "Orbcomm", groups: []SatelliteGroup{GroupOrbcomm}, prefixes: []string{"ORBCOMM"}},
+	{name: "GPS", groups: []SatelliteGroup{GroupGPS}, prefixes: []string{"NAVSTAR", "GPS "}},
+	{name: "GLONASS", groups: []SatelliteGroup{GroupGlonass}},
+	{name: "Galileo", groups: []SatelliteGroup{GroupGalileo}, prefixes: []string{"GALILEO", "GSAT0"}},
+	{name: "BeiDou", groups: []SatelliteGroup{GroupBeidou}, prefixes: []string{"BEIDOU"}},
+	{name: "Planet", groups: []SatelliteGroup{GroupPlanet}, prefixes: []string{"FLOCK", "SKYSAT"}},
+	{name: "Spire", groups: []SatelliteGroup{GroupSpire}, prefixes: []string{"LEMUR"}},
+}
+
+// ConstellationOf возвращает название группировки, к которой относится спутник,
+// по шаблону имени или членству в группе Celestrak. false — спутника нет в каталоге
+// или группировка не распознана.
+func (s *TLEStore) ConstellationOf(noradID int) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tle, ok := s.catalog[noradID]
+	if !ok {
+		return "", false
+	}
+
+	name := normalizeName(tle.Name)
+
+	for _, rule := range constellationRules {
+		for _, prefix := range rule.prefixes {
+			if strings.HasPrefix(name, prefix) {
+				return rule.name, true
+			}
+		}
+	}
+
+	for _, rule := range constellationRules {
+		for _, group := range rule.groups {
+			if slices.Contains(s.byGroup[group], noradID) {
+				return rule.name, true
+			}
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,143 @@
+package tracker
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// constellationTestPlane создаёт синтетическую плоскость из count спутников,
+// равномерно распределённых по средней аномалии; номера из skip пропускаются.
+func constellationTestPlane(firstID, count int, raan float64, skip ...int) []*TLE {
+	epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	skipped := make(map[int]bool, len(skip))
+
+	for _, k := range skip {
+		skipped[k] = true
+	}
+
+	var tles []*TLE
+
+	for k := range count {
+		if skipped[k] {
+			continue
+		}
+
+		tles = append(tles, &TLE{
+			NoradID:      firstID + k,
+			Epoch:        epoch,
+			Inclination:  53,
+			RAAN:         raan,
+			Eccentricity: 0.0001,
+			MeanAnomaly:  float64(k) * 360 / float64(count),
+			MeanMotion:   15.06,
+		})
+	}
+
+	return tles
+}
+
+// TestConstellationPhase проверяет равномерные интервалы в плоскости и обнаружение пропуска.
+func TestConstellationPhase(t *testing.T) {
+	t.Parallel()
+
+	tles := append(constellationTestPlane(1000, 8, 100), constellationTestPlane(2000, 8, 145, 3)...)
+
+	infos := ConstellationPhase(tles)
+	if len(infos) != 15 {
+		t.Fatalf("ConstellationPhase() returned %d entries, want 15", len(infos))
+	}
+
+	gaps := 0
+
+	for _, info := range infos {
+		wantPlane := 0
+		if info.NoradID >= 2000 {
+			wantPlane = 1
+		}
+
+		if info.Plane != wantPlane {
+			t.Errorf("satellite %d in plane %d, want %d", info.NoradID, info.Plane, wantPlane)
+		}
+
+		switch {
+		case info.Gap:
+			gaps++
+
+			if info.NoradID != 2002 || math.Abs(info.SpacingDeg-90) > 0.5 {
+				t.Errorf("unexpected gap after %d: spacing %.2f°", info.NoradID, info.SpacingDeg)
+			}
+		case math.Abs(info.SpacingDeg-45) > 0.5:
+			t.Errorf("satellite %d spacing = %.2f°, want 45°", info.NoradID, info.SpacingDeg)
+		}
+	}
+
+	if gaps != 1 {
+		t.Errorf("found %d gaps, want 1 (missing satellite 2003)", gaps)
+	}
+
+	if ConstellationPhase(nil) != nil {
+		t.Error("ConstellationPhase(nil) should return nil")
+	}
+}
+
+// TestConstellationPhase_Equatorial проверяет, что околоэкваториальные спутники с разным
+// (неопределённым) RAAN попадают в одну плоскость, как в CoPlanar.
+func TestConstellationPhase_Equatorial(t *testing.T) {
+	t.Parallel()
+
+	var tles []*TLE
+
+	for k, raan := range []float64{10, 100, 200, 300} {
+		tle := constellationTestPlane(3000+k, 1, raan)[0]
+		tle.Inclination = 0.05
+		tles = append(tles, tle)
+	}
+
+	for _, info := range ConstellationPhase(tles) {
+		if info.Plane != 0 {
+			t.Errorf("satellite %d in plane %d, want 0", info.NoradID, info.Plane)
+		}
+	}
+}
+
+// TestTLEStore_ConstellationOf проверяет классификацию по имени и по членству в группе.
+func TestTLEStore_ConstellationOf(t *testing.T) {
+	t.Parallel()
+
+	starlink := issVariant(t, "44238", "229.6000")
+	starlink.Name = "STARLINK-1234"
+
+	unnamed := issVariant(t, "44239", "229.6000")
+	unnamed.Name = "OBJECT A"
+
+	station := issVariant(t, "25544", "229.6000")
+
+	store := NewTLEStore()
+	store.replaceGroup(GroupStarlink, []*TLE{starlink})
+	store.replaceGroup(GroupOneWeb, []*TLE{unnamed})
+	store.replaceGroup(GroupStations, []*TLE{station})
+
+	tests := []struct {
+		name    string
+		noradID int
+		want    string
+		wantOK  bool
+	}{
+		{name: "starlink by name and group", noradID: 44238, want: "Starlink", wantOK: true},
+		{name: "oneweb by group only", noradID: 44239, want: "OneWeb", wantOK: true},
+		{name: "station is not a constellation", noradID: 25544},
+		{name: "not in catalog", noradID: 99999},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := store.ConstellationOf(tt.noradID)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ConstellationOf(%d) = %q, %v, want %q, %v", tt.noradID, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -1,10 +1,18 @@
 package tracker
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"time"
 )
 
+// Ошибки валидации координат.
+var (
+	ErrInvalidLatitude  = errors.New("latitude must be within [-90, 90] degrees")
+	ErrInvalidLongitude = errors.New("longitude must be a finite number")
+)
+
 // Константы WGS84 эллипсоида.
 const (
 	// WGS84A — экваториальный радиус Земли (большая полуось), км.
@@ -53,6 +61,12 @@ type AER struct {
 	Az    float64 // Азимут в радианах (от севера по часовой стрелке).
 	El    float64 // Угол места (elevation) в радианах.
 	Range float64 // Дальность до объекта, км.
+
+	// RangeRate — скорость изменения дальности, км/с (положительная — объект удаляется).
+	// Заполняется GetAER по скорости из ECI; ECEFToAER, работающий только с позициями, оставляет 0.
+	RangeRate float64
+
+	Time time.Time // Время расчёта (из позиции спутника).
 }
 
 // Observer представляет позицию наблюдателя на поверхности Земли.
@@ -60,6 +74,8 @@ type Observer struct {
 	Lat float64 // Широта в градусах.
 	Lon float64 // Долгота в градусах.
 	Alt float64 // Высота над уровнем моря, км.
+
+	HorizonMask HorizonMask // Профиль видимого горизонта (nil — математический горизонт).
 }
 
 // ECIToECEF преобразует координаты из ECI (TEME) в ECEF.
@@ -70,7 +86,27 @@ func ECIToECEF(eci *ECIPosition) *ECEFPosition {
 	}
 
 	// Получаем GMST (Greenwich Mean Sidereal Time) в радианах.
-	gmst := GMST(eci.Time)
+	return ECIToECEFWithGMST(eci, GMST(eci.Time))
+}
+
+// gmstRate — скорость роста GMST в модели IAU-82 (см. GMST), рад/с. Позволяет получать
+// GMST равноотстоящих моментов приращением, не пересчитывая его для каждого момента.
+const gmstRate = 1.00273790935 * 2 * math.Pi / 86400
+
+// gmstAfter возвращает GMST момента t по известному GMST gmst0 момента t0. GMST
+// рассчитывается по целым секундам, поэтому приращение берётся тоже по целым секундам.
+func gmstAfter(gmst0 float64, t0, t time.Time) float64 {
+	return gmst0 + gmstRate*float64(t.Unix()-t0.Unix())
+}
+
+// ECIToECEFWithGMST преобразует ECI в ECEF с заранее рассчитанным GMST (радианы).
+// Расчёт GMST заметно дороже самого поворота, поэтому при пакетной обработке
+// многих позиций на один момент времени (созвездие, спутник и Солнце)
+// GMST(t) стоит вычислить один раз и передать сюда.
+func ECIToECEFWithGMST(eci *ECIPosition, gmst float64) *ECEFPosition {
+	if eci == nil {
+		return nil
+	}
 
 	// Поворот вокруг оси Z.
 	cosGMST := math.Cos(gmst)
@@ -91,9 +127,11 @@ func ECEFToECI(ecef *ECEFPosition) *ECIPosition {
 		return nil
 	}
 
-	// Получаем GMST в радианах.
-	gmst := GMST(ecef.Time)
+	return ecefToECIWithGMST(ecef, GMST(ecef.Time))
+}
 
+// ecefToECIWithGMST преобразует ECEF в ECI с заранее рассчитанным GMST (радианы).
+func ecefToECIWithGMST(ecef *ECEFPosition, gmst float64) *ECIPosition {
 	// Обратный поворот вокруг оси Z.
 	cosGMST := math.Cos(gmst)
 	sinGMST := math.Sin(gmst)
@@ -212,9 +250,7 @@ func ECEFToAER(satECEF, obsECEF *ECEFPosition, obsLLA *LLA) *AER {
 	}
 
 	// Вектор от наблюдателя к спутнику в ECEF.
-	dx := satECEF.X - obsECEF.X
-	dy := satECEF.Y - obsECEF.Y
-	dz := satECEF.Z - obsECEF.Z
+	dx, dy, dz := lineOfSightECEF(satECEF, obsECEF)
 
 	// Дальность.
 	rng := math.Sqrt(dx*dx + dy*dy + dz*dz)
@@ -247,9 +283,15 @@ func ECEFToAER(satECEF, obsECEF *ECEFPosition, obsLLA *LLA) *AER {
 		Az:    az,
 		El:    el,
 		Range: rng,
+		Time:  satECEF.Time,
 	}
 }
 
+// lineOfSightECEF возвращает вектор от наблюдателя к спутнику в ECEF (км).
+func lineOfSightECEF(satECEF, obsECEF *ECEFPosition) (dx, dy, dz float64) {
+	return satECEF.X - obsECEF.X, satECEF.Y - obsECEF.Y, satECEF.Z - obsECEF.Z
+}
+
 // NewLLAFromDegrees создаёт LLA из координат в градусах.
 func NewLLAFromDegrees(latDeg, lonDeg, altKm float64) *LLA {
 	return &LLA{
@@ -278,6 +320,31 @@ func NewObserver(latDeg, lonDeg, altKm float64) *Observer {
 	}
 }
 
+// NewObserverValidated создаёт Observer с проверкой координат.
+// Долгота приводится к диапазону [-180, 180), широта вне [-90, 90] — ошибка.
+// В отличие от NewObserver, не допускает координат, дающих неверный результат.
+func NewObserverValidated(latDeg, lonDeg, altKm float64) (*Observer, error) {
+	if math.IsNaN(latDeg) || latDeg < -90 || latDeg > 90 {
+		return nil, fmt.Errorf("%w: got %v", ErrInvalidLatitude, latDeg)
+	}
+
+	if math.IsNaN(lonDeg) || math.IsInf(lonDeg, 0) {
+		return nil, fmt.Errorf("%w: got %v", ErrInvalidLongitude, lonDeg)
+	}
+
+	return NewObserver(latDeg, NormalizeLongitude(lonDeg), altKm), nil
+}
+
+// NormalizeLongitude приводит долготу в градусах к диапазону [-180, 180).
+func NormalizeLongitude(lonDeg float64) float64 {
+	lon := math.Mod(lonDeg+180, 360)
+	if lon < 0 {
+		lon += 360
+	}
+
+	return lon - 180
+}
+
 // AzDeg возвращает азимут в градусах.
 func (aer *AER) AzDeg() float64 {
 	return aer.Az * Rad2Deg
@@ -307,9 +374,70 @@ func (obs *Observer) GetAER(eci *ECIPosition) *AER {
 		return nil
 	}
 
-	satECEF := ECIToECEF(eci)
+	return obs.aerWithGMST(eci, GMST(eci.Time))
+}
+
+// aerWithGMST вычисляет AER как GetAER с заранее рассчитанным GMST момента eci.Time:
+// при обработке многих спутников на один момент GMST считается один раз.
+func (obs *Observer) aerWithGMST(eci *ECIPosition, gmst float64) *AER {
+	satECEF := ECIToECEFWithGMST(eci, gmst)
 	obsECEF := ObserverToECEF(obs)
 	obsLLA := obs.ToLLA()
 
-	return ECEFToAER(satECEF, obsECEF, obsLLA)
+	aer := ECEFToAER(satECEF, obsECEF, obsLLA)
+	aer.RangeRate = rangeRate(eci, obsECEF, gmst)
+
+	return aer
+}
+
+// rangeRate возвращает проекцию относительной скорости спутника на линию визирования, км/с.
+// Скорость наблюдателя в ECI обусловлена вращением Земли: v = ω × r.
+func rangeRate(eci *ECIPosition, obsECEF *ECEFPosition, gmst float64) float64 {
+	obsPos := *obsECEF
+	obsPos.Time = eci.Time
+	obsECI := eciVec(ecefToECIWithGMST(&obsPos, gmst))
+	obsVel := vec3{X: -OmegaEarth * obsECI.Y, Y: OmegaEarth * obsECI.X}
+
+	los := eciVec(eci).sub(obsECI)
+	if los.norm() == 0 {
+		return 0
+	}
+
+	return eciVelocity(eci).sub(obsVel).dot(los.unit())
+}
+
+// PointingVectorECEF возвращает единичный вектор направления от наблюдателя
+// на спутник в ECEF — исходные данные для электронного управления лучом
+// фазированной антенной решётки. Для nil-аргументов или совпадения позиций
+// возвращает нулевой вектор.
+func (obs *Observer) PointingVectorECEF(sat *ECIPosition) (ux, uy, uz float64) {
+	if obs == nil || sat == nil {
+		return 0, 0, 0
+	}
+
+	dx, dy, dz := lineOfSightECEF(ECIToECEF(sat), ObserverToECEF(obs))
+	u := vec3{dx, dy, dz}.unit()
+
+	return u.X, u.Y, u.Z
+}
+
+// AngularSeparation возвращает угловое расстояние между двумя направлениями
+// на небе наблюдателя, заданными азимутом и углом места, в радианах.
+func AngularSeparation(a, b *AER) float64 {
+	if a == nil || b == nil {
+		return math.NaN()
+	}
+
+	return angleBetween(a.enuUnit(), b.enuUnit())
+}
+
+// enuUnit возвращает единичный вектор направления в топоцентрической системе ENU.
+func (aer *AER) enuUnit() vec3 {
+	cosEl := math.Cos(aer.El)
+
+	return vec3{
+		X: cosEl * math.Sin(aer.Az),
+		Y: cosEl * math.Cos(aer.Az),
+		Z: math.Sin(aer.El),
+	}
 }
@@ -249,6 +249,45 @@ func TestObserverGetAER(t *testing.T) {
 	}
 }
 
+// TestObserver_PointingVectorECEF проверяет единичную длину вектора наведения
+// и совпадение восстановленных по нему азимута и угла места с GetAER.
+func TestObserver_PointingVectorECEF(t *testing.T) {
+	t.Parallel()
+
+	observer := NewObserver(55.7558, 37.6173, 0.156)
+	eci := &ECIPosition{
+		X: -4400.594, Y: 1932.870, Z: 4760.712,
+		Time: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+	}
+
+	ux, uy, uz := observer.PointingVectorECEF(eci)
+	if norm := math.Sqrt(ux*ux + uy*uy + uz*uz); !almostEqual(norm, 1, 1e-12) {
+		t.Errorf("|PointingVectorECEF()| = %v, want 1", norm)
+	}
+
+	// Проекция на локальные оси ENU наблюдателя.
+	lla := observer.ToLLA()
+	sinLat, cosLat := math.Sin(lla.Lat), math.Cos(lla.Lat)
+	sinLon, cosLon := math.Sin(lla.Lon), math.Cos(lla.Lon)
+
+	e := -sinLon*ux + cosLon*uy
+	n := -sinLat*cosLon*ux - sinLat*sinLon*uy + cosLat*uz
+	u := cosLat*cosLon*ux + cosLat*sinLon*uy + sinLat*uz
+
+	az := math.Mod(math.Atan2(e, n)+2*math.Pi, 2*math.Pi)
+	el := math.Asin(u)
+
+	aer := observer.GetAER(eci)
+	if !almostEqual(az, aer.Az, 1e-9) || !almostEqual(el, aer.El, 1e-9) {
+		t.Errorf("az/el from vector = %.6f°, %.6f°, GetAER = %.6f°, %.6f°",
+			az*Rad2Deg, el*Rad2Deg, aer.AzDeg(), aer.ElDeg())
+	}
+
+	if x, y, z := observer.PointingVectorECEF(nil); x != 0 || y != 0 || z != 0 {
+		t.Errorf("PointingVectorECEF(nil) = %v, %v, %v, want zero vector", x, y, z)
+	}
+}
+
 // TestNilInputs проверяет обработку nil входных данных.
 func TestNilInputs(t *testing.T) {
 	if ECIToECEF(nil) != nil {
@@ -361,6 +400,34 @@ func BenchmarkECIToECEF(b *testing.B) {
 	}
 }
 
+// BenchmarkECIToECEF_SharedGMST сравнивает преобразование позиций созвездия
+// на один момент времени с пересчётом GMST и с общим GMST.
+func BenchmarkECIToECEF_SharedGMST(b *testing.B) {
+	testTime := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	positions := make([]*ECIPosition, 64)
+	for i := range positions {
+		positions[i] = &ECIPosition{X: -4400.594 + float64(i), Y: 1932.870, Z: 4760.712, Time: testTime}
+	}
+
+	b.Run("per-position", func(b *testing.B) {
+		for b.Loop() {
+			for _, eci := range positions {
+				ECIToECEF(eci)
+			}
+		}
+	})
+
+	b.Run("shared", func(b *testing.B) {
+		for b.Loop() {
+			gmst := GMST(testTime)
+			for _, eci := range positions {
+				ECIToECEFWithGMST(eci, gmst)
+			}
+		}
+	})
+}
+
 // BenchmarkECEFToLLA измеряет производительность преобразования ECEF→LLA.
 func BenchmarkECEFToLLA(b *testing.B) {
 	ecef := &ECEFPosition{X: 1000.0, Y: 2000.0, Z: 6000.0}
@@ -383,3 +450,81 @@ func BenchmarkObserverGetAER(b *testing.B) {
 		observer.GetAER(eci)
 	}
 }
+
+// TestNewObserverValidated проверяет нормализацию долготы и проверку широты.
+func TestNewObserverValidated(t *testing.T) {
+	tests := []struct {
+		name    string
+		lat     float64
+		lon     float64
+		wantLon float64
+		wantErr bool
+	}{
+		{name: "regular", lat: 55.75, lon: 37.62, wantLon: 37.62},
+		{name: "lon 270 wraps to -90", lat: 10, lon: 270, wantLon: -90},
+		{name: "lon -190 wraps to 170", lat: 10, lon: -190, wantLon: 170},
+		{name: "lon 540 wraps to -180", lat: 0, lon: 540, wantLon: -180},
+		{name: "north pole", lat: 90, lon: 0, wantLon: 0},
+		{name: "lat 100", lat: 100, lon: 0, wantErr: true},
+		{name: "lat -90.5", lat: -90.5, lon: 0, wantErr: true},
+		{name: "lat NaN", lat: math.NaN(), lon: 0, wantErr: true},
+		{name: "lon Inf", lat: 0, lon: math.Inf(1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obs, err := NewObserverValidated(tt.lat, tt.lon, 0.1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewObserverValidated() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if !almostEqual(obs.Lon, tt.wantLon, toleranceDegree) {
+				t.Errorf("Lon = %v, want %v", obs.Lon, tt.wantLon)
+			}
+
+			if obs.Lat != tt.lat || obs.Alt != 0.1 {
+				t.Errorf("Lat/Alt = %v/%v, want %v/0.1", obs.Lat, obs.Alt, tt.lat)
+			}
+		})
+	}
+}
+
+// TestECIToECEFWithGMST проверяет совпадение с ECIToECEF при переданном GMST.
+func TestECIToECEFWithGMST(t *testing.T) {
+	t.Parallel()
+
+	eci := &ECIPosition{
+		X: -4400.594, Y: 1932.870, Z: 4760.712,
+		Time: time.Date(2024, 1, 15, 12, 34, 56, 0, time.UTC),
+	}
+
+	want := ECIToECEF(eci)
+	got := ECIToECEFWithGMST(eci, GMST(eci.Time))
+
+	if *got != *want {
+		t.Errorf("ECIToECEFWithGMST() = %+v, want %+v", got, want)
+	}
+
+	if ECIToECEFWithGMST(nil, 0) != nil {
+		t.Error("ECIToECEFWithGMST(nil) should return nil")
+	}
+}
+
+// TestGMSTAfter проверяет, что приращение GMST совпадает с прямым расчётом
+// на интервале в несколько суток, в том числе для моментов с долями секунды.
+func TestGMSTAfter(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 15, 12, 34, 56, 700_000_000, time.UTC)
+	gmst0 := GMST(start)
+
+	for tm := start; tm.Before(start.Add(72 * time.Hour)); tm = tm.Add(17*time.Minute + 300*time.Millisecond) {
+		if diff := math.Remainder(gmstAfter(gmst0, start, tm)-GMST(tm), 2*math.Pi); math.Abs(diff) > 1e-8 {
+			t.Fatalf("gmstAfter(%v) differs from GMST() by %g rad", tm, diff)
+		}
+	}
+}
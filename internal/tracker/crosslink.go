@@ -0,0 +1,70 @@
+package tracker
+
+import "math"
+
+// SpaceObserverAER вычисляет направление и дальность от спутника-наблюдателя
+// (например, ретранслятора) до другого спутника. Используется та же система ENU,
+// что и для наземного наблюдателя (см. ECEFToAER), но с началом в точке спутника:
+// «вверх» — по радиус-вектору наблюдателя, «север» — к северному полюсу мира
+// в местной горизонтальной плоскости. Угол места отсчитывается от местного горизонта
+// спутника; отрицательный угол не означает затенения Землёй — для этого см. HasLineOfSight.
+// RangeRate — скорость изменения дальности по скоростям ECI обоих спутников.
+// Расчёт выполняется в ECI, поэтому вращение Земли не учитывается.
+func SpaceObserverAER(observerECI, targetECI *ECIPosition) *AER {
+	if observerECI == nil || targetECI == nil {
+		return nil
+	}
+
+	origin := eciVec(observerECI)
+	los := eciVec(targetECI).sub(origin)
+	rng := los.norm()
+
+	up := origin.unit()
+
+	// Над полюсом мира направление на север не определено: отсчитываем азимут от оси X.
+	east := vec3{Z: 1}.cross(up).unit()
+	if east.norm() == 0 {
+		east = vec3{Y: 1}
+	}
+
+	north := up.cross(east)
+
+	az := math.Atan2(los.dot(east), los.dot(north))
+	if az < 0 {
+		az += 2 * math.Pi
+	}
+
+	aer := &AER{
+		Az:    az,
+		El:    math.Asin(los.dot(up) / rng),
+		Range: rng,
+		Time:  targetECI.Time,
+	}
+
+	if rng > 0 {
+		aer.RangeRate = los.dot(eciVelocity(targetECI).sub(eciVelocity(observerECI))) / rng
+	}
+
+	return aer
+}
+
+// HasLineOfSight проверяет прямую видимость между двумя спутниками: отрезок между ними
+// не должен пересекать Землю, которая моделируется сферой радиуса WGS84A плюс
+// grazingAltKm (запас на атмосферу, обычно 0–100 км).
+func HasLineOfSight(a, b *ECIPosition, grazingAltKm float64) bool {
+	if a == nil || b == nil {
+		return false
+	}
+
+	pa, pb := eciVec(a), eciVec(b)
+	d := pb.sub(pa)
+	radius := WGS84A + grazingAltKm
+
+	// Ближайшая к центру Земли точка отрезка: параметр проекции, ограниченный [0, 1].
+	k := 0.0
+	if dd := d.dot(d); dd > 0 {
+		k = math.Max(0, math.Min(1, -pa.dot(d)/dd))
+	}
+
+	return pa.add(d.scale(k)).norm() > radius
+}
@@ -0,0 +1,69 @@
+package tracker
+
+import (
+	"math"
+	"testing"
+)
+
+// TestSpaceObserverAER проверяет направление от спутника-ретранслятора
+// и прямую видимость между спутниками.
+func TestSpaceObserverAER(t *testing.T) {
+	t.Parallel()
+
+	relay := &ECIPosition{X: 7000, Vy: 7.5}
+
+	tests := []struct {
+		name      string
+		target    *ECIPosition
+		wantAzDeg float64 // NaN — не проверять.
+		wantElDeg float64
+		wantRange float64
+		wantLOS   bool
+	}{
+		{name: "GEO above", target: &ECIPosition{X: 42164}, wantAzDeg: math.NaN(), wantElDeg: 90, wantRange: 35164, wantLOS: true},
+		{name: "north on horizon", target: &ECIPosition{X: 7000, Z: 1000}, wantAzDeg: 0, wantElDeg: 0, wantRange: 1000, wantLOS: true},
+		{name: "east on horizon", target: &ECIPosition{X: 7000, Y: 1000}, wantAzDeg: 90, wantElDeg: 0, wantRange: 1000, wantLOS: true},
+		{name: "behind Earth", target: &ECIPosition{X: -7000}, wantAzDeg: math.NaN(), wantElDeg: -90, wantRange: 14000, wantLOS: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			aer := SpaceObserverAER(relay, tt.target)
+
+			if !math.IsNaN(tt.wantAzDeg) && !almostEqual(aer.AzDeg(), tt.wantAzDeg, 1e-9) {
+				t.Errorf("AzDeg() = %.3f, want %.3f", aer.AzDeg(), tt.wantAzDeg)
+			}
+
+			if !almostEqual(aer.ElDeg(), tt.wantElDeg, 1e-6) {
+				t.Errorf("ElDeg() = %.3f, want %.3f", aer.ElDeg(), tt.wantElDeg)
+			}
+
+			if !almostEqual(aer.Range, tt.wantRange, 1e-9) {
+				t.Errorf("Range = %.3f, want %.3f", aer.Range, tt.wantRange)
+			}
+
+			if got := HasLineOfSight(relay, tt.target, 0); got != tt.wantLOS {
+				t.Errorf("HasLineOfSight() = %v, want %v", got, tt.wantLOS)
+			}
+		})
+	}
+
+	// Ретранслятор движется на восток, к неподвижной цели: дальность сокращается.
+	if aer := SpaceObserverAER(relay, &ECIPosition{X: 7000, Y: 1000}); !almostEqual(aer.RangeRate, -7.5, 1e-9) {
+		t.Errorf("RangeRate = %.3f, want -7.5", aer.RangeRate)
+	}
+
+	// Хорда, проходящая на высоте ~50 км, блокируется атмосферным запасом 100 км.
+	a := &ECIPosition{X: WGS84A + 50, Y: -3000}
+	b := &ECIPosition{X: WGS84A + 50, Y: 3000}
+
+	if !HasLineOfSight(a, b, 0) || HasLineOfSight(a, b, 100) {
+		t.Error("HasLineOfSight() should pass at 0 km and fail with 100 km grazing altitude")
+	}
+
+	if SpaceObserverAER(nil, relay) != nil {
+		t.Error("SpaceObserverAER(nil) should return nil")
+	}
+}
@@ -0,0 +1,123 @@
+package tracker
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"strconv"
+	"sync"
+)
+
+// ErrGeoidDataMissing возвращается DefaultGeoid, если сетка EGM96 не встроена в сборку.
+var ErrGeoidDataMissing = errors.New("embedded EGM96 grid is missing")
+
+// egm96GridPath — путь к встроенной сетке EGM96 шагом 1° в формате WW15MGH.GRD.
+// Файл получается из исходной сетки NGA 15′ утилитой cmd/geoidgrid (make geoid).
+const egm96GridPath = "data/egm96-1deg.grd"
+
+// embeddedData — встроенная директория данных пакета. Встраивается директория целиком,
+// чтобы сборка не зависела от наличия сетки геоида.
+//
+//go:embed data
+var embeddedData embed.FS
+
+// defaultGeoid разбирает встроенную сетку EGM96 один раз за время работы процесса.
+var defaultGeoid = sync.OnceValues(func() (*GridGeoid, error) {
+	f, err := embeddedData.Open(egm96GridPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrGeoidDataMissing, egm96GridPath)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("opening embedded geoid grid: %w", err)
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return ParseWW15MGH(f)
+})
+
+// DefaultGeoid возвращает модель геоида EGM96 по встроенной сетке шагом 1°
+// (погрешность билинейной интерполяции — порядка метра, в горах до нескольких метров).
+// Если сетка не встроена в сборку, возвращается ErrGeoidDataMissing.
+func DefaultGeoid() (*GridGeoid, error) {
+	return defaultGeoid()
+}
+
+// ParseWW15MGH читает сетку ундуляций в текстовом формате NGA WW15MGH.GRD:
+// заголовок «южная и северная широты, западная и восточная долготы, шаги по широте
+// и долготе», затем значения в метрах построчно с севера на юг и с запада на восток.
+// Сетка должна покрывать весь земной шар; повторяющийся столбец 360° отбрасывается.
+func ParseWW15MGH(r io.Reader) (*GridGeoid, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+
+	next := func() (float64, bool, error) {
+		if !scanner.Scan() {
+			return 0, false, scanner.Err()
+		}
+
+		v, err := strconv.ParseFloat(scanner.Text(), 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("%w: %w", ErrInvalidGeoidGrid, err)
+		}
+
+		return v, true, nil
+	}
+
+	var header [6]float64
+
+	for i := range header {
+		v, ok, err := next()
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("%w: truncated header", ErrInvalidGeoidGrid)
+		}
+
+		header[i] = v
+	}
+
+	south, north, west, east, dLat, dLon := header[0], header[1], header[2], header[3], header[4], header[5]
+	if south != -90 || north != 90 || west != 0 || east != 360 || dLat != dLon {
+		return nil, fmt.Errorf("%w: unsupported header %v", ErrInvalidGeoidGrid, header)
+	}
+
+	if dLat <= 0 || math.IsNaN(dLat) {
+		return nil, fmt.Errorf("%w: step %v", ErrInvalidGeoidGrid, dLat)
+	}
+
+	rows := int(math.Round(180/dLat)) + 1
+	cols := int(math.Round(360/dLon)) + 1
+	values := make([][]float64, rows)
+
+	for i := range values {
+		values[i] = make([]float64, cols)
+
+		for j := range values[i] {
+			v, ok, err := next()
+			if err != nil {
+				return nil, err
+			}
+
+			if !ok {
+				return nil, fmt.Errorf("%w: truncated at row %d column %d", ErrInvalidGeoidGrid, i, j)
+			}
+
+			values[i][j] = v
+		}
+
+		// Столбец 360° совпадает со столбцом 0°.
+		values[i] = values[i][:cols-1]
+	}
+
+	return NewGridGeoid(dLat, values)
+}
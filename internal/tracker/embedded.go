@@ -0,0 +1,58 @@
+package tracker
+
+import (
+	"embed"
+	"fmt"
+)
+
+// embeddedCatalogPath — путь к встроенному каталогу TLE.
+const embeddedCatalogPath = "data/archived.tle"
+
+// embeddedCatalog — небольшой встроенный набор реальных архивных TLE для работы без сети:
+// ISS (эпоха 2008-09-20), метеоспутник NOAA 19 (2016-06-11) и Vanguard 1 (2000-06-27).
+// Элементы взяты из опубликованных архивов без изменений, с исходными эпохами и
+// контрольными суммами; это те же TLE, что используются в проверочных наборах SGP4.
+//
+//go:embed data/archived.tle
+var embeddedCatalog embed.FS
+
+// LoadEmbeddedCatalog возвращает TLE из встроенного каталога.
+//
+// Внимание: элементы заведомо устаревшие (см. embeddedCatalog, TLE.IsStale) — на текущую
+// дату положения расходятся с реальными на тысячи километров, по ним нельзя наводить
+// антенну или планировать наблюдения. Каталог предназначен только для демонстрации
+// до первой загрузки с Celestrak.
+func LoadEmbeddedCatalog() ([]*TLE, error) {
+	data, err := embeddedCatalog.ReadFile(embeddedCatalogPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading embedded catalog: %w", err)
+	}
+
+	tles, err := ParseTLEBatch(string(data))
+	if err != nil {
+		return nil, fmt.Errorf(errMsgParsingTLE, err)
+	}
+
+	return tles, nil
+}
+
+// SeedDefaults добавляет в хранилище встроенный каталог (см. LoadEmbeddedCatalog),
+// чтобы было что показать до завершения загрузки из сети. Уже загруженные TLE
+// не перезаписываются: встроенные данные заведомо старее.
+func (s *TLEStore) SeedDefaults() error {
+	tles, err := LoadEmbeddedCatalog()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tle := range tles {
+		if _, exists := s.catalog[tle.NoradID]; !exists {
+			s.addInternal(tle)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package tracker
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+// TestTLEStore_SeedDefaults проверяет заполнение каталога встроенными TLE.
+func TestTLEStore_SeedDefaults(t *testing.T) {
+	t.Parallel()
+
+	store := NewTLEStore()
+
+	// Загруженный из сети TLE не перезаписывается встроенным.
+	fresh, err := ParseTLE([]string{"ISS (FRESH)", issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	store.Add(fresh)
+
+	if err := store.SeedDefaults(); err != nil {
+		t.Fatalf("SeedDefaults() error = %v", err)
+	}
+
+	var ids []int
+	for _, tle := range store.All() {
+		ids = append(ids, tle.NoradID)
+	}
+
+	sort.Ints(ids)
+
+	want := []int{5, 25544, 33591}
+	if len(ids) != len(want) {
+		t.Fatalf("catalog IDs = %v, want %v", ids, want)
+	}
+
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("catalog IDs = %v, want %v", ids, want)
+		}
+	}
+
+	if iss, _ := store.Get(25544); iss != fresh {
+		t.Error("SeedDefaults() should not replace already loaded TLE")
+	}
+
+	noaa, ok := store.GetByName("NOAA 19")
+	if !ok {
+		t.Fatal("GetByName(NOAA 19) not found after SeedDefaults")
+	}
+
+	// Архивные элементы сохраняют исходную эпоху и считаются устаревшими.
+	if want := time.Date(2016, 6, 11, 0, 0, 0, 0, time.UTC); noaa.Epoch.Truncate(24*time.Hour) != want {
+		t.Errorf("NOAA 19 epoch = %v, want 2016-06-11", noaa.Epoch)
+	}
+
+	if !noaa.IsStale(30) {
+		t.Error("embedded NOAA 19 TLE should be stale")
+	}
+}
@@ -0,0 +1,49 @@
+package tracker
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// passCSVHeader — заголовок CSV со списком пролётов.
+var passCSVHeader = []string{"aos", "los", "duration_s", "max_el_deg", "max_el_az_deg"}
+
+// PassesToCSV записывает пролёты в CSV: строка заголовка и по строке на пролёт.
+// Время в RFC 3339 (UTC), длительность в целых секундах, углы — с одним знаком после запятой.
+// Формат рассчитан на вставку в электронные таблицы.
+func PassesToCSV(w io.Writer, passes []*Pass) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(passCSVHeader); err != nil {
+		return fmt.Errorf("writing CSV header: %w", err)
+	}
+
+	for _, pass := range passes {
+		if pass == nil {
+			continue
+		}
+
+		record := []string{
+			pass.AOS.UTC().Format(time.RFC3339),
+			pass.LOS.UTC().Format(time.RFC3339),
+			strconv.FormatInt(int64(pass.Duration().Round(time.Second)/time.Second), 10),
+			strconv.FormatFloat(pass.MaxElDeg, 'f', 1, 64),
+			strconv.FormatFloat(pass.MaxElAzDeg, 'f', 1, 64),
+		}
+
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("writing CSV record: %w", err)
+		}
+	}
+
+	cw.Flush()
+
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flushing CSV: %w", err)
+	}
+
+	return nil
+}
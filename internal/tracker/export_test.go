@@ -0,0 +1,42 @@
+package tracker
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestPassesToCSV проверяет заголовок и форматирование строк CSV.
+func TestPassesToCSV(t *testing.T) {
+	t.Parallel()
+
+	aos := time.Date(2024, 1, 1, 13, 26, 5, 0, time.UTC)
+	passes := []*Pass{
+		{
+			AOS:        aos,
+			TCA:        aos.Add(5 * time.Minute),
+			LOS:        aos.Add(10*time.Minute + 30*time.Second),
+			MaxElDeg:   45.678,
+			MaxElAzDeg: 182.04,
+		},
+		{
+			AOS:      aos.Add(95 * time.Minute).In(time.FixedZone("MSK", 3*60*60)),
+			TCA:      aos.Add(98 * time.Minute),
+			LOS:      aos.Add(101 * time.Minute),
+			MaxElDeg: 12.3,
+		},
+	}
+
+	var sb strings.Builder
+	if err := PassesToCSV(&sb, passes); err != nil {
+		t.Fatalf("PassesToCSV() error = %v", err)
+	}
+
+	want := "aos,los,duration_s,max_el_deg,max_el_az_deg\n" +
+		"2024-01-01T13:26:05Z,2024-01-01T13:36:35Z,630,45.7,182.0\n" +
+		"2024-01-01T15:01:05Z,2024-01-01T15:07:05Z,360,12.3,0.0\n"
+
+	if got := sb.String(); got != want {
+		t.Errorf("PassesToCSV() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,290 @@
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrInvalidFootprintPoints возвращается, если для контура зоны видимости задано меньше 3 точек.
+var ErrInvalidFootprintPoints = errors.New("footprint circle needs at least 3 points")
+
+// earthRadiusMeanKm — средний радиус Земли, км (сферическая модель для геометрии зоны видимости).
+const earthRadiusMeanKm = 6371.0
+
+// FootprintCentralAngle возвращает земной центральный угол зоны видимости (радианы)
+// для спутника на расстоянии radiusKm от центра Земли при минимальном угле места minElRad.
+// λ = acos(Re·cos(ε)/r) − ε. Для точек ниже поверхности возвращает 0.
+func FootprintCentralAngle(radiusKm, minElRad float64) float64 {
+	if radiusKm <= earthRadiusMeanKm {
+		return 0
+	}
+
+	lambda := math.Acos(earthRadiusMeanKm/radiusKm*math.Cos(minElRad)) - minElRad
+	if lambda < 0 {
+		return 0
+	}
+
+	return lambda
+}
+
+// CoverageFraction возвращает долю поверхности Земли (0..1), с которой спутник
+// виден под углом места не ниже minElevationDeg. Площадь сферического сегмента
+// с центральным углом λ равна (1 − cos λ)/2 от площади сферы.
+func (pos *ECIPosition) CoverageFraction(minElevationDeg float64) float64 {
+	lambda := FootprintCentralAngle(pos.Magnitude(), minElevationDeg*Deg2Rad)
+
+	return (1 - math.Cos(lambda)) / 2
+}
+
+// WithinFootprint сообщает, находится ли наблюдатель в зоне видимости спутника
+// с углом места не ниже minElevationDeg. Вместо полного расчёта AER сравнивается
+// центральный угол между наблюдателем и подспутниковой точкой с радиусом зоны
+// (сферическая модель Земли), поэтому метод дёшев для сеток покрытия.
+func (obs *Observer) WithinFootprint(sat *ECIPosition, minElevationDeg float64) bool {
+	if obs == nil || sat == nil {
+		return false
+	}
+
+	lambda := FootprintCentralAngle(sat.Magnitude(), minElevationDeg*Deg2Rad)
+
+	return angleBetween(subSatelliteDir(sat), surfaceDir(obs.Lat, obs.Lon)) <= lambda
+}
+
+// subSatelliteDir возвращает единичный вектор ECEF на подспутниковую точку.
+func subSatelliteDir(sat *ECIPosition) vec3 {
+	ecef := ECIToECEF(sat)
+
+	return vec3{ecef.X, ecef.Y, ecef.Z}.unit()
+}
+
+// surfaceDir возвращает единичный вектор ECEF на точку сферы с широтой и долготой в градусах.
+func surfaceDir(latDeg, lonDeg float64) vec3 {
+	lat, lon := latDeg*Deg2Rad, lonDeg*Deg2Rad
+
+	return vec3{
+		X: math.Cos(lat) * math.Cos(lon),
+		Y: math.Cos(lat) * math.Sin(lon),
+		Z: math.Sin(lat),
+	}
+}
+
+// CoverageGrid возвращает сетку широта×долгота, где true отмечает ячейки, центр
+// которых находится в зоне видимости спутника (угол места не ниже minElevationDeg).
+// Строка i соответствует широте −90 + (i+0.5)·latStepDeg, столбец j — долготе
+// −180 + (j+0.5)·lonStepDeg. Размер сетки задаётся шагом, чтобы вызывающий
+// контролировал расход памяти. Для неположительного шага возвращает nil.
+func (pos *ECIPosition) CoverageGrid(latStepDeg, lonStepDeg, minElevationDeg float64) [][]bool {
+	lats, lons := gridCenters(-90, 180, latStepDeg), gridCenters(-180, 360, lonStepDeg)
+	if lats == nil || lons == nil {
+		return nil
+	}
+
+	lambda := FootprintCentralAngle(pos.Magnitude(), minElevationDeg*Deg2Rad)
+	sub := subSatelliteDir(pos)
+
+	grid := make([][]bool, len(lats))
+	for i, lat := range lats {
+		grid[i] = make([]bool, len(lons))
+		for j, lon := range lons {
+			grid[i][j] = angleBetween(sub, surfaceDir(lat, lon)) <= lambda
+		}
+	}
+
+	return grid
+}
+
+// gridCenters возвращает центры ячеек шага stepDeg на интервале [start, start+span).
+func gridCenters(start, span, stepDeg float64) []float64 {
+	if stepDeg <= 0 || math.IsNaN(stepDeg) {
+		return nil
+	}
+
+	n := int(math.Ceil(span / stepDeg))
+	centers := make([]float64, n)
+
+	for i := range centers {
+		centers[i] = math.Min(start+(float64(i)+0.5)*stepDeg, start+span)
+	}
+
+	return centers
+}
+
+// AccumulatedCoverage возвращает для каждой ячейки сетки (раскладка как в CoverageGrid)
+// долю времени интервала [start, end], в течение которой ячейка была в зоне видимости.
+// Моменты с ошибкой пропагации не учитываются. Для неположительного шага
+// по времени или по сетке возвращает nil.
+func (p *Propagator) AccumulatedCoverage(start, end time.Time, step time.Duration, latStepDeg, lonStepDeg, minElDeg float64) [][]float64 {
+	lats, lons := gridCenters(-90, 180, latStepDeg), gridCenters(-180, 360, lonStepDeg)
+	if p == nil || step <= 0 || lats == nil || lons == nil {
+		return nil
+	}
+
+	// Направления на центры ячеек не зависят от времени — считаем их один раз.
+	dirs := make([][]vec3, len(lats))
+	for i, lat := range lats {
+		dirs[i] = make([]vec3, len(lons))
+		for j, lon := range lons {
+			dirs[i][j] = surfaceDir(lat, lon)
+		}
+	}
+
+	coverage := make([][]float64, len(lats))
+	for i := range coverage {
+		coverage[i] = make([]float64, len(lons))
+	}
+
+	samples := 0
+
+	for t := start; !t.After(end); t = t.Add(step) {
+		pos, err := p.Propagate(t)
+		if err != nil {
+			continue
+		}
+
+		samples++
+
+		lambda := FootprintCentralAngle(pos.Magnitude(), minElDeg*Deg2Rad)
+		sub := subSatelliteDir(pos)
+
+		for i := range dirs {
+			for j := range dirs[i] {
+				if angleBetween(sub, dirs[i][j]) <= lambda {
+					coverage[i][j]++
+				}
+			}
+		}
+	}
+
+	if samples == 0 {
+		return coverage
+	}
+
+	for i := range coverage {
+		for j := range coverage[i] {
+			coverage[i][j] /= float64(samples)
+		}
+	}
+
+	return coverage
+}
+
+// maxDurationSeconds — наибольшая длительность, представимая в time.Duration, секунды.
+const maxDurationSeconds = float64(math.MaxInt64) / float64(time.Second)
+
+// MaxPassDuration оценивает длительность самого длинного возможного пролёта над
+// наблюдателем (через точку трассы, ближайшую к нему) без запуска предсказателя.
+// Орбита считается круговой радиуса большой полуоси; угловая скорость спутника
+// относительно Земли — mean motion минус вращение Земли, спроецированное на плоскость орбиты.
+// Если трасса не подходит к наблюдателю на расстояние зоны видимости
+// (наблюдатель далеко за широтой наклонения), возвращает 0. Для геосинхронных
+// и более медленных орбит, где спутник не проходит над наблюдателем, также возвращает 0.
+func (tle *TLE) MaxPassDuration(obs *Observer, minElDeg float64) time.Duration {
+	if tle == nil || obs == nil || tle.MeanMotion <= 0 {
+		return 0
+	}
+
+	lambda := FootprintCentralAngle(tle.SemiMajorAxis(), minElDeg*Deg2Rad)
+
+	// Максимальная широта трассы и минимальное угловое расстояние от неё до наблюдателя.
+	maxLat := tle.Inclination
+	if maxLat > 90 {
+		maxLat = 180 - maxLat
+	}
+
+	offset := math.Max(0, math.Abs(obs.Lat)-maxLat) * Deg2Rad
+	if offset >= lambda {
+		return 0
+	}
+
+	// Половина хорды зоны видимости вдоль трассы: cos λ = cos d · cos h.
+	halfArc := math.Acos(math.Cos(lambda) / math.Cos(offset))
+
+	n := tle.MeanMotion * 2 * math.Pi / 86400 // рад/с.
+	rate := n - OmegaEarth*math.Cos(tle.Inclination*Deg2Rad)
+
+	// Геосинхронная и более медленная орбита не «пролетает» над наблюдателем:
+	// относительная скорость нулевая или обратная, а длительность не ограничена.
+	seconds := 2 * halfArc / rate
+	if rate <= 0 || math.IsInf(seconds, 0) || math.IsNaN(seconds) || seconds > maxDurationSeconds {
+		return 0
+	}
+
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// FootprintCircle возвращает контур зоны видимости спутника (горизонт, угол места 0°)
+// в момент t: points точек на сфере вокруг подспутниковой точки на центральном угле
+// λ = acos(Re/(Re+h)). Контур разбит на сегменты по антимеридиану, как трасса
+// (см. splitAtAntimeridian), чтобы фронтенд рисовал его без «швов». Если зона
+// накрывает полюс, сегмент дополняется точками на широте ±90°, замыкая область.
+func FootprintCircle(tle *TLE, t time.Time, points int) ([][]TrackPoint, error) {
+	if points < 3 {
+		return nil, fmt.Errorf("%w: got %d", ErrInvalidFootprintPoints, points)
+	}
+
+	prop, err := NewPropagator(tle)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := prop.Propagate(t)
+	if err != nil {
+		return nil, err
+	}
+
+	center := trackPointFromECI(pos)
+	lambda := FootprintCentralAngle(pos.Magnitude(), 0)
+
+	return closeOverPole(footprintRing(center, lambda, points), center, lambda), nil
+}
+
+// footprintRing строит замкнутый контур радиуса lambda (радианы) вокруг точки center
+// и разбивает его по антимеридиану.
+func footprintRing(center TrackPoint, lambda float64, points int) [][]TrackPoint {
+	lat1, lon1 := center.Lat*Deg2Rad, center.Lon*Deg2Rad
+	sinLat1, cosLat1 := math.Sin(lat1), math.Cos(lat1)
+	sinL, cosL := math.Sin(lambda), math.Cos(lambda)
+
+	ring := make([]TrackPoint, 0, points+1)
+
+	for k := range points {
+		bearing := 2 * math.Pi * float64(k) / float64(points)
+
+		sinLat2 := sinLat1*cosL + cosLat1*sinL*math.Cos(bearing)
+		lat2 := math.Asin(sinLat2)
+		lon2 := lon1 + math.Atan2(math.Sin(bearing)*sinL*cosLat1, cosL-sinLat1*sinLat2)
+
+		ring = append(ring, TrackPoint{Lat: lat2 * Rad2Deg, Lon: NormalizeLongitude(lon2 * Rad2Deg), Time: center.Time})
+	}
+
+	segments := splitAtAntimeridian(append(ring, ring[0]))
+
+	// Контур замкнут: последний сегмент продолжается первым.
+	if n := len(segments); n > 1 {
+		merged := append(segments[n-1], segments[0][1:]...)
+		segments = append([][]TrackPoint{merged}, segments[1:n-1]...)
+	}
+
+	return segments
+}
+
+// closeOverPole замыкает через полюс контур радиуса lambda вокруг center, если полюс
+// оказался внутри: единственный сегмент такого контура идёт от края карты до края.
+func closeOverPole(segments [][]TrackPoint, center TrackPoint, lambda float64) [][]TrackPoint {
+	if lambda+math.Abs(center.Lat*Deg2Rad) <= math.Pi/2 || len(segments) != 1 {
+		return segments
+	}
+
+	pole := math.Copysign(90, center.Lat)
+	seg := segments[0]
+	first, last := seg[0], seg[len(seg)-1]
+
+	closed := make([]TrackPoint, 0, len(seg)+2)
+	closed = append(closed, TrackPoint{Lat: pole, Lon: first.Lon, Time: center.Time})
+	closed = append(closed, seg...)
+	closed = append(closed, TrackPoint{Lat: pole, Lon: last.Lon, Time: center.Time})
+
+	return [][]TrackPoint{closed}
+}
@@ -0,0 +1,329 @@
+package tracker
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestECIPosition_CoverageFraction сравнивает покрытие GEO и LEO спутников.
+func TestECIPosition_CoverageFraction(t *testing.T) {
+	testTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	geo := &ECIPosition{X: 42164, Time: testTime}
+	leo := &ECIPosition{X: 6371 + 420, Time: testTime}
+
+	geoFraction := geo.CoverageFraction(0)
+	if !almostEqual(geoFraction, 0.42, 0.01) {
+		t.Errorf("GEO coverage = %.4f, want ~0.42", geoFraction)
+	}
+
+	leoFraction := leo.CoverageFraction(0)
+	if leoFraction <= 0 || leoFraction > 0.05 {
+		t.Errorf("LEO coverage = %.4f, want 0-0.05", leoFraction)
+	}
+
+	// Повышение минимального угла места сужает зону видимости.
+	if masked := geo.CoverageFraction(10); masked >= geoFraction {
+		t.Errorf("GEO coverage at 10° = %.4f, want less than %.4f", masked, geoFraction)
+	}
+
+	// Точка под поверхностью ничего не покрывает.
+	if got := (&ECIPosition{X: 6000}).CoverageFraction(0); got != 0 {
+		t.Errorf("coverage below surface = %v, want 0", got)
+	}
+}
+
+// TestObserver_WithinFootprint проверяет попадание наблюдателя в зону видимости.
+func TestObserver_WithinFootprint(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+
+	sat, err := prop.Propagate(passTestStart)
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	sub := ECEFToLLA(ECIToECEF(sat))
+
+	tests := []struct {
+		name string
+		obs  *Observer
+		want bool
+	}{
+		{name: "beneath satellite", obs: NewObserver(sub.LatDeg(), sub.LonDeg(), 0), want: true},
+		{name: "1000 km away", obs: NewObserver(sub.LatDeg(), sub.LonDeg()+9, 0), want: true},
+		{name: "opposite hemisphere", obs: NewObserver(-sub.LatDeg(), sub.LonDeg()+180, 0), want: false},
+		{name: "beyond horizon", obs: NewObserver(sub.LatDeg()-30, sub.LonDeg(), 0), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.obs.WithinFootprint(sat, 0); got != tt.want {
+				t.Errorf("WithinFootprint() = %v, want %v", got, tt.want)
+			}
+
+			// Согласованность с полным расчётом угла места (вдали от границы зоны).
+			if el := tt.obs.GetAER(sat).ElDeg(); (el >= 0) != tt.want {
+				t.Errorf("elevation %.2f° disagrees with WithinFootprint = %v", el, tt.want)
+			}
+		})
+	}
+}
+
+// TestECIPosition_CoverageGrid проверяет, что покрытые ячейки образуют связную область
+// вокруг подспутниковой точки с площадью, соответствующей зоне видимости.
+func TestECIPosition_CoverageGrid(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+
+	sat, err := prop.Propagate(passTestStart)
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	const step = 1.0
+
+	grid := sat.CoverageGrid(step, step, 0)
+	if len(grid) != 180 || len(grid[0]) != 360 {
+		t.Fatalf("grid size = %dx%d, want 180x360", len(grid), len(grid[0]))
+	}
+
+	sub := ECEFToLLA(ECIToECEF(sat))
+	subRow := int((sub.LatDeg() + 90) / step)
+	subCol := int((sub.LonDeg() + 180) / step)
+
+	if !grid[subRow][subCol] {
+		t.Fatal("sub-satellite cell is not covered")
+	}
+
+	// Площадь покрытых ячеек (вес cos φ) против аналитической доли поверхности.
+	var covered, total float64
+
+	count := 0
+
+	for i, row := range grid {
+		weight := math.Cos((-90 + (float64(i)+0.5)*step) * Deg2Rad)
+		for _, cell := range row {
+			total += weight
+			if cell {
+				covered += weight
+				count++
+			}
+		}
+	}
+
+	if want := sat.CoverageFraction(0); math.Abs(covered/total-want) > 0.1*want {
+		t.Errorf("covered area fraction = %.4f, want ~%.4f", covered/total, want)
+	}
+
+	// Связность: обход из подспутниковой ячейки (с переходом через антимеридиан) находит все покрытые.
+	seen := map[[2]int]bool{{subRow, subCol}: true}
+	queue := [][2]int{{subRow, subCol}}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for _, d := range [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			r, c := cur[0]+d[0], (cur[1]+d[1]+360)%360
+			if r < 0 || r >= len(grid) || !grid[r][c] || seen[[2]int{r, c}] {
+				continue
+			}
+
+			seen[[2]int{r, c}] = true
+			queue = append(queue, [2]int{r, c})
+		}
+	}
+
+	if len(seen) != count {
+		t.Errorf("connected region has %d cells, total covered %d", len(seen), count)
+	}
+
+	if sat.CoverageGrid(0, 1, 0) != nil {
+		t.Error("CoverageGrid(step=0) should return nil")
+	}
+}
+
+// TestPropagator_AccumulatedCoverage проверяет, что полярный спутник за сутки
+// покрывает высокие широты дольше, чем экватор.
+func TestPropagator_AccumulatedCoverage(t *testing.T) {
+	t.Parallel()
+
+	polarLine2 := makeTLELine("2 25544  90.0000 247.4627 0006703 130.5360 325.0288 15.4981557142340")
+
+	tle, err := ParseTLE([]string{issLine1, polarLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	prop, err := NewPropagator(tle)
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	const step = 10.0
+
+	coverage := prop.AccumulatedCoverage(passTestStart, passTestStart.Add(24*time.Hour), time.Minute, step, step, 0)
+	if len(coverage) != 18 || len(coverage[0]) != 36 {
+		t.Fatalf("grid size = %dx%d, want 18x36", len(coverage), len(coverage[0]))
+	}
+
+	rowMean := func(row []float64) float64 {
+		sum := 0.0
+		for _, v := range row {
+			if v < 0 || v > 1 {
+				t.Fatalf("coverage fraction %v outside [0, 1]", v)
+			}
+
+			sum += v
+		}
+
+		return sum / float64(len(row))
+	}
+
+	// Строки 16 (70°..80° с.ш.) и 8 (−10°..0°).
+	polar, equatorial := rowMean(coverage[16]), rowMean(coverage[8])
+
+	if polar <= 2*equatorial {
+		t.Errorf("high-latitude coverage %.3f should be well above equatorial %.3f", polar, equatorial)
+	}
+
+	if prop.AccumulatedCoverage(passTestStart, passTestStart.Add(time.Hour), 0, step, step, 0) != nil {
+		t.Error("AccumulatedCoverage(step=0) should return nil")
+	}
+}
+
+// TestTLE_MaxPassDuration проверяет, что более высокая орбита даёт более длинный пролёт.
+func TestTLE_MaxPassDuration(t *testing.T) {
+	t.Parallel()
+
+	low := &TLE{MeanMotion: 15.5, Inclination: 51.6}   // ~420 км.
+	high := &TLE{MeanMotion: 14.2, Inclination: 51.6}  // ~800 км.
+	polar := &TLE{MeanMotion: 15.5, Inclination: 97.5} // Солнечно-синхронная.
+
+	lowMax := low.MaxPassDuration(passTestMoscow, 0)
+	highMax := high.MaxPassDuration(passTestMoscow, 0)
+
+	// Зенитный пролёт ISS длится около 10-11 минут.
+	if lowMax < 9*time.Minute || lowMax > 12*time.Minute {
+		t.Errorf("low orbit max pass = %v, want ~10 min", lowMax)
+	}
+
+	if highMax <= lowMax {
+		t.Errorf("high orbit max pass %v should exceed low orbit %v", highMax, lowMax)
+	}
+
+	// Порог по углу места сокращает пролёт.
+	if masked := low.MaxPassDuration(passTestMoscow, 10); masked >= lowMax {
+		t.Errorf("max pass at 10° = %v, want less than %v", masked, lowMax)
+	}
+
+	// Наблюдатель у полюса не видит наклонённую орбиту, но видит полярную.
+	arctic := NewObserver(85, 0, 0)
+	if got := low.MaxPassDuration(arctic, 0); got != 0 {
+		t.Errorf("51.6° orbit max pass at 85°N = %v, want 0", got)
+	}
+
+	if got := polar.MaxPassDuration(arctic, 0); got == 0 {
+		t.Error("polar orbit max pass at 85°N should be positive")
+	}
+	// Геосинхронная и более медленная орбиты не дают пролёта; результат не переполняется.
+	equator := NewObserver(0, 0, 0)
+	for name, tle := range map[string]*TLE{
+		"GEO":               {MeanMotion: 1.00273791, Inclination: 0.05},
+		"exact synchronous": {MeanMotion: 1.0027379093, Inclination: 0},
+		"sub-synchronous":   {MeanMotion: 0.99, Inclination: 0},
+	} {
+		if got := tle.MaxPassDuration(equator, 0); got != 0 {
+			t.Errorf("%s max pass = %v, want 0", name, got)
+		}
+	}
+}
+
+// TestFootprintCircle проверяет, что точки контура лежат на центральном угле
+// зоны видимости от подспутниковой точки МКС.
+func TestFootprintCircle(t *testing.T) {
+	t.Parallel()
+
+	tle, err := ParseTLE([]string{issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	segments, err := FootprintCircle(tle, passTestStart, 72)
+	if err != nil {
+		t.Fatalf("FootprintCircle() error = %v", err)
+	}
+
+	pos, err := createTestPropagator(t).Propagate(passTestStart)
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	sub := trackPointFromECI(pos)
+	lambda := FootprintCentralAngle(pos.Magnitude(), 0)
+
+	// Радиус зоны МКС — около 20° дуги.
+	if lambda*Rad2Deg < 18 || lambda*Rad2Deg > 23 {
+		t.Errorf("central angle = %.2f°, expected ~20°", lambda*Rad2Deg)
+	}
+
+	count := 0
+
+	for _, seg := range segments {
+		for _, p := range seg {
+			if got := angleBetween(surfaceDir(sub.Lat, sub.Lon), surfaceDir(p.Lat, p.Lon)); !almostEqual(got, lambda, 1e-6) {
+				t.Errorf("point (%.3f, %.3f) at %.4f rad from sub-point, want %.4f", p.Lat, p.Lon, got, lambda)
+			}
+
+			count++
+		}
+	}
+
+	if count < 73 {
+		t.Errorf("got %d points, want at least 73 (closed ring)", count)
+	}
+
+	if _, err := FootprintCircle(tle, passTestStart, 2); !errors.Is(err, ErrInvalidFootprintPoints) {
+		t.Errorf("FootprintCircle(points=2) error = %v, want ErrInvalidFootprintPoints", err)
+	}
+}
+
+// TestFootprintRing_Wrapping проверяет разбиение по антимеридиану и замыкание через полюс.
+func TestFootprintRing_Wrapping(t *testing.T) {
+	t.Parallel()
+
+	lambda := 20 * Deg2Rad
+
+	// Контур вокруг точки на антимеридиане — два сегмента, каждый доходит до края карты.
+	segments := footprintRing(TrackPoint{Lat: 0, Lon: 179}, lambda, 72)
+	if len(segments) != 2 {
+		t.Fatalf("antimeridian ring: got %d segments, want 2", len(segments))
+	}
+
+	for i, seg := range segments {
+		for _, p := range seg {
+			if p.Lon < -180 || p.Lon > 180 {
+				t.Errorf("segment %d longitude %.2f out of range", i, p.Lon)
+			}
+		}
+	}
+
+	// Контур, накрывающий северный полюс, — один сегмент через всю карту, замкнутый на +90°.
+	polar := TrackPoint{Lat: 80, Lon: 30}
+
+	segments = closeOverPole(footprintRing(polar, lambda, 72), polar, lambda)
+	if len(segments) != 1 {
+		t.Fatalf("polar ring: got %d segments, want 1", len(segments))
+	}
+
+	seg := segments[0]
+	first, last := seg[0], seg[len(seg)-1]
+
+	if first.Lat != 90 || last.Lat != 90 || math.Abs(first.Lon-last.Lon) != 360 {
+		t.Errorf("polar ring not closed through the pole: first %+v, last %+v", first, last)
+	}
+}
@@ -0,0 +1,86 @@
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidGeoidGrid возвращается при некорректных размерах сетки геоида.
+var ErrInvalidGeoidGrid = errors.New("invalid geoid grid")
+
+// GeoidModel возвращает высоту геоида над эллипсоидом WGS84 (ундуляцию) в метрах.
+// Высота над уровнем моря = высота над эллипсоидом − ундуляция.
+type GeoidModel interface {
+	UndulationM(latDeg, lonDeg float64) float64
+}
+
+// GridGeoid — модель геоида на регулярной сетке с билинейной интерполяцией.
+// Раскладка совпадает с сетками EGM96 (WW15MGH): строка i соответствует широте
+// 90 − i·step, столбец j — восточной долготе j·step в [0, 360).
+type GridGeoid struct {
+	stepDeg float64
+	values  [][]float64
+}
+
+// NewGridGeoid создаёт модель геоида из сетки ундуляций в метрах.
+// Сетка должна покрывать широты от 90 до −90 включительно: 180/step + 1 строк
+// по 360/step столбцов.
+func NewGridGeoid(stepDeg float64, values [][]float64) (*GridGeoid, error) {
+	if stepDeg <= 0 || math.IsNaN(stepDeg) {
+		return nil, fmt.Errorf("%w: step %v", ErrInvalidGeoidGrid, stepDeg)
+	}
+
+	rows := int(math.Round(180/stepDeg)) + 1
+	cols := int(math.Round(360 / stepDeg))
+
+	if len(values) != rows {
+		return nil, fmt.Errorf("%w: got %d rows, want %d", ErrInvalidGeoidGrid, len(values), rows)
+	}
+
+	for i, row := range values {
+		if len(row) != cols {
+			return nil, fmt.Errorf("%w: row %d has %d columns, want %d", ErrInvalidGeoidGrid, i, len(row), cols)
+		}
+	}
+
+	return &GridGeoid{stepDeg: stepDeg, values: values}, nil
+}
+
+// UndulationM возвращает ундуляцию геоида в точке, метры (билинейная интерполяция).
+func (g *GridGeoid) UndulationM(latDeg, lonDeg float64) float64 {
+	lat := math.Max(-90, math.Min(90, latDeg))
+
+	lon := math.Mod(lonDeg, 360)
+	if lon < 0 {
+		lon += 360
+	}
+
+	rows, cols := len(g.values), len(g.values[0])
+
+	y := (90 - lat) / g.stepDeg
+	x := lon / g.stepDeg
+
+	i0 := min(int(math.Floor(y)), rows-2)
+	j0 := int(math.Floor(x)) % cols
+	j1 := (j0 + 1) % cols
+	fy, fx := y-float64(i0), x-math.Floor(x)
+
+	top := g.values[i0][j0]*(1-fx) + g.values[i0][j1]*fx
+	bottom := g.values[i0+1][j0]*(1-fx) + g.values[i0+1][j1]*fx
+
+	return top*(1-fy) + bottom*fy
+}
+
+// ECEFToLLAGeoid преобразует ECEF в LLA с высотой над геоидом (уровнем моря) вместо
+// высоты над эллипсоидом. При geoid == nil совпадает с ECEFToLLA.
+func ECEFToLLAGeoid(ecef *ECEFPosition, geoid GeoidModel) *LLA {
+	lla := ECEFToLLA(ecef)
+	if lla == nil || geoid == nil {
+		return lla
+	}
+
+	lla.Alt -= geoid.UndulationM(lla.LatDeg(), lla.LonDeg()) / 1000
+
+	return lla
+}
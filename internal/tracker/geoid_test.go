@@ -0,0 +1,142 @@
+package tracker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// newTestGeoid создаёт сетку 90° с ундуляцией +50 м на (0°, 90° в.д.)
+// и −100 м на (0°, 270° в.д.), остальные узлы нулевые.
+func newTestGeoid(t *testing.T) *GridGeoid {
+	t.Helper()
+
+	values := [][]float64{
+		{0, 0, 0, 0},
+		{0, 50, 0, -100},
+		{0, 0, 0, 0},
+	}
+
+	geoid, err := NewGridGeoid(90, values)
+	if err != nil {
+		t.Fatalf("NewGridGeoid() error = %v", err)
+	}
+
+	return geoid
+}
+
+// TestECEFToLLAGeoid проверяет поправку высоты на ундуляцию геоида.
+func TestECEFToLLAGeoid(t *testing.T) {
+	t.Parallel()
+
+	geoid := newTestGeoid(t)
+
+	tests := []struct {
+		name         string
+		latDeg       float64
+		lonDeg       float64
+		undulationKm float64
+	}{
+		{name: "grid node", latDeg: 0, lonDeg: 90, undulationKm: 0.05},
+		{name: "negative longitude", latDeg: 0, lonDeg: -90, undulationKm: -0.1},
+		{name: "interpolated", latDeg: 45, lonDeg: 90, undulationKm: 0.025},
+		{name: "antimeridian wrap", latDeg: 0, lonDeg: 315, undulationKm: -0.05},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ecef := LLAToECEF(NewLLAFromDegrees(tt.latDeg, tt.lonDeg, 1.0))
+
+			ellipsoidal := ECEFToLLA(ecef)
+			msl := ECEFToLLAGeoid(ecef, geoid)
+
+			if diff := ellipsoidal.Alt - msl.Alt; !almostEqual(diff, tt.undulationKm, 1e-6) {
+				t.Errorf("ellipsoidal - MSL = %.6f km, want %.6f km", diff, tt.undulationKm)
+			}
+		})
+	}
+
+	// Без модели геоида — эллипсоидальная высота.
+	ecef := LLAToECEF(NewLLAFromDegrees(0, 90, 1.0))
+	if got := ECEFToLLAGeoid(ecef, nil); !almostEqual(got.Alt, 1.0, 1e-6) {
+		t.Errorf("ECEFToLLAGeoid(nil geoid) alt = %v, want 1.0", got.Alt)
+	}
+
+	if _, err := NewGridGeoid(90, [][]float64{{0}}); !errors.Is(err, ErrInvalidGeoidGrid) {
+		t.Errorf("NewGridGeoid(bad size) error = %v, want ErrInvalidGeoidGrid", err)
+	}
+}
+
+// TestParseWW15MGH проверяет чтение сетки в формате WW15MGH.GRD и отбрасывание столбца 360°.
+func TestParseWW15MGH(t *testing.T) {
+	t.Parallel()
+
+	grid := `  -90.000000   90.000000     .000000  360.000000   90.000000   90.000000
+
+	0 0 0 0 0
+	0 50 0 -100 0
+	0 0 0 0 0
+`
+
+	geoid, err := ParseWW15MGH(strings.NewReader(grid))
+	if err != nil {
+		t.Fatalf("ParseWW15MGH() error = %v", err)
+	}
+
+	want := newTestGeoid(t)
+
+	for _, p := range [][2]float64{{0, 90}, {0, -90}, {45, 90}, {0, 315}, {-30, 10}} {
+		if got, exp := geoid.UndulationM(p[0], p[1]), want.UndulationM(p[0], p[1]); !almostEqual(got, exp, 1e-9) {
+			t.Errorf("UndulationM(%v, %v) = %v, want %v", p[0], p[1], got, exp)
+		}
+	}
+
+	bad := map[string]string{
+		"truncated header": "-90 90 0",
+		"partial globe":    "-80 90 0 360 90 90",
+		"unequal steps":    "-90 90 0 360 90 45",
+		"truncated values": "-90 90 0 360 90 90 0 0 0",
+		"not a number":     "-90 90 0 360 90 90 x",
+	}
+
+	for name, data := range bad {
+		if _, err := ParseWW15MGH(strings.NewReader(data)); !errors.Is(err, ErrInvalidGeoidGrid) {
+			t.Errorf("%s: ParseWW15MGH() error = %v, want ErrInvalidGeoidGrid", name, err)
+		}
+	}
+}
+
+// TestDefaultGeoid проверяет встроенную сетку EGM96 по контрольным точкам NGA
+// (INTPT.DAT/OUTINTPT.DAT из дистрибутива EGM96). Допуск учитывает шаг сетки 1°.
+func TestDefaultGeoid(t *testing.T) {
+	t.Parallel()
+
+	// Отсутствие сетки — ошибка сборки, а не повод пропустить тест: без неё DefaultGeoid
+	// не работает у пользователей пакета.
+	geoid, err := DefaultGeoid()
+	if errors.Is(err, ErrGeoidDataMissing) {
+		t.Fatalf("DefaultGeoid() error = %v: сгенерируйте сетку командой make geoid и добавьте её в репозиторий", err)
+	}
+
+	if err != nil {
+		t.Fatalf("DefaultGeoid() error = %v", err)
+	}
+
+	tests := []struct {
+		latDeg, lonDeg float64
+		undulationM    float64
+	}{
+		{38.6281550, 269.7791550, -31.628},
+		{-14.6212170, 305.0211140, -2.969},
+		{46.8743190, 102.4487290, -43.575},
+		{-23.6174460, 133.8747120, 15.871},
+		{38.6254730, 359.9995000, 50.066},
+		{-0.4667440, 0.0023000, 17.329},
+	}
+
+	for _, tt := range tests {
+		if got := geoid.UndulationM(tt.latDeg, tt.lonDeg); !almostEqual(got, tt.undulationM, 2) {
+			t.Errorf("UndulationM(%v, %v) = %.3f m, want %.3f m", tt.latDeg, tt.lonDeg, got, tt.undulationM)
+		}
+	}
+}
@@ -0,0 +1,466 @@
+package tracker
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Параметры трассы по умолчанию.
+const (
+	// DefaultTrackStep шаг между точками трассы.
+	DefaultTrackStep = 30 * time.Second
+
+	// DefaultTrackPastPeriods количество витков прошлой трассы.
+	DefaultTrackPastPeriods = 1.0
+
+	// DefaultTrackFuturePeriods количество витков будущей трассы.
+	DefaultTrackFuturePeriods = 2.0
+)
+
+// TrackPoint — точка подспутниковой трассы.
+type TrackPoint struct {
+	Lat  float64   `json:"lat"`  // Широта, градусы.
+	Lon  float64   `json:"lon"`  // Долгота, градусы [-180, 180].
+	Alt  float64   `json:"alt"`  // Высота над эллипсоидом, км.
+	Time time.Time `json:"time"` // Время точки.
+}
+
+// GroundTrack — подспутниковая трасса относительно текущего момента.
+// Прошлая и будущая части разбиты на сегменты по антимеридиану,
+// чтобы фронтенд мог рисовать их без горизонтальных «швов».
+type GroundTrack struct {
+	NoradID int            `json:"norad_id"`
+	Name    string         `json:"name"`
+	Current TrackPoint     `json:"current"`
+	Past    [][]TrackPoint `json:"past"`
+	Future  [][]TrackPoint `json:"future"`
+}
+
+// BoundingBox — географические границы набора точек, градусы.
+type BoundingBox struct {
+	MinLat float64 `json:"min_lat"`
+	MaxLat float64 `json:"max_lat"`
+	MinLon float64 `json:"min_lon"`
+	MaxLon float64 `json:"max_lon"`
+}
+
+// MultiTrack — трассы нескольких спутников для отрисовки одним запросом.
+type MultiTrack struct {
+	Tracks      map[int]*GroundTrack `json:"tracks"`       // NORAD ID → трасса.
+	Bounds      BoundingBox          `json:"bounds"`       // Общие границы всех трасс.
+	TotalPoints int                  `json:"total_points"` // Суммарное количество точек.
+}
+
+// GenerateGroundTrack рассчитывает трассу спутника на интервале [now-past, now+future] с шагом step.
+func GenerateGroundTrack(tle *TLE, now time.Time, past, future, step time.Duration) (*GroundTrack, error) {
+	prop, err := NewPropagator(tle)
+	if err != nil {
+		return nil, err
+	}
+
+	if step <= 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStep, step)
+	}
+
+	current, err := prop.Propagate(now)
+	if err != nil {
+		return nil, err
+	}
+
+	pastPoints, err := generateTrackPoints(prop, now.Add(-past), now, step)
+	if err != nil {
+		return nil, fmt.Errorf("past track: %w", err)
+	}
+
+	futurePoints, err := generateTrackPoints(prop, now, now.Add(future), step)
+	if err != nil {
+		return nil, fmt.Errorf("future track: %w", err)
+	}
+
+	return &GroundTrack{
+		NoradID: tle.NoradID,
+		Name:    tle.Name,
+		Current: trackPointFromECI(current),
+		Past:    splitAtAntimeridian(pastPoints),
+		Future:  splitAtAntimeridian(futurePoints),
+	}, nil
+}
+
+// GenerateDefaultGroundTrack рассчитывает трассу на один виток назад и два вперёд с шагом 30 секунд.
+func GenerateDefaultGroundTrack(tle *TLE, now time.Time) (*GroundTrack, error) {
+	if tle == nil {
+		return nil, ErrNilTLE
+	}
+
+	period := time.Duration(tle.OrbitalPeriod() * float64(time.Minute))
+	if period <= 0 {
+		return nil, fmt.Errorf("%w: mean motion is zero", ErrInvalidTLEForPropagation)
+	}
+
+	past := time.Duration(DefaultTrackPastPeriods * float64(period))
+	future := time.Duration(DefaultTrackFuturePeriods * float64(period))
+
+	return GenerateGroundTrack(tle, now, past, future, DefaultTrackStep)
+}
+
+// GenerateGroundTrackStream рассчитывает точки трассы на интервале [start, end] с шагом step
+// и передаёт каждую в emit, не накапливая их в памяти. Подходит для длинных трасс
+// (например, ГСО за несколько суток), которые пишутся в поток или прореживаются на лету.
+// Ошибка emit прерывает генерацию и возвращается вызывающему. Разбиение по антимеридиану
+// не выполняется — точки передаются в исходном порядке.
+func GenerateGroundTrackStream(tle *TLE, start, end time.Time, step time.Duration, emit func(TrackPoint) error) error {
+	prop, err := NewPropagator(tle)
+	if err != nil {
+		return err
+	}
+
+	if step <= 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidStep, step)
+	}
+
+	if end.Before(start) {
+		start, end = end, start
+	}
+
+	gmst0 := GMST(start)
+
+	for t := start; !t.After(end); t = t.Add(step) {
+		pos, err := prop.Propagate(t)
+		if err != nil {
+			return fmt.Errorf("propagation at %v: %w", t, err)
+		}
+
+		if err := emit(trackPointWithGMST(pos, gmstAfter(gmst0, start, t))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SubPointVelocityDeg возвращает скорость изменения широты и долготы подспутниковой
+// точки в момент t, градусы в секунду. Используется фронтендом для стрелок движения
+// на плоской проекции. Производная считается центральной разностью на интервале 1 с;
+// переход через антимеридиан учитывается при вычислении dLon.
+func (p *Propagator) SubPointVelocityDeg(t time.Time) (dLatPerSec, dLonPerSec float64, err error) {
+	const halfStep = 500 * time.Millisecond
+
+	before, err := p.propagatePrecise(t.Add(-halfStep))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	after, err := p.propagatePrecise(t.Add(halfStep))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	from, to := trackPointFromECI(before), trackPointFromECI(after)
+	dt := (2 * halfStep).Seconds()
+
+	return (to.Lat - from.Lat) / dt, NormalizeLongitude(to.Lon-from.Lon) / dt, nil
+}
+
+// PointCount возвращает количество точек прошлой и будущей трассы.
+func (gt *GroundTrack) PointCount() int {
+	count := 0
+
+	for _, segments := range [][][]TrackPoint{gt.Past, gt.Future} {
+		for _, segment := range segments {
+			count += len(segment)
+		}
+	}
+
+	return count
+}
+
+// MergeGroundTracks объединяет трассы нескольких спутников в одну структуру
+// с общими границами и суммарным количеством точек. Трассы остаются
+// раздельными по NORAD ID, чтобы фронтенд мог стилизовать их по отдельности.
+func MergeGroundTracks(tracks map[int]*GroundTrack) *MultiTrack {
+	merged := &MultiTrack{
+		Tracks: make(map[int]*GroundTrack, len(tracks)),
+		Bounds: BoundingBox{
+			MinLat: math.Inf(1),
+			MaxLat: math.Inf(-1),
+			MinLon: math.Inf(1),
+			MaxLon: math.Inf(-1),
+		},
+	}
+
+	for id, track := range tracks {
+		if track == nil {
+			continue
+		}
+
+		merged.Tracks[id] = track
+		merged.TotalPoints += track.PointCount()
+
+		for _, segments := range [][][]TrackPoint{track.Past, track.Future} {
+			for _, segment := range segments {
+				for _, p := range segment {
+					merged.Bounds.extend(p)
+				}
+			}
+		}
+	}
+
+	if merged.TotalPoints == 0 {
+		merged.Bounds = BoundingBox{}
+	}
+
+	return merged
+}
+
+// extend расширяет границы так, чтобы они включали точку p.
+func (b *BoundingBox) extend(p TrackPoint) {
+	b.MinLat = math.Min(b.MinLat, p.Lat)
+	b.MaxLat = math.Max(b.MaxLat, p.Lat)
+	b.MinLon = math.Min(b.MinLon, p.Lon)
+	b.MaxLon = math.Max(b.MaxLon, p.Lon)
+}
+
+// generateTrackPoints пропагирует спутник на интервале и преобразует позиции в точки трассы.
+// GMST рассчитывается один раз для начала интервала, для остальных точек — приращением.
+func generateTrackPoints(prop *Propagator, start, end time.Time, step time.Duration) ([]TrackPoint, error) {
+	positions, err := prop.PropagateRange(start, end, step)
+	if err != nil {
+		return nil, err
+	}
+
+	gmst0 := GMST(start)
+
+	points := make([]TrackPoint, 0, len(positions))
+	for _, pos := range positions {
+		points = append(points, trackPointWithGMST(pos, gmstAfter(gmst0, start, pos.Time)))
+	}
+
+	return points, nil
+}
+
+// trackPointFromECI преобразует позицию ECI в подспутниковую точку.
+func trackPointFromECI(pos *ECIPosition) TrackPoint {
+	return trackPointWithGMST(pos, GMST(pos.Time))
+}
+
+// trackPointWithGMST преобразует позицию ECI в подспутниковую точку с заранее рассчитанным GMST.
+func trackPointWithGMST(pos *ECIPosition, gmst float64) TrackPoint {
+	lla := ECEFToLLA(ECIToECEFWithGMST(pos, gmst))
+
+	return TrackPoint{
+		Lat:  lla.LatDeg(),
+		Lon:  lla.LonDeg(),
+		Alt:  lla.Alt,
+		Time: pos.Time,
+	}
+}
+
+// splitAtAntimeridian разбивает трассу на сегменты в местах пересечения антимеридиана.
+// В точке разрыва добавляются интерполированные граничные точки на ±180°,
+// чтобы соседние сегменты доходили до края карты.
+func splitAtAntimeridian(points []TrackPoint) [][]TrackPoint {
+	if len(points) == 0 {
+		return nil
+	}
+
+	var segments [][]TrackPoint
+
+	current := []TrackPoint{points[0]}
+
+	for i := 1; i < len(points); i++ {
+		prev, cur := points[i-1], points[i]
+
+		if math.Abs(cur.Lon-prev.Lon) <= 180 {
+			current = append(current, cur)
+			continue
+		}
+
+		// Пересечение антимеридиана: «разворачиваем» долготу текущей точки.
+		edge := 180.0
+		unwrapped := cur.Lon + 360
+		if prev.Lon < 0 {
+			edge = -180.0
+			unwrapped = cur.Lon - 360
+		}
+
+		f := (edge - prev.Lon) / (unwrapped - prev.Lon)
+		crossing := TrackPoint{
+			Lat:  prev.Lat + f*(cur.Lat-prev.Lat),
+			Lon:  edge,
+			Alt:  prev.Alt + f*(cur.Alt-prev.Alt),
+			Time: prev.Time.Add(time.Duration(f * float64(cur.Time.Sub(prev.Time)))),
+		}
+
+		current = append(current, crossing)
+		segments = append(segments, current)
+
+		crossing.Lon = -edge
+		current = []TrackPoint{crossing, cur}
+	}
+
+	return append(segments, current)
+}
+
+// GroundTrackIntersections находит точки пересечения подспутниковых трасс спутников a и b
+// на интервале [start, end] (пролёт через точку пересечения не обязательно одновременный).
+// Трассы выбираются с шагом step, соседние точки соединяются дугами большого круга на сфере;
+// пересечения дуг вычисляются в трёхмерных векторах, поэтому антимеридиан и полюса
+// не требуют особой обработки. Возвращает точки LLA (радианы) с нулевой высотой
+// в порядке обхода трассы a. Используется для планирования съёмки и калибровки на пересечениях.
+func GroundTrackIntersections(a, b *Propagator, start, end time.Time, step time.Duration) ([]*LLA, error) {
+	if a == nil || b == nil {
+		return nil, ErrNilTLE
+	}
+
+	if step <= 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStep, step)
+	}
+
+	trackA, err := surfaceTrack(a, start, end, step)
+	if err != nil {
+		return nil, err
+	}
+
+	trackB, err := surfaceTrack(b, start, end, step)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		crossings []*LLA
+		last      vec3
+	)
+
+	for i := 1; i < len(trackA); i++ {
+		for j := 1; j < len(trackB); j++ {
+			x, ok := arcIntersection(trackA[i-1], trackA[i], trackB[j-1], trackB[j])
+			if !ok {
+				continue
+			}
+
+			// Пересечение в общей вершине соседних дуг находится дважды.
+			if len(crossings) > 0 && angleBetween(x, last) < arcDuplicateAngle {
+				continue
+			}
+
+			last = x
+			crossings = append(crossings, &LLA{
+				Lat: math.Asin(math.Max(-1, math.Min(1, x.Z))),
+				Lon: math.Atan2(x.Y, x.X),
+			})
+		}
+	}
+
+	return crossings, nil
+}
+
+// arcDuplicateAngle — угол (радианы), ближе которого пересечения считаются совпадающими.
+const arcDuplicateAngle = 1e-9
+
+// surfaceTrack возвращает единичные векторы подспутниковых точек (ECEF) на интервале.
+func surfaceTrack(prop *Propagator, start, end time.Time, step time.Duration) ([]vec3, error) {
+	if end.Before(start) {
+		start, end = end, start
+	}
+
+	var track []vec3
+
+	for t := start; !t.After(end); t = t.Add(step) {
+		pos, err := prop.Propagate(t)
+		if err != nil {
+			return nil, fmt.Errorf("propagation at %v: %w", t, err)
+		}
+
+		point := trackPointFromECI(pos)
+		track = append(track, surfaceDir(point.Lat, point.Lon))
+	}
+
+	return track, nil
+}
+
+// arcIntersection возвращает точку пересечения коротких дуг большого круга p1–p2 и q1–q2.
+func arcIntersection(p1, p2, q1, q2 vec3) (vec3, bool) {
+	// Быстрое отсечение: дуги далеко друг от друга.
+	if angleBetween(p1.add(p2), q1.add(q2)) > (angleBetween(p1, p2)+angleBetween(q1, q2))/2 {
+		return vec3{}, false
+	}
+
+	n1, n2 := p1.cross(p2), q1.cross(q2)
+
+	x := n1.cross(n2).unit()
+	if x.norm() == 0 {
+		return vec3{}, false // Дуги на одном большом круге или вырождены.
+	}
+
+	// Большие круги пересекаются в двух противоположных точках; подходит та, что лежит на дугах.
+	for _, candidate := range []vec3{x, x.scale(-1)} {
+		if onArc(candidate, p1, p2, n1) && onArc(candidate, q1, q2, n2) {
+			return candidate, true
+		}
+	}
+
+	return vec3{}, false
+}
+
+// onArc проверяет, что точка x большого круга с нормалью n лежит между a и b.
+func onArc(x, a, b, n vec3) bool {
+	return a.cross(x).dot(n) >= 0 && x.cross(b).dot(n) >= 0
+}
+
+// StationKeepingExcursion возвращает интервалы, на которых долгота подспутниковой точки
+// геостационарного спутника выходит за пределы окна удержания nominalLonDeg ± boxHalfWidthDeg.
+// Долгота проверяется с шагом step, поэтому границы интервалов определены с точностью
+// до шага; интервал, продолжающийся до end, обрывается на end. Выходы из окна указывают
+// на пропущенную коррекцию или сбой удержания.
+func (p *Propagator) StationKeepingExcursion(nominalLonDeg, boxHalfWidthDeg float64, start, end time.Time, step time.Duration) ([]TimeWindow, error) {
+	if p == nil {
+		return nil, ErrNilPropagator
+	}
+
+	if step <= 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStep, step)
+	}
+
+	if !end.After(start) {
+		return nil, ErrInvalidWindow
+	}
+
+	var (
+		windows []TimeWindow
+		current *TimeWindow
+	)
+
+	for t := start; ; t = t.Add(step) {
+		t = minTime(t, end)
+
+		pos, err := p.Propagate(t)
+		if err != nil {
+			return nil, err
+		}
+
+		lonDeg := ECEFToLLA(ECIToECEF(pos)).Lon * Rad2Deg
+		outside := math.Abs(math.Remainder(lonDeg-nominalLonDeg, 360)) > boxHalfWidthDeg
+
+		switch {
+		case outside && current == nil:
+			current = &TimeWindow{Start: t, End: t}
+		case outside:
+			current.End = t
+		case current != nil:
+			current.End = t
+			windows = append(windows, *current)
+			current = nil
+		}
+
+		if !t.Before(end) {
+			break
+		}
+	}
+
+	if current != nil {
+		windows = append(windows, *current)
+	}
+
+	return windows, nil
+}
@@ -0,0 +1,338 @@
+package tracker
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestGenerateDefaultGroundTrack проверяет генерацию трассы ISS.
+func TestGenerateDefaultGroundTrack(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tle, err := ParseTLE([]string{"ISS (ZARYA)", issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	track, err := GenerateDefaultGroundTrack(tle, now)
+	if err != nil {
+		t.Fatalf("GenerateDefaultGroundTrack() error = %v", err)
+	}
+
+	if len(track.Past) == 0 || len(track.Future) == 0 {
+		t.Fatal("expected non-empty past and future tracks")
+	}
+
+	// Один виток назад и два вперёд с шагом 30 с — около 560 точек (плюс точки на антимеридиане).
+	if count := track.PointCount(); count < 500 || count > 600 {
+		t.Errorf("PointCount() = %d, expected 500-600", count)
+	}
+
+	const maxISSLat = 52.0
+
+	for _, segment := range append(track.Past, track.Future...) {
+		for i, p := range segment {
+			if math.Abs(p.Lat) > maxISSLat || math.Abs(p.Lon) > 180 {
+				t.Fatalf("point %+v out of range", p)
+			}
+
+			if i > 0 && math.Abs(p.Lon-segment[i-1].Lon) > 180 {
+				t.Fatalf("segment contains antimeridian jump at %+v", p)
+			}
+		}
+	}
+
+	if _, err := GenerateDefaultGroundTrack(nil, now); err == nil {
+		t.Error("GenerateDefaultGroundTrack(nil) expected error")
+	}
+}
+
+// TestSplitAtAntimeridian проверяет разбиение трассы и граничные точки.
+func TestSplitAtAntimeridian(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := []TrackPoint{
+		{Lat: 0, Lon: 170, Time: base},
+		{Lat: 2, Lon: 178, Time: base.Add(time.Minute)},
+		{Lat: 4, Lon: -178, Time: base.Add(2 * time.Minute)},
+		{Lat: 6, Lon: -170, Time: base.Add(3 * time.Minute)},
+	}
+
+	segments := splitAtAntimeridian(points)
+	if len(segments) != 2 {
+		t.Fatalf("got %d segments, want 2", len(segments))
+	}
+
+	last := segments[0][len(segments[0])-1]
+	first := segments[1][0]
+
+	if last.Lon != 180 || first.Lon != -180 {
+		t.Errorf("edge longitudes = %v/%v, want 180/-180", last.Lon, first.Lon)
+	}
+
+	if !almostEqual(last.Lat, 3, 1e-9) || last.Lat != first.Lat {
+		t.Errorf("edge latitude = %v/%v, want 3", last.Lat, first.Lat)
+	}
+
+	if splitAtAntimeridian(nil) != nil {
+		t.Error("splitAtAntimeridian(nil) should return nil")
+	}
+}
+
+// TestMergeGroundTracks проверяет объединение трасс созвездия.
+func TestMergeGroundTracks(t *testing.T) {
+	trackA := &GroundTrack{
+		NoradID: 1,
+		Past:    [][]TrackPoint{{{Lat: 10, Lon: 20}, {Lat: 15, Lon: 25}}},
+		Future:  [][]TrackPoint{{{Lat: 20, Lon: 30}}},
+	}
+	trackB := &GroundTrack{
+		NoradID: 2,
+		Past:    [][]TrackPoint{{{Lat: -40, Lon: -100}}},
+		Future:  [][]TrackPoint{{{Lat: -30, Lon: -90}}, {{Lat: -20, Lon: 150}}},
+	}
+
+	merged := MergeGroundTracks(map[int]*GroundTrack{1: trackA, 2: trackB})
+
+	if merged.TotalPoints != trackA.PointCount()+trackB.PointCount() {
+		t.Errorf("TotalPoints = %d, want %d", merged.TotalPoints, trackA.PointCount()+trackB.PointCount())
+	}
+
+	want := BoundingBox{MinLat: -40, MaxLat: 20, MinLon: -100, MaxLon: 150}
+	if merged.Bounds != want {
+		t.Errorf("Bounds = %+v, want %+v", merged.Bounds, want)
+	}
+
+	if merged.Tracks[1] != trackA || merged.Tracks[2] != trackB {
+		t.Error("merged tracks should keep per-satellite separation")
+	}
+
+	empty := MergeGroundTracks(nil)
+	if empty.TotalPoints != 0 || empty.Bounds != (BoundingBox{}) {
+		t.Errorf("MergeGroundTracks(nil) = %+v, want empty", empty)
+	}
+}
+
+// TestGenerateGroundTrackStream проверяет количество переданных точек и остановку по ошибке emit.
+func TestGenerateGroundTrackStream(t *testing.T) {
+	t.Parallel()
+
+	tle, err := ParseTLE([]string{"ISS (ZARYA)", issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	count := 0
+	last := start.Add(-time.Second)
+
+	err = GenerateGroundTrackStream(tle, start, end, time.Minute, func(p TrackPoint) error {
+		if !p.Time.After(last) {
+			t.Errorf("point %v is not after previous %v", p.Time, last)
+		}
+
+		last = p.Time
+		count++
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("GenerateGroundTrackStream() error = %v", err)
+	}
+
+	// 2 часа с шагом в минуту, включая обе границы.
+	if count != 121 {
+		t.Errorf("emitted %d points, want 121", count)
+	}
+
+	errStop := errors.New("stop")
+	emitted := 0
+
+	err = GenerateGroundTrackStream(tle, start, end, time.Minute, func(TrackPoint) error {
+		emitted++
+		if emitted == 5 {
+			return errStop
+		}
+
+		return nil
+	})
+	if !errors.Is(err, errStop) || emitted != 5 {
+		t.Errorf("GenerateGroundTrackStream() = %v after %d points, want errStop after 5", err, emitted)
+	}
+
+	if err := GenerateGroundTrackStream(tle, start, end, 0, func(TrackPoint) error { return nil }); err == nil {
+		t.Error("GenerateGroundTrackStream(step=0) expected error")
+	}
+}
+
+// TestPropagator_SubPointVelocityDeg проверяет скорость подспутниковой точки
+// для прямой экваториальной орбиты: движение на восток без изменения широты.
+func TestPropagator_SubPointVelocityDeg(t *testing.T) {
+	t.Parallel()
+
+	equatorialLine2 := makeTLELine("2 25544   0.1000 247.4627 0006703 130.5360 325.0288 15.4981557142340")
+
+	tle, err := ParseTLE([]string{issLine1, equatorialLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	prop, err := NewPropagator(tle)
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Проверяем весь виток, включая переход через антимеридиан.
+	for at := start; at.Before(start.Add(95 * time.Minute)); at = at.Add(time.Minute) {
+		dLat, dLon, err := prop.SubPointVelocityDeg(at)
+		if err != nil {
+			t.Fatalf("SubPointVelocityDeg(%v) error = %v", at, err)
+		}
+
+		// ~0.065°/с орбитального движения минус ~0.004°/с вращения Земли.
+		if dLon < 0.05 || dLon > 0.07 {
+			t.Fatalf("dLon at %v = %.5f°/s, want ~0.06°/s", at, dLon)
+		}
+
+		if math.Abs(dLat) > 1e-3 {
+			t.Fatalf("dLat at %v = %.5f°/s, want ~0", at, dLat)
+		}
+	}
+}
+
+// TestGroundTrackIntersections проверяет поиск пересечений трасс двух полярных спутников.
+func TestGroundTrackIntersections(t *testing.T) {
+	t.Parallel()
+
+	polar := func(noradID int, raan float64) *Propagator {
+		t.Helper()
+
+		return issTestPropagator(t, time.Time{}, func(tle *TLE) {
+			tle.NoradID, tle.Inclination, tle.RAAN = noradID, 89, raan
+		})
+	}
+
+	a, b := polar(90001, 0), polar(90002, 90)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+	step := 30 * time.Second
+
+	crossings, err := GroundTrackIntersections(a, b, start, end, step)
+	if err != nil {
+		t.Fatalf("GroundTrackIntersections() error = %v", err)
+	}
+
+	if len(crossings) == 0 {
+		t.Fatal("GroundTrackIntersections() found no crossings of polar tracks")
+	}
+
+	trackA, err := surfaceTrack(a, start, end, step)
+	if err != nil {
+		t.Fatalf("surfaceTrack() error = %v", err)
+	}
+
+	trackB, err := surfaceTrack(b, start, end, step)
+	if err != nil {
+		t.Fatalf("surfaceTrack() error = %v", err)
+	}
+
+	// За шаг 30 с подспутниковая точка МКС смещается примерно на 2°.
+	const maxDistDeg = 2.0
+
+	nearest := func(track []vec3, x vec3) float64 {
+		best := math.Pi
+		for _, p := range track {
+			best = math.Min(best, angleBetween(p, x))
+		}
+
+		return best * Rad2Deg
+	}
+
+	for _, c := range crossings {
+		x := surfaceDir(c.LatDeg(), c.LonDeg())
+
+		if da, db := nearest(trackA, x), nearest(trackB, x); da > maxDistDeg || db > maxDistDeg {
+			t.Errorf("crossing %.2f°, %.2f° is %.2f° / %.2f° from tracks", c.LatDeg(), c.LonDeg(), da, db)
+		}
+	}
+
+	if _, err := GroundTrackIntersections(a, b, start, end, 0); !errors.Is(err, ErrInvalidStep) {
+		t.Errorf("GroundTrackIntersections(step=0) error = %v, want ErrInvalidStep", err)
+	}
+}
+
+// TestPropagator_StationKeepingExcursion проверяет выходы геостационарного спутника
+// из окна удержания: в узком окне суточная либрация долготы (~±0.01° при e = 1e-4)
+// даёт выходы, в широком — нет.
+func TestPropagator_StationKeepingExcursion(t *testing.T) {
+	t.Parallel()
+
+	prop := createGEOTestPropagator(t)
+	start := prop.TLE().Epoch
+	end := start.Add(48 * time.Hour)
+
+	tight, err := prop.StationKeepingExcursion(0, 0.005, start, end, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("StationKeepingExcursion() error = %v", err)
+	}
+
+	if len(tight) < 2 {
+		t.Fatalf("tight box: %d excursions, want at least one per day", len(tight))
+	}
+
+	for i, w := range tight {
+		if w.End.Before(w.Start) || w.Start.Before(start) || w.End.After(end) {
+			t.Errorf("excursion %d = %v–%v outside [%v, %v]", i, w.Start, w.End, start, end)
+		}
+
+		if i > 0 && !w.Start.After(tight[i-1].End) {
+			t.Errorf("excursion %d starts at %v before previous end %v", i, w.Start, tight[i-1].End)
+		}
+	}
+
+	wide, err := prop.StationKeepingExcursion(0, 0.1, start, end, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("StationKeepingExcursion() error = %v", err)
+	}
+
+	if len(wide) != 0 {
+		t.Errorf("wide box: %d excursions, want none", len(wide))
+	}
+
+	// Номинальная долгота в другом полушарии — весь интервал вне окна.
+	away, err := prop.StationKeepingExcursion(180, 0.1, start, end, 10*time.Minute)
+	if err != nil {
+		t.Fatalf("StationKeepingExcursion() error = %v", err)
+	}
+
+	if len(away) != 1 || !away[0].Start.Equal(start) || !away[0].End.Equal(end) {
+		t.Errorf("nominal 180°: excursions = %v, want single [start, end]", away)
+	}
+
+	if _, err := prop.StationKeepingExcursion(0, 0.1, start, end, 0); !errors.Is(err, ErrInvalidStep) {
+		t.Errorf("StationKeepingExcursion(step=0) error = %v, want ErrInvalidStep", err)
+	}
+}
+
+// BenchmarkGenerateGroundTrack измеряет расчёт трассы ISS на виток назад и два вперёд с шагом 10 секунд.
+func BenchmarkGenerateGroundTrack(b *testing.B) {
+	tle, err := ParseTLE([]string{issLine1, issLine2})
+	if err != nil {
+		b.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	period := time.Duration(tle.OrbitalPeriod() * float64(time.Minute))
+
+	for b.Loop() {
+		if _, err := GenerateGroundTrack(tle, tle.Epoch, period, 2*period, 10*time.Second); err != nil {
+			b.Fatalf("GenerateGroundTrack() error = %v", err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package tracker
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ReconstructHistory восстанавливает траекторию спутника на интервале [start, end]
+// с шагом step по набору исторических TLE одного спутника. Для каждого момента
+// пропагируется TLE с ближайшей эпохой (при равенстве — более поздний), поэтому
+// ошибка SGP4, растущая с удалением от эпохи, остаётся минимальной; смена TLE
+// происходит посередине между соседними эпохами. Порядок tles не важен.
+func ReconstructHistory(tles []*TLE, start, end time.Time, step time.Duration) ([]*ECIPosition, error) {
+	if len(tles) == 0 {
+		return nil, fmt.Errorf("%w: no historical TLEs", ErrNilTLE)
+	}
+
+	if step <= 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStep, step)
+	}
+
+	if end.Before(start) {
+		start, end = end, start
+	}
+
+	props := make([]*Propagator, len(tles))
+
+	for i, tle := range tles {
+		prop, err := NewPropagator(tle)
+		if err != nil {
+			return nil, fmt.Errorf("historical TLE %d: %w", i, err)
+		}
+
+		props[i] = prop
+	}
+
+	slices.SortStableFunc(props, func(a, b *Propagator) int {
+		return a.tle.Epoch.Compare(b.tle.Epoch)
+	})
+
+	var (
+		positions []*ECIPosition
+		current   int
+	)
+
+	for t := start; !t.After(end); t = t.Add(step) {
+		// Моменты возрастают, поэтому ближайшая эпоха может только сдвигаться вперёд.
+		for current+1 < len(props) &&
+			absDuration(props[current+1].tle.Epoch.Sub(t)) <= absDuration(props[current].tle.Epoch.Sub(t)) {
+			current++
+		}
+
+		pos, err := props[current].Propagate(t)
+		if err != nil {
+			return positions, fmt.Errorf("propagation at %v (TLE epoch %v): %w", t, props[current].tle.Epoch, err)
+		}
+
+		positions = append(positions, pos)
+	}
+
+	return positions, nil
+}
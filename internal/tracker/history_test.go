@@ -0,0 +1,73 @@
+package tracker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestReconstructHistory проверяет, что восстановление траектории переключается
+// на следующий TLE посередине между эпохами.
+func TestReconstructHistory(t *testing.T) {
+	t.Parallel()
+
+	older, err := ParseTLE([]string{issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	newer := issVariant(t, "25544", "145.0288") // Другая фаза, чтобы TLE различались.
+	newer.Epoch = older.Epoch.Add(48 * time.Hour)
+
+	if newer.Line1, newer.Line2, err = newer.ToLines(); err != nil {
+		t.Fatalf("ToLines() error = %v", err)
+	}
+
+	start := older.Epoch
+	midpoint := older.Epoch.Add(24 * time.Hour)
+	end := newer.Epoch
+
+	// Порядок входных TLE не важен.
+	history, err := ReconstructHistory([]*TLE{newer, older}, start, end, time.Hour)
+	if err != nil {
+		t.Fatalf("ReconstructHistory() error = %v", err)
+	}
+
+	if len(history) != 49 {
+		t.Fatalf("ReconstructHistory() returned %d points, want 49", len(history))
+	}
+
+	olderProp, err := NewPropagator(older)
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	newerProp, err := NewPropagator(newer)
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	for _, pos := range history {
+		prop, name := olderProp, "older"
+		if !pos.Time.Before(midpoint) {
+			prop, name = newerProp, "newer"
+		}
+
+		want, err := prop.Propagate(pos.Time)
+		if err != nil {
+			t.Fatalf("Propagate() error = %v", err)
+		}
+
+		if *pos != *want {
+			t.Errorf("position at %v = %v, want %s TLE %v", pos.Time, pos, name, want)
+		}
+	}
+
+	if _, err := ReconstructHistory(nil, start, end, time.Hour); !errors.Is(err, ErrNilTLE) {
+		t.Errorf("ReconstructHistory(nil) error = %v, want ErrNilTLE", err)
+	}
+
+	if _, err := ReconstructHistory([]*TLE{older}, start, end, 0); !errors.Is(err, ErrInvalidStep) {
+		t.Errorf("ReconstructHistory(step=0) error = %v, want ErrInvalidStep", err)
+	}
+}
@@ -0,0 +1,94 @@
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Ошибки профиля горизонта.
+var (
+	ErrEmptyHorizonMask   = errors.New("horizon mask has no points")
+	ErrInvalidMaskAzimuth = errors.New("mask azimuth must be within [0, 360) degrees")
+	ErrInvalidMaskElev    = errors.New("mask elevation must be within [-90, 90] degrees")
+)
+
+// MaskPoint — точка профиля горизонта: угол места препятствия на заданном азимуте.
+type MaskPoint struct {
+	AzDeg float64 // Азимут, градусы [0, 360).
+	ElDeg float64 // Угол места кромки препятствия, градусы.
+}
+
+// HorizonMask — профиль горизонта наблюдателя (рельеф, здания), упорядоченный по азимуту.
+// Между точками угол места интерполируется линейно, с переходом через 0°/360°.
+type HorizonMask []MaskPoint
+
+// NewHorizonMask создаёт профиль горизонта из точек в произвольном порядке.
+func NewHorizonMask(points ...MaskPoint) (HorizonMask, error) {
+	if len(points) == 0 {
+		return nil, ErrEmptyHorizonMask
+	}
+
+	mask := make(HorizonMask, len(points))
+	copy(mask, points)
+
+	for _, p := range mask {
+		if math.IsNaN(p.AzDeg) || p.AzDeg < 0 || p.AzDeg >= 360 {
+			return nil, fmt.Errorf("%w: got %v", ErrInvalidMaskAzimuth, p.AzDeg)
+		}
+
+		if math.IsNaN(p.ElDeg) || p.ElDeg < -90 || p.ElDeg > 90 {
+			return nil, fmt.Errorf("%w: got %v", ErrInvalidMaskElev, p.ElDeg)
+		}
+	}
+
+	sort.Slice(mask, func(i, j int) bool { return mask[i].AzDeg < mask[j].AzDeg })
+
+	return mask, nil
+}
+
+// ElevationAt возвращает угол места кромки горизонта на азимуте azDeg, градусы.
+// Для пустого профиля возвращает 0 (математический горизонт).
+func (m HorizonMask) ElevationAt(azDeg float64) float64 {
+	if len(m) == 0 {
+		return 0
+	}
+
+	if len(m) == 1 {
+		return m[0].ElDeg
+	}
+
+	az := math.Mod(azDeg, 360)
+	if az < 0 {
+		az += 360
+	}
+
+	// Первая точка с азимутом больше az; соседние точки берутся по кругу.
+	i := sort.Search(len(m), func(i int) bool { return m[i].AzDeg > az })
+
+	lo, hi := m[(i-1+len(m))%len(m)], m[i%len(m)]
+
+	span := hi.AzDeg - lo.AzDeg
+	if span <= 0 {
+		span += 360
+	}
+
+	offset := az - lo.AzDeg
+	if offset < 0 {
+		offset += 360
+	}
+
+	return lo.ElDeg + (hi.ElDeg-lo.ElDeg)*offset/span
+}
+
+// IsObstructed сообщает, закрыт ли спутник с координатами aer рельефом
+// или горизонтом: угол места ниже профиля горизонта наблюдателя на этом азимуте.
+// Без профиля используется математический горизонт (0°).
+func (obs *Observer) IsObstructed(aer *AER) bool {
+	if obs == nil || aer == nil {
+		return true
+	}
+
+	return aer.ElDeg() < obs.HorizonMask.ElevationAt(aer.AzDeg())
+}
@@ -0,0 +1,92 @@
+package tracker
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestHorizonMask_ElevationAt проверяет интерполяцию профиля, в том числе через 0°/360°.
+func TestHorizonMask_ElevationAt(t *testing.T) {
+	mask, err := NewHorizonMask(
+		MaskPoint{AzDeg: 270, ElDeg: 0},
+		MaskPoint{AzDeg: 90, ElDeg: 20},
+		MaskPoint{AzDeg: 350, ElDeg: 10},
+	)
+	if err != nil {
+		t.Fatalf("NewHorizonMask() error = %v", err)
+	}
+
+	tests := []struct {
+		azDeg float64
+		want  float64
+	}{
+		{azDeg: 90, want: 20},
+		{azDeg: 180, want: 10},
+		{azDeg: 310, want: 5},
+		{azDeg: 10, want: 12},
+		{azDeg: 370, want: 12},
+		{azDeg: -10, want: 10},
+	}
+
+	for _, tt := range tests {
+		if got := mask.ElevationAt(tt.azDeg); !almostEqual(got, tt.want, 1e-9) {
+			t.Errorf("ElevationAt(%v) = %v, want %v", tt.azDeg, got, tt.want)
+		}
+	}
+
+	if got := HorizonMask(nil).ElevationAt(123); got != 0 {
+		t.Errorf("nil mask ElevationAt() = %v, want 0", got)
+	}
+
+	if _, err := NewHorizonMask(); !errors.Is(err, ErrEmptyHorizonMask) {
+		t.Errorf("NewHorizonMask() error = %v, want ErrEmptyHorizonMask", err)
+	}
+
+	if _, err := NewHorizonMask(MaskPoint{AzDeg: 360}); !errors.Is(err, ErrInvalidMaskAzimuth) {
+		t.Errorf("NewHorizonMask(az=360) error = %v, want ErrInvalidMaskAzimuth", err)
+	}
+}
+
+// TestObserver_IsObstructed проверяет, что гора в восточном секторе
+// закрывает спутник на этом азимуте, но не на западе.
+func TestObserver_IsObstructed(t *testing.T) {
+	mask, err := NewHorizonMask(
+		MaskPoint{AzDeg: 45, ElDeg: 0},
+		MaskPoint{AzDeg: 60, ElDeg: 25},
+		MaskPoint{AzDeg: 120, ElDeg: 25},
+		MaskPoint{AzDeg: 135, ElDeg: 0},
+	)
+	if err != nil {
+		t.Fatalf("NewHorizonMask() error = %v", err)
+	}
+
+	obs := NewObserver(43.35, 42.44, 2.0)
+	obs.HorizonMask = mask
+
+	tests := []struct {
+		name  string
+		azDeg float64
+		elDeg float64
+		want  bool
+	}{
+		{name: "behind mountain", azDeg: 90, elDeg: 15, want: true},
+		{name: "above mountain", azDeg: 90, elDeg: 30, want: false},
+		{name: "open sector", azDeg: 270, elDeg: 5, want: false},
+		{name: "below horizon", azDeg: 270, elDeg: -1, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aer := &AER{Az: tt.azDeg * Deg2Rad, El: tt.elDeg * Deg2Rad}
+			if got := obs.IsObstructed(aer); got != tt.want {
+				t.Errorf("IsObstructed(az=%v, el=%v) = %v, want %v", tt.azDeg, tt.elDeg, got, tt.want)
+			}
+		})
+	}
+
+	// Без профиля — математический горизонт.
+	plain := NewObserver(43.35, 42.44, 2.0)
+	if plain.IsObstructed(&AER{Az: 90 * Deg2Rad, El: 15 * Deg2Rad}) {
+		t.Error("observer without mask should not be obstructed above 0°")
+	}
+}
@@ -0,0 +1,206 @@
+package tracker
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrNilPass возвращается, если пролёт не задан.
+var ErrNilPass = errors.New("pass is nil")
+
+// SpeedOfLightKmS — скорость света в вакууме, км/с.
+const SpeedOfLightKmS = 299792.458
+
+// fsplConstKmGHz — константа формулы потерь в свободном пространстве
+// для дальности в километрах и частоты в гигагерцах.
+const fsplConstKmGHz = 92.45
+
+// FreeSpacePathLossDB возвращает потери в свободном пространстве, дБ.
+// Формула: FSPL = 20·log10(d, км) + 20·log10(f, ГГц) + 92.45.
+func FreeSpacePathLossDB(rangeKm, freqHz float64) float64 {
+	freqGHz := freqHz / 1e9
+
+	return 20*math.Log10(rangeKm) + 20*math.Log10(freqGHz) + fsplConstKmGHz
+}
+
+// boltzmannDBW — постоянная Больцмана, дБВт/(К·Гц).
+const boltzmannDBW = -228.6
+
+// MinElevationForLinkDB возвращает минимальный угол места (градусы), при котором
+// линия спутника на высоте altitudeKm замыкается: C/N0 = EIRP + G/T − FSPL − k
+// не ниже requiredCN0DBHz — требуемого C/N0, дБ·Гц (Eb/N0 + 10·log10(скорость, бит/с)
+// + запас). Потери FSPL рассчитываются по наклонной дальности для угла места
+// (сферическая Земля). Если линия замыкается уже у горизонта, возвращается 0;
+// false — линия не замыкается даже в зените.
+func MinElevationForLinkDB(requiredCN0DBHz, eirpDBW, gOverTDB, freqHz, altitudeKm float64) (float64, bool) {
+	if altitudeKm <= 0 || freqHz <= 0 {
+		return 0, false
+	}
+
+	// Наибольшие допустимые потери и соответствующая им дальность.
+	maxLossDB := eirpDBW + gOverTDB - boltzmannDBW - requiredCN0DBHz
+	maxRangeKm := math.Pow(10, (maxLossDB-20*math.Log10(freqHz/1e9)-fsplConstKmGHz)/20)
+
+	switch {
+	case maxRangeKm < altitudeKm:
+		return 0, false
+	case maxRangeKm >= slantRangeKm(altitudeKm, 0):
+		return 0, true
+	}
+
+	// Теорема косинусов для треугольника центр Земли — наблюдатель — спутник:
+	// (R+h)² = R² + ρ² + 2Rρ·sin(E).
+	r := earthRadiusMeanKm
+	sinEl := ((r+altitudeKm)*(r+altitudeKm) - r*r - maxRangeKm*maxRangeKm) / (2 * r * maxRangeKm)
+
+	return math.Asin(math.Max(-1, math.Min(1, sinEl))) * Rad2Deg, true
+}
+
+// slantRangeKm возвращает наклонную дальность до спутника на высоте altitudeKm
+// при угле места elDeg (сферическая Земля).
+func slantRangeKm(altitudeKm, elDeg float64) float64 {
+	r := earthRadiusMeanKm
+	sinEl, cosEl := math.Sincos(elDeg * Deg2Rad)
+
+	return math.Sqrt((r+altitudeKm)*(r+altitudeKm)-r*r*cosEl*cosEl) - r*sinEl
+}
+
+// MinSlantRange оценивает наименьшее расстояние от наблюдателя до спутника, км, —
+// лучший случай для бюджета линии без поиска пролётов. Если широта наблюдателя
+// в полосе трассы (|φ| ≤ min(i, 180°−i)), возможен пролёт через зенит в перигее:
+// расстояние равно высоте перигея над наблюдателем, второй результат true.
+// Иначе ближайшая точка — на краю полосы трассы; возвращается дальность до неё
+// на высоте перигея и false. Оценка не учитывает, совпадает ли перигей с зенитом.
+func (tle *TLE) MinSlantRange(obs *Observer) (float64, bool) {
+	if tle == nil || obs == nil || tle.MeanMotion <= 0 {
+		return math.NaN(), false
+	}
+
+	satRadius := tle.SemiMajorAxis() * (1 - tle.Eccentricity)
+	obsRadius := WGS84A + obs.Alt
+
+	maxLatDeg := math.Min(tle.Inclination, 180-tle.Inclination)
+	offsetDeg := math.Abs(obs.Lat) - maxLatDeg
+
+	if offsetDeg <= 0 {
+		return satRadius - obsRadius, true
+	}
+
+	// Теорема косинусов для центрального угла между наблюдателем и краем полосы.
+	cosOffset := math.Cos(offsetDeg * Deg2Rad)
+
+	return math.Sqrt(satRadius*satRadius + obsRadius*obsRadius - 2*satRadius*obsRadius*cosOffset), false
+}
+
+// DopplerShift возвращает частоту нисходящего канала downlinkHz, принимаемую
+// наблюдателем с учётом эффекта Доплера: f = f0·(1 − ṙ/c), где ṙ — скорость
+// изменения дальности (см. AER.RangeRate). При сближении частота выше номинальной.
+func (obs *Observer) DopplerShift(eci *ECIPosition, downlinkHz float64) float64 {
+	aer := obs.GetAER(eci)
+	if aer == nil {
+		return math.NaN()
+	}
+
+	return dopplerFrequency(downlinkHz, aer.RangeRate)
+}
+
+// dopplerFrequency возвращает принимаемую частоту при скорости изменения дальности rangeRateKmS.
+func dopplerFrequency(freqHz, rangeRateKmS float64) float64 {
+	return freqHz * (1 - rangeRateKmS/SpeedOfLightKmS)
+}
+
+// ionosphereWarningElevationDeg — угол места, ниже которого ионосферная рефракция
+// и дополнительный доплеровский сдвиг в ионосфере заметно искажают расчётную частоту.
+const ionosphereWarningElevationDeg = 10.0
+
+// TuningFrequency возвращает частоту настройки приёмника для нисходящего канала
+// freqHz с учётом эффекта Доплера (см. DopplerShift). lowElevationWarning сообщает,
+// что спутник ниже ~10° над горизонтом: здесь модель не учитывает ионосферные
+// эффекты, и на КВ/УКВ частоту может потребоваться подстраивать вручную.
+func (obs *Observer) TuningFrequency(eci *ECIPosition, freqHz float64) (tunedHz float64, lowElevationWarning bool) {
+	aer := obs.GetAER(eci)
+	if aer == nil {
+		return math.NaN(), true
+	}
+
+	return dopplerFrequency(freqHz, aer.RangeRate), aer.ElDeg() < ionosphereWarningElevationDeg
+}
+
+// dopplerRateSampleStep — шаг выборки доплеровской кривой при поиске максимальной скорости её изменения.
+const dopplerRateSampleStep = time.Second
+
+// MaxDopplerRate возвращает максимальную по модулю скорость изменения доплеровского
+// сдвига |dF/dt| (Гц/с) на пролёте и момент, когда она достигается. Кривая частоты
+// выбирается с шагом 1 с, производная — центральная разность. Максимум приходится
+// на окрестность TCA и определяет требования к петле АПЧ приёмника.
+func (obs *Observer) MaxDopplerRate(prop *Propagator, pass *Pass, freqHz float64) (float64, time.Time, error) {
+	if obs == nil {
+		return 0, time.Time{}, ErrNilObserver
+	}
+
+	if prop == nil {
+		return 0, time.Time{}, ErrNilPropagator
+	}
+
+	if pass == nil {
+		return 0, time.Time{}, ErrNilPass
+	}
+
+	freqAt := func(t time.Time) (float64, error) {
+		pos, err := prop.propagatePrecise(t)
+		if err != nil {
+			return 0, err
+		}
+
+		return obs.DopplerShift(pos, freqHz), nil
+	}
+
+	var (
+		maxRate float64
+		maxAt   = pass.TCA
+	)
+
+	for t := pass.AOS.Add(dopplerRateSampleStep); t.Before(pass.LOS); t = t.Add(dopplerRateSampleStep) {
+		before, err := freqAt(t.Add(-dopplerRateSampleStep))
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+
+		after, err := freqAt(t.Add(dopplerRateSampleStep))
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+
+		if rate := math.Abs(after-before) / (2 * dopplerRateSampleStep.Seconds()); rate > maxRate {
+			maxRate, maxAt = rate, t
+		}
+	}
+
+	return maxRate, maxAt, nil
+}
+
+// PassPathLoss возвращает потери в свободном пространстве в моменты AOS, TCA и LOS пролёта.
+// Наибольшие потери — у горизонта (максимальная дальность), наименьшие — в кульминации.
+func (obs *Observer) PassPathLoss(prop *Propagator, pass *Pass, freqHz float64) (aosDB, tcaDB, losDB float64, err error) {
+	if obs == nil {
+		return 0, 0, 0, ErrNilObserver
+	}
+
+	if pass == nil {
+		return 0, 0, 0, ErrNilPass
+	}
+
+	var losses [3]float64
+
+	for i, t := range [3]time.Time{pass.AOS, pass.TCA, pass.LOS} {
+		pos, err := prop.Propagate(t)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		losses[i] = FreeSpacePathLossDB(obs.GetAER(pos).Range, freqHz)
+	}
+
+	return losses[0], losses[1], losses[2], nil
+}
@@ -0,0 +1,268 @@
+package tracker
+
+import (
+	"testing"
+	"time"
+)
+
+// TestFreeSpacePathLossDB проверяет формулу FSPL на известных значениях.
+func TestFreeSpacePathLossDB(t *testing.T) {
+	tests := []struct {
+		name    string
+		rangeKm float64
+		freqHz  float64
+		want    float64
+	}{
+		{name: "1 km, 1 GHz", rangeKm: 1, freqHz: 1e9, want: 92.45},
+		{name: "1000 km, 137.1 MHz", rangeKm: 1000, freqHz: 137.1e6, want: 135.19},
+		{name: "doubling range adds 6 dB", rangeKm: 2, freqHz: 1e9, want: 98.47},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FreeSpacePathLossDB(tt.rangeKm, tt.freqHz)
+			if !almostEqual(got, tt.want, 0.01) {
+				t.Errorf("FreeSpacePathLossDB() = %.3f, want %.3f", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestObserver_PassPathLoss проверяет, что потери максимальны у горизонта и минимальны в TCA.
+func TestObserver_PassPathLoss(t *testing.T) {
+	prop := createTestPropagator(t)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	pass, err := prop.NextPass(passTestMoscow, start, 0)
+	if err != nil {
+		t.Fatalf("NextPass() error = %v", err)
+	}
+
+	aos, tca, los, err := passTestMoscow.PassPathLoss(prop, pass, 145.8e6)
+	if err != nil {
+		t.Fatalf("PassPathLoss() error = %v", err)
+	}
+
+	if tca >= aos || tca >= los {
+		t.Errorf("TCA loss %.2f dB should be lower than AOS %.2f dB and LOS %.2f dB", tca, aos, los)
+	}
+
+	if _, _, _, err := passTestMoscow.PassPathLoss(prop, nil, 145.8e6); err == nil {
+		t.Error("PassPathLoss(nil pass) expected error")
+	}
+}
+
+// TestObserver_DopplerShift проверяет знак доплеровского сдвига на пролёте МКС
+// и согласованность RangeRate с изменением дальности.
+func TestObserver_DopplerShift(t *testing.T) {
+	t.Parallel()
+
+	const downlinkHz = 145.8e6 // Радиолюбительский канал МКС.
+
+	prop := createTestPropagator(t)
+
+	pass, err := prop.NextPass(passTestMoscow, passTestStart.Add(30*time.Minute), 0)
+	if err != nil {
+		t.Fatalf("NextPass() error = %v", err)
+	}
+
+	shiftAt := func(at time.Time) float64 {
+		pos, err := prop.Propagate(at)
+		if err != nil {
+			t.Fatalf("Propagate() error = %v", err)
+		}
+
+		return passTestMoscow.DopplerShift(pos, downlinkHz) - downlinkHz
+	}
+
+	approaching := shiftAt(pass.AOS.Add(time.Minute))
+	receding := shiftAt(pass.LOS.Add(-time.Minute))
+
+	// Для МКС на УКВ сдвиг у горизонта — единицы килогерц.
+	if approaching < 1e3 || approaching > 5e3 {
+		t.Errorf("shift before TCA = %.0f Hz, want +1..5 kHz", approaching)
+	}
+
+	if receding > -1e3 || receding < -5e3 {
+		t.Errorf("shift after TCA = %.0f Hz, want -1..-5 kHz", receding)
+	}
+
+	// RangeRate совпадает с конечной разностью дальности.
+	at := pass.AOS.Add(2 * time.Minute)
+
+	before, err := prop.Propagate(at.Add(-time.Second))
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	after, err := prop.Propagate(at.Add(time.Second))
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	mid, err := prop.Propagate(at)
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	numeric := (passTestMoscow.GetAER(after).Range - passTestMoscow.GetAER(before).Range) / 2
+	if got := passTestMoscow.GetAER(mid).RangeRate; !almostEqual(got, numeric, 0.01) {
+		t.Errorf("RangeRate = %.4f km/s, finite difference %.4f km/s", got, numeric)
+	}
+}
+
+// TestObserver_MaxDopplerRate проверяет, что пик скорости изменения доплеровского
+// сдвига приходится на окрестность TCA.
+func TestObserver_MaxDopplerRate(t *testing.T) {
+	t.Parallel()
+
+	const downlinkHz = 437.8e6
+
+	prop := createTestPropagator(t)
+
+	pass, err := prop.NextPass(passTestMoscow, passTestStart.Add(30*time.Minute), 0)
+	if err != nil {
+		t.Fatalf("NextPass() error = %v", err)
+	}
+
+	rate, at, err := passTestMoscow.MaxDopplerRate(prop, pass, downlinkHz)
+	if err != nil {
+		t.Fatalf("MaxDopplerRate() error = %v", err)
+	}
+
+	if diff := absDuration(at.Sub(pass.TCA)); diff > 30*time.Second {
+		t.Errorf("peak Doppler rate at %v, %v away from TCA %v", at, diff, pass.TCA)
+	}
+
+	// На 70 см МКС даёт десятки — сотни Гц/с в зависимости от высоты пролёта.
+	if rate < 10 || rate > 1000 {
+		t.Errorf("MaxDopplerRate() = %.1f Hz/s, expected 10-1000 Hz/s", rate)
+	}
+
+	if _, _, err := passTestMoscow.MaxDopplerRate(prop, nil, downlinkHz); err == nil {
+		t.Error("MaxDopplerRate(nil pass) should fail")
+	}
+}
+
+// TestObserver_TuningFrequency проверяет частоту настройки и предупреждение
+// о ионосферных эффектах у горизонта.
+func TestObserver_TuningFrequency(t *testing.T) {
+	t.Parallel()
+
+	const downlinkHz = 145.8e6
+
+	prop := createTestPropagator(t)
+
+	pass, err := prop.NextPass(passTestMoscow, passTestStart.Add(30*time.Minute), 0)
+	if err != nil {
+		t.Fatalf("NextPass() error = %v", err)
+	}
+
+	if pass.MaxElDeg < 2*ionosphereWarningElevationDeg {
+		t.Fatalf("pass max elevation %.1f° too low for the test", pass.MaxElDeg)
+	}
+
+	tests := []struct {
+		name        string
+		at          time.Time
+		wantWarning bool
+	}{
+		{name: "near AOS", at: pass.AOS.Add(30 * time.Second), wantWarning: true},
+		{name: "at TCA", at: pass.TCA, wantWarning: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pos, err := prop.Propagate(tt.at)
+			if err != nil {
+				t.Fatalf("Propagate() error = %v", err)
+			}
+
+			tuned, warning := passTestMoscow.TuningFrequency(pos, downlinkHz)
+			if warning != tt.wantWarning {
+				t.Errorf("lowElevationWarning = %v, want %v (el %.1f°)", warning, tt.wantWarning, passTestMoscow.GetAER(pos).ElDeg())
+			}
+
+			if want := passTestMoscow.DopplerShift(pos, downlinkHz); tuned != want {
+				t.Errorf("tunedHz = %.1f, want %.1f", tuned, want)
+			}
+		})
+	}
+}
+
+// TestMinElevationForLinkDB проверяет, что более жёсткие требования к C/N0 требуют
+// большего угла места, а найденный угол действительно замыкает линию.
+func TestMinElevationForLinkDB(t *testing.T) {
+	t.Parallel()
+
+	const (
+		eirpDBW    = 10.0
+		gOverTDB   = -10.0
+		freqHz     = 2.2e9
+		altitudeKm = 420.0
+	)
+
+	// У горизонта C/N0 ≈ 62 дБ·Гц, в зените ≈ 77 дБ·Гц.
+	if el, ok := MinElevationForLinkDB(60, eirpDBW, gOverTDB, freqHz, altitudeKm); !ok || el != 0 {
+		t.Errorf("MinElevationForLinkDB(60) = %.2f, %v, want 0, true", el, ok)
+	}
+
+	if _, ok := MinElevationForLinkDB(80, eirpDBW, gOverTDB, freqHz, altitudeKm); ok {
+		t.Error("MinElevationForLinkDB(80) ok = true, want link not closing at zenith")
+	}
+
+	prev := 0.0
+
+	for _, required := range []float64{65, 70, 75} {
+		el, ok := MinElevationForLinkDB(required, eirpDBW, gOverTDB, freqHz, altitudeKm)
+		if !ok {
+			t.Fatalf("MinElevationForLinkDB(%v) ok = false", required)
+		}
+
+		if el <= prev {
+			t.Errorf("MinElevationForLinkDB(%v) = %.2f°, want above %.2f°", required, el, prev)
+		}
+
+		cn0 := eirpDBW + gOverTDB - boltzmannDBW - FreeSpacePathLossDB(slantRangeKm(altitudeKm, el), freqHz)
+		if !almostEqual(cn0, required, 1e-6) {
+			t.Errorf("C/N0 at %.2f° = %.4f dB-Hz, want %v", el, cn0, required)
+		}
+
+		prev = el
+	}
+}
+
+// TestTLE_MinSlantRange проверяет наименьшую дальность для наблюдателей
+// внутри и вне полосы трассы МКС (наклонение 51.6°).
+func TestTLE_MinSlantRange(t *testing.T) {
+	t.Parallel()
+
+	perigee := regimeTestLEO.Perigee()
+
+	inside, ok := regimeTestLEO.MinSlantRange(passTestRostov)
+	if !ok || !almostEqual(inside, perigee-passTestRostov.Alt, 1e-6) {
+		t.Errorf("MinSlantRange(Rostov) = %.2f, %v, want perigee %.2f, true", inside, ok, perigee-passTestRostov.Alt)
+	}
+
+	outside, ok := regimeTestLEO.MinSlantRange(passTestMoscow)
+	if ok {
+		t.Error("MinSlantRange(Moscow) ok = true, want no overhead pass at 55.8°")
+	}
+
+	// Москва в ~4° к северу от края полосы: ~450 км по поверхности, дальность 550–700 км.
+	if outside <= inside || outside < 550 || outside > 700 {
+		t.Errorf("MinSlantRange(Moscow) = %.2f, want 550–700 km and above %.2f", outside, inside)
+	}
+
+	// Ретроградная солнечно-синхронная орбита проходит над Москвой.
+	sso := &TLE{MeanMotion: 14.2, Eccentricity: 0.001, Inclination: 98.2}
+	if _, ok := sso.MinSlantRange(passTestMoscow); !ok {
+		t.Error("MinSlantRange(SSO, Moscow) ok = false, want overhead pass possible")
+	}
+
+	if _, ok := (*TLE)(nil).MinSlantRange(passTestMoscow); ok {
+		t.Error("MinSlantRange(nil TLE) ok = true, want false")
+	}
+}
@@ -0,0 +1,134 @@
+package tracker
+
+import (
+	"fmt"
+	"time"
+)
+
+// LiveTrack — трасса для живой карты, которая наращивается с течением времени.
+// Вместо пересчёта всей трассы на каждом такте Advance добавляет только новые точки
+// и отбрасывает те, что вышли за пределы хвоста длиной window.
+// LiveTrack не потокобезопасен.
+type LiveTrack struct {
+	prop   *Propagator
+	step   time.Duration
+	window time.Duration
+	points []TrackPoint
+	next   time.Time // Время следующей ещё не рассчитанной точки.
+	err    error
+}
+
+// LiveTrackOption функция настройки LiveTrack.
+type LiveTrackOption func(*LiveTrack)
+
+// WithLiveTrackStep устанавливает шаг между точками (по умолчанию DefaultTrackStep).
+func WithLiveTrackStep(step time.Duration) LiveTrackOption {
+	return func(lt *LiveTrack) {
+		lt.step = step
+	}
+}
+
+// WithLiveTrackWindow устанавливает длину хвоста трассы
+// (по умолчанию DefaultTrackPastPeriods витков).
+func WithLiveTrackWindow(window time.Duration) LiveTrackOption {
+	return func(lt *LiveTrack) {
+		lt.window = window
+	}
+}
+
+// NewLiveTrack создаёт трассу с хвостом [now-window, now].
+// Ошибки создания пропагатора и расчёта точек доступны через Err;
+// после ошибки Advance больше не добавляет точек.
+func NewLiveTrack(tle *TLE, now time.Time, opts ...LiveTrackOption) *LiveTrack {
+	lt := &LiveTrack{step: DefaultTrackStep}
+
+	if tle != nil {
+		lt.window = time.Duration(DefaultTrackPastPeriods * tle.OrbitalPeriod() * float64(time.Minute))
+	}
+
+	for _, opt := range opts {
+		opt(lt)
+	}
+
+	if lt.step <= 0 {
+		lt.err = fmt.Errorf("%w: %v", ErrInvalidStep, lt.step)
+		return lt
+	}
+
+	if lt.prop, lt.err = NewPropagator(tle); lt.err != nil {
+		return lt
+	}
+
+	lt.next = now.Add(-lt.window)
+	lt.Advance(now)
+
+	return lt
+}
+
+// Advance рассчитывает точки трассы до момента to включительно и возвращает только новые —
+// рассчитанные после предыдущего вызова и попадающие в окно [to-window, to]. Точки старше
+// to-window удаляются из трассы, а после долгого перерыва не рассчитываются вовсе.
+// Если to не позже последней точки, возвращается nil.
+func (lt *LiveTrack) Advance(to time.Time) []TrackPoint {
+	if lt.err != nil {
+		return nil
+	}
+
+	added := len(lt.points)
+	cutoff := to.Add(-lt.window)
+
+	// После долгого перерыва точки до начала окна всё равно были бы удалены —
+	// пропускаем их, сохраняя сетку шагов.
+	if lt.next.Before(cutoff) {
+		skip := (cutoff.Sub(lt.next) + lt.step - 1) / lt.step
+		lt.next = lt.next.Add(skip * lt.step)
+	}
+
+	for ; !lt.next.After(to); lt.next = lt.next.Add(lt.step) {
+		pos, err := lt.prop.Propagate(lt.next)
+		if err != nil {
+			lt.err = fmt.Errorf("propagation at %v: %w", lt.next, err)
+			break
+		}
+
+		lt.points = append(lt.points, trackPointFromECI(pos))
+	}
+
+	fresh := lt.points[added:]
+	if len(fresh) == 0 {
+		return nil
+	}
+
+	fresh = append([]TrackPoint(nil), fresh...)
+
+	lt.trim(cutoff)
+
+	return fresh
+}
+
+// Points возвращает копию текущей трассы в порядке времени.
+func (lt *LiveTrack) Points() []TrackPoint {
+	return append([]TrackPoint(nil), lt.points...)
+}
+
+// Segments возвращает текущую трассу, разбитую по антимеридиану.
+func (lt *LiveTrack) Segments() [][]TrackPoint {
+	return splitAtAntimeridian(lt.points)
+}
+
+// Err возвращает первую ошибку создания или расчёта трассы.
+func (lt *LiveTrack) Err() error {
+	return lt.err
+}
+
+// trim удаляет точки раньше cutoff, переиспользуя массив среза.
+func (lt *LiveTrack) trim(cutoff time.Time) {
+	drop := 0
+	for drop < len(lt.points) && lt.points[drop].Time.Before(cutoff) {
+		drop++
+	}
+
+	if drop > 0 {
+		lt.points = append(lt.points[:0], lt.points[drop:]...)
+	}
+}
@@ -0,0 +1,104 @@
+package tracker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestLiveTrack_Advance проверяет, что Advance возвращает только новые точки внутри окна
+// (в том числе после долгого перерыва), а трасса не выходит за пределы хвоста.
+func TestLiveTrack_Advance(t *testing.T) {
+	t.Parallel()
+
+	tle, err := ParseTLE([]string{issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	const (
+		step   = 30 * time.Second
+		window = 10 * time.Minute
+	)
+
+	lt := NewLiveTrack(tle, passTestStart, WithLiveTrackStep(step), WithLiveTrackWindow(window))
+	if err := lt.Err(); err != nil {
+		t.Fatalf("NewLiveTrack() error = %v", err)
+	}
+
+	if got, want := len(lt.Points()), int(window/step)+1; got != want {
+		t.Fatalf("initial track has %d points, want %d", got, want)
+	}
+
+	last := passTestStart
+	tail := lt.Points()[len(lt.Points())-1].Time
+
+	for _, tt := range []struct {
+		advance time.Duration
+		want    int
+	}{
+		{advance: 2 * time.Minute, want: 4},
+		{advance: 45 * time.Second, want: 1},
+		{advance: 15 * time.Second, want: 1},
+		{advance: 0, want: 0},
+		{advance: 20 * time.Minute, want: int(window/step) + 1},
+		{advance: 30 * 24 * time.Hour, want: int(window/step) + 1},
+	} {
+		to := last.Add(tt.advance)
+		fresh := lt.Advance(to)
+
+		if len(fresh) != tt.want {
+			t.Fatalf("Advance(+%v) returned %d points, want %d", tt.advance, len(fresh), tt.want)
+		}
+
+		for i, p := range fresh {
+			if !p.Time.After(tail) || p.Time.After(to) || p.Time.Before(to.Add(-window)) {
+				t.Errorf("Advance(+%v) point[%d] at %v outside (%v, %v] or window", tt.advance, i, p.Time, tail, to)
+			}
+		}
+
+		points := lt.Points()
+		tail = points[len(points)-1].Time
+
+		if len(fresh) > 0 && fresh[len(fresh)-1] != points[len(points)-1] {
+			t.Errorf("Advance(+%v) last point is not the track tail", tt.advance)
+		}
+
+		if oldest := points[0].Time; oldest.Before(to.Add(-window)) {
+			t.Errorf("after Advance(+%v) oldest point %v is older than window start %v", tt.advance, oldest, to.Add(-window))
+		}
+
+		if len(points) > int(window/step)+1 {
+			t.Errorf("after Advance(+%v) track has %d points, exceeds window", tt.advance, len(points))
+		}
+
+		last = to
+	}
+
+	if fresh := lt.Advance(passTestStart); fresh != nil {
+		t.Errorf("Advance(past) = %d points, want nil", len(fresh))
+	}
+}
+
+// TestNewLiveTrack_Errors проверяет обработку некорректных аргументов.
+func TestNewLiveTrack_Errors(t *testing.T) {
+	t.Parallel()
+
+	if err := NewLiveTrack(nil, passTestStart).Err(); !errors.Is(err, ErrNilTLE) {
+		t.Errorf("NewLiveTrack(nil) error = %v, want ErrNilTLE", err)
+	}
+
+	tle, err := ParseTLE([]string{issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	lt := NewLiveTrack(tle, passTestStart, WithLiveTrackStep(0))
+	if !errors.Is(lt.Err(), ErrInvalidStep) {
+		t.Errorf("zero step error = %v, want ErrInvalidStep", lt.Err())
+	}
+
+	if fresh := lt.Advance(passTestStart.Add(time.Hour)); fresh != nil {
+		t.Errorf("Advance() after error = %d points, want nil", len(fresh))
+	}
+}
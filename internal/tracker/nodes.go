@@ -0,0 +1,72 @@
+package tracker
+
+import (
+	"fmt"
+	"time"
+)
+
+// nodeScanStep — шаг грубого поиска пересечений экватора.
+// Должен быть заметно меньше половины периода самой низкой орбиты.
+const nodeScanStep = 60 * time.Second
+
+// EquatorCrossingLongitudes возвращает долготы (градусы, [-180, 180))
+// восходящих узлов — моментов пересечения экватора с юга на север — на интервале.
+// Разница между соседними значениями показывает смещение трассы за виток.
+func (p *Propagator) EquatorCrossingLongitudes(start, end time.Time) ([]float64, error) {
+	if p == nil {
+		return nil, ErrNilTLE
+	}
+
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: start=%v end=%v", ErrInvalidWindow, start, end)
+	}
+
+	prev, err := p.Propagate(start)
+	if err != nil {
+		return nil, err
+	}
+
+	var longitudes []float64
+
+	for t := start.Add(nodeScanStep); !t.After(end); t = t.Add(nodeScanStep) {
+		cur, err := p.Propagate(t)
+		if err != nil {
+			return longitudes, err
+		}
+
+		if prev.Z < 0 && cur.Z >= 0 {
+			node, err := p.bisectAscendingNode(prev.Time, cur.Time)
+			if err != nil {
+				return longitudes, err
+			}
+
+			lla := ECEFToLLA(ECIToECEF(node))
+			longitudes = append(longitudes, NormalizeLongitude(lla.LonDeg()))
+		}
+
+		prev = cur
+	}
+
+	return longitudes, nil
+}
+
+// bisectAscendingNode уточняет момент смены знака Z с отрицательного
+// на положительный между a и b и возвращает позицию в этот момент.
+func (p *Propagator) bisectAscendingNode(a, b time.Time) (*ECIPosition, error) {
+	for iter := 0; !p.refine.converged(b.Sub(a), iter); iter++ {
+		mid := a.Add(b.Sub(a) / 2)
+
+		pos, err := p.propagatePrecise(mid)
+		if err != nil {
+			return nil, err
+		}
+
+		if pos.Z < 0 {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+
+	return p.propagatePrecise(b)
+}
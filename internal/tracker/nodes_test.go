@@ -0,0 +1,38 @@
+package tracker
+
+import (
+	"testing"
+	"time"
+)
+
+// TestEquatorCrossingLongitudes проверяет смещение восходящего узла ISS за виток.
+func TestEquatorCrossingLongitudes(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// ISS: период ~92.7 мин, за 8 часов — 5 восходящих узлов.
+	lons, err := prop.EquatorCrossingLongitudes(start, start.Add(8*time.Hour))
+	if err != nil {
+		t.Fatalf("EquatorCrossingLongitudes() error = %v", err)
+	}
+
+	if len(lons) < 4 || len(lons) > 6 {
+		t.Fatalf("got %d crossings, expected 4-6", len(lons))
+	}
+
+	// За виток Земля поворачивается на ~23°, узел смещается к западу.
+	const minShift, maxShift = 21.0, 25.0
+
+	for i := 1; i < len(lons); i++ {
+		shift := NormalizeLongitude(lons[i-1] - lons[i])
+		if shift < minShift || shift > maxShift {
+			t.Errorf("crossing %d: westward shift %.2f°, expected %.0f-%.0f°", i, shift, minShift, maxShift)
+		}
+	}
+
+	if _, err := prop.EquatorCrossingLongitudes(start, start); err == nil {
+		t.Error("EquatorCrossingLongitudes(empty window) expected error")
+	}
+}
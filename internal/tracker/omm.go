@@ -0,0 +1,228 @@
+package tracker
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidOMM возвращается при некорректных данных OMM (Orbit Mean-Elements Message).
+var ErrInvalidOMM = errors.New("invalid OMM data")
+
+// ommEpochLayout — формат эпохи OMM Celestrak (ISO-8601 без часового пояса, UTC).
+const ommEpochLayout = "2006-01-02T15:04:05.999999"
+
+// ommRecord — набор средних элементов OMM в представлении Celestrak.
+// Имена полей совпадают в JSON, CSV и XML (CCSDS 502.0-B).
+type ommRecord struct {
+	ObjectName     string  `json:"OBJECT_NAME"         xml:"metadata>OBJECT_NAME"`
+	ObjectID       string  `json:"OBJECT_ID"           xml:"metadata>OBJECT_ID"`
+	Epoch          string  `json:"EPOCH"               xml:"data>meanElements>EPOCH"`
+	MeanMotion     float64 `json:"MEAN_MOTION"         xml:"data>meanElements>MEAN_MOTION"`
+	Eccentricity   float64 `json:"ECCENTRICITY"        xml:"data>meanElements>ECCENTRICITY"`
+	Inclination    float64 `json:"INCLINATION"         xml:"data>meanElements>INCLINATION"`
+	RAAN           float64 `json:"RA_OF_ASC_NODE"      xml:"data>meanElements>RA_OF_ASC_NODE"`
+	ArgOfPerigee   float64 `json:"ARG_OF_PERICENTER"   xml:"data>meanElements>ARG_OF_PERICENTER"`
+	MeanAnomaly    float64 `json:"MEAN_ANOMALY"        xml:"data>meanElements>MEAN_ANOMALY"`
+	EphemerisType  int     `json:"EPHEMERIS_TYPE"      xml:"data>tleParameters>EPHEMERIS_TYPE"`
+	Classification string  `json:"CLASSIFICATION_TYPE" xml:"data>tleParameters>CLASSIFICATION_TYPE"`
+	NoradID        int     `json:"NORAD_CAT_ID"        xml:"data>tleParameters>NORAD_CAT_ID"`
+	ElementSetNo   int     `json:"ELEMENT_SET_NO"      xml:"data>tleParameters>ELEMENT_SET_NO"`
+	RevNumber      int     `json:"REV_AT_EPOCH"        xml:"data>tleParameters>REV_AT_EPOCH"`
+	Bstar          float64 `json:"BSTAR"               xml:"data>tleParameters>BSTAR"`
+	MeanMotionDot  float64 `json:"MEAN_MOTION_DOT"     xml:"data>tleParameters>MEAN_MOTION_DOT"`
+	MeanMotionDot2 float64 `json:"MEAN_MOTION_DDOT"    xml:"data>tleParameters>MEAN_MOTION_DDOT"`
+}
+
+// ommXMLDocument — корневой элемент NDM/OMM XML Celestrak.
+type ommXMLDocument struct {
+	Segments []ommRecord `xml:"omm>body>segment"`
+}
+
+// ParseOMMJSON разбирает массив OMM в формате JSON Celestrak (FORMAT=JSON).
+// Строки Line1/Line2 формируются по полям через ToLines, поэтому результат
+// можно сразу передать в NewPropagator.
+func ParseOMMJSON(data []byte) ([]*TLE, error) {
+	var records []ommRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOMM, err)
+	}
+
+	return ommToTLEs(records)
+}
+
+// ParseOMMXML разбирает OMM в формате XML (NDM) Celestrak (FORMAT=XML).
+func ParseOMMXML(data []byte) ([]*TLE, error) {
+	var doc ommXMLDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOMM, err)
+	}
+
+	return ommToTLEs(doc.Segments)
+}
+
+// ParseOMMCSV разбирает OMM в формате CSV Celestrak (FORMAT=CSV).
+// Первая строка — заголовок с именами полей OMM; неизвестные колонки игнорируются.
+func ParseOMMCSV(data []byte) ([]*TLE, error) {
+	reader := csv.NewReader(strings.NewReader(string(data)))
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOMM, err)
+	}
+
+	var records []ommRecord
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidOMM, err)
+		}
+
+		var rec ommRecord
+
+		for i, name := range header {
+			if i >= len(row) {
+				break
+			}
+
+			if err := rec.setField(strings.TrimSpace(name), strings.TrimSpace(row[i])); err != nil {
+				return nil, fmt.Errorf("%w: row %d: %s: %w", ErrInvalidOMM, len(records)+1, name, err)
+			}
+		}
+
+		records = append(records, rec)
+	}
+
+	return ommToTLEs(records)
+}
+
+// setField устанавливает поле записи по имени колонки CSV.
+func (r *ommRecord) setField(name, value string) error {
+	strFields := map[string]*string{
+		"OBJECT_NAME":         &r.ObjectName,
+		"OBJECT_ID":           &r.ObjectID,
+		"EPOCH":               &r.Epoch,
+		"CLASSIFICATION_TYPE": &r.Classification,
+	}
+
+	floatFields := map[string]*float64{
+		"MEAN_MOTION":       &r.MeanMotion,
+		"ECCENTRICITY":      &r.Eccentricity,
+		"INCLINATION":       &r.Inclination,
+		"RA_OF_ASC_NODE":    &r.RAAN,
+		"ARG_OF_PERICENTER": &r.ArgOfPerigee,
+		"MEAN_ANOMALY":      &r.MeanAnomaly,
+		"BSTAR":             &r.Bstar,
+		"MEAN_MOTION_DOT":   &r.MeanMotionDot,
+		"MEAN_MOTION_DDOT":  &r.MeanMotionDot2,
+	}
+
+	intFields := map[string]*int{
+		"EPHEMERIS_TYPE": &r.EphemerisType,
+		"NORAD_CAT_ID":   &r.NoradID,
+		"ELEMENT_SET_NO": &r.ElementSetNo,
+		"REV_AT_EPOCH":   &r.RevNumber,
+	}
+
+	var err error
+
+	if p, ok := strFields[name]; ok {
+		*p = value
+	} else if p, ok := floatFields[name]; ok && value != "" {
+		*p, err = strconv.ParseFloat(value, 64)
+	} else if p, ok := intFields[name]; ok && value != "" {
+		*p, err = strconv.Atoi(value)
+	}
+
+	return err
+}
+
+// ommToTLEs преобразует записи OMM в TLE.
+func ommToTLEs(records []ommRecord) ([]*TLE, error) {
+	tles := make([]*TLE, 0, len(records))
+
+	for i := range records {
+		tle, err := records[i].toTLE()
+		if err != nil {
+			return nil, fmt.Errorf("%w: record %d (%s): %w", ErrInvalidOMM, i, records[i].ObjectName, err)
+		}
+
+		tles = append(tles, tle)
+	}
+
+	return tles, nil
+}
+
+// toTLE отображает поля OMM на TLE и формирует канонические строки Line1/Line2.
+func (r *ommRecord) toTLE() (*TLE, error) {
+	epoch, err := parseOMMEpoch(r.Epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	tle := &TLE{
+		Name:           strings.TrimSpace(r.ObjectName),
+		NoradID:        r.NoradID,
+		Classification: r.Classification,
+		IntlDesignator: ommIntlDesignator(r.ObjectID),
+		Epoch:          epoch,
+		MeanMotionDot:  r.MeanMotionDot,
+		MeanMotionDot2: r.MeanMotionDot2,
+		Bstar:          r.Bstar,
+		EphemerisType:  r.EphemerisType,
+		ElementSetNo:   r.ElementSetNo,
+		Inclination:    r.Inclination,
+		RAAN:           r.RAAN,
+		Eccentricity:   r.Eccentricity,
+		ArgOfPerigee:   r.ArgOfPerigee,
+		MeanAnomaly:    r.MeanAnomaly,
+		MeanMotion:     r.MeanMotion,
+		RevNumber:      r.RevNumber,
+	}
+
+	tle.Line1, tle.Line2, err = tle.ToLines()
+	if err != nil {
+		return nil, err
+	}
+
+	return tle, nil
+}
+
+// parseOMMEpoch парсит эпоху OMM (ISO-8601, UTC), допускается и RFC 3339 с зоной.
+func parseOMMEpoch(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation(ommEpochLayout, s, time.UTC); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing EPOCH %q: %w", s, err)
+	}
+
+	return t.UTC(), nil
+}
+
+// ommIntlDesignator преобразует OBJECT_ID COSPAR ("1998-067A") в формат TLE ("98067A").
+func ommIntlDesignator(objectID string) string {
+	year, rest, ok := strings.Cut(strings.TrimSpace(objectID), "-")
+	if !ok || len(year) != 4 {
+		return objectID
+	}
+
+	return year[2:] + rest
+}
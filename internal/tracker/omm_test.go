@@ -0,0 +1,139 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Эталонные OMM для МКС с теми же элементами, что issLine1/issLine2.
+const (
+	issOMMJSON = `[{"OBJECT_NAME":"ISS (ZARYA)","OBJECT_ID":"1998-067A","EPOCH":"2024-01-01T12:00:00.000000",
+"MEAN_MOTION":15.49815571,"ECCENTRICITY":0.0006703,"INCLINATION":51.64,"RA_OF_ASC_NODE":247.4627,
+"ARG_OF_PERICENTER":130.536,"MEAN_ANOMALY":325.0288,"EPHEMERIS_TYPE":0,"CLASSIFICATION_TYPE":"U",
+"NORAD_CAT_ID":25544,"ELEMENT_SET_NO":999,"REV_AT_EPOCH":42340,"BSTAR":0.0001027,
+"MEAN_MOTION_DOT":0.00016717,"MEAN_MOTION_DDOT":0}]`
+
+	issOMMXML = `<?xml version="1.0" encoding="UTF-8"?>
+<ndm xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+<omm id="CCSDS_OMM_VERS" version="2.0">
+<header><CREATION_DATE/><ORIGINATOR/></header>
+<body><segment>
+<metadata><OBJECT_NAME>ISS (ZARYA)</OBJECT_NAME><OBJECT_ID>1998-067A</OBJECT_ID><CENTER_NAME>EARTH</CENTER_NAME>
+<REF_FRAME>TEME</REF_FRAME><TIME_SYSTEM>UTC</TIME_SYSTEM><MEAN_ELEMENT_THEORY>SGP4</MEAN_ELEMENT_THEORY></metadata>
+<data><meanElements><EPOCH>2024-01-01T12:00:00.000000</EPOCH><MEAN_MOTION>15.49815571</MEAN_MOTION>
+<ECCENTRICITY>.0006703</ECCENTRICITY><INCLINATION>51.6400</INCLINATION><RA_OF_ASC_NODE>247.4627</RA_OF_ASC_NODE>
+<ARG_OF_PERICENTER>130.5360</ARG_OF_PERICENTER><MEAN_ANOMALY>325.0288</MEAN_ANOMALY></meanElements>
+<tleParameters><EPHEMERIS_TYPE>0</EPHEMERIS_TYPE><CLASSIFICATION_TYPE>U</CLASSIFICATION_TYPE>
+<NORAD_CAT_ID>25544</NORAD_CAT_ID><ELEMENT_SET_NO>999</ELEMENT_SET_NO><REV_AT_EPOCH>42340</REV_AT_EPOCH>
+<BSTAR>.1027E-3</BSTAR><MEAN_MOTION_DOT>.00016717</MEAN_MOTION_DOT><MEAN_MOTION_DDOT>0</MEAN_MOTION_DDOT></tleParameters>
+</data></segment></body></omm>
+</ndm>`
+
+	issOMMCSV = `OBJECT_NAME,OBJECT_ID,EPOCH,MEAN_MOTION,ECCENTRICITY,INCLINATION,RA_OF_ASC_NODE,ARG_OF_PERICENTER,MEAN_ANOMALY,` +
+		`EPHEMERIS_TYPE,CLASSIFICATION_TYPE,NORAD_CAT_ID,ELEMENT_SET_NO,REV_AT_EPOCH,BSTAR,MEAN_MOTION_DOT,MEAN_MOTION_DDOT
+ISS (ZARYA),1998-067A,2024-01-01T12:00:00.000000,15.49815571,.0006703,51.6400,247.4627,130.5360,325.0288,0,U,25544,999,42340,.1027E-3,.00016717,0
+`
+)
+
+// TestParseOMM проверяет, что OMM во всех форматах даёт те же элементы и строки, что и TLE.
+func TestParseOMM(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		parse func([]byte) ([]*TLE, error)
+		data  string
+	}{
+		{name: "JSON", parse: ParseOMMJSON, data: issOMMJSON},
+		{name: "XML", parse: ParseOMMXML, data: issOMMXML},
+		{name: "CSV", parse: ParseOMMCSV, data: issOMMCSV},
+	}
+
+	want, err := ParseTLE([]string{"ISS (ZARYA)", issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tles, err := tt.parse([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+
+			if len(tles) != 1 {
+				t.Fatalf("got %d TLEs, want 1", len(tles))
+			}
+
+			if *tles[0] != *want {
+				t.Errorf("OMM mapped to\n%+v\nwant\n%+v", tles[0], want)
+			}
+
+			if _, err := NewPropagator(tles[0]); err != nil {
+				t.Errorf("NewPropagator() error = %v", err)
+			}
+		})
+	}
+}
+
+// TestParseOMM_Invalid проверяет ошибки разбора некорректного OMM.
+func TestParseOMM_Invalid(t *testing.T) {
+	t.Parallel()
+
+	badEpoch := strings.Replace(issOMMJSON, "2024-01-01T12:00:00.000000", "yesterday", 1)
+
+	inputs := map[string]func() error{
+		"malformed JSON": func() error { _, err := ParseOMMJSON([]byte("{")); return err },
+		"bad epoch":      func() error { _, err := ParseOMMJSON([]byte(badEpoch)); return err },
+		"malformed XML":  func() error { _, err := ParseOMMXML([]byte("<ndm><omm>")); return err },
+		"bad CSV number": func() error { _, err := ParseOMMCSV([]byte("MEAN_MOTION\nfast\n")); return err },
+	}
+
+	for name, run := range inputs {
+		if err := run(); !errors.Is(err, ErrInvalidOMM) {
+			t.Errorf("%s: error = %v, want ErrInvalidOMM", name, err)
+		}
+	}
+}
+
+// TestCelestrakClient_WithFormat проверяет запрос и разбор OMM JSON клиентом.
+func TestCelestrakClient_WithFormat(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("FORMAT") != "JSON" {
+			_, _ = w.Write([]byte("unexpected format"))
+			return
+		}
+
+		_, _ = w.Write([]byte(issOMMJSON))
+	}))
+	defer server.Close()
+
+	client := NewCelestrakClient(WithBaseURL(server.URL), WithRateLimit(0), WithFormat(FormatJSON))
+
+	tle, err := client.FetchByNoradID(context.Background(), 25544)
+	if err != nil {
+		t.Fatalf("FetchByNoradID() error = %v", err)
+	}
+
+	if tle.NoradID != 25544 || tle.IntlDesignator != "98067A" {
+		t.Errorf("got NoradID=%d IntlDesignator=%q, want 25544 98067A", tle.NoradID, tle.IntlDesignator)
+	}
+
+	tles, err := client.FetchGroup(context.Background(), GroupStations)
+	if err != nil || len(tles) != 1 {
+		t.Errorf("FetchGroup() = %d TLEs, error = %v", len(tles), err)
+	}
+
+	bad := NewCelestrakClient(WithBaseURL(server.URL), WithRateLimit(0), WithFormat("KVN"))
+	if _, err := bad.FetchGroup(context.Background(), GroupStations); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("FetchGroup() with unsupported format error = %v, want ErrUnsupportedFormat", err)
+	}
+}
@@ -0,0 +1,308 @@
+package tracker
+
+import (
+	"math"
+	"time"
+)
+
+// OrbitRegime — класс орбиты по высоте и форме.
+type OrbitRegime int
+
+// OrbitClass — синоним OrbitRegime для выборок каталога по классу орбиты
+// (см. TLEStore.GetByOrbitClass).
+type OrbitClass = OrbitRegime
+
+// Классы орбит.
+const (
+	RegimeUnknown OrbitRegime = iota // Недостаточно данных (нулевое mean motion).
+	RegimeLEO                        // Низкая орбита: апогей ниже 2000 км.
+	RegimeMEO                        // Средняя орбита: от LEO до геостационарной.
+	RegimeGEO                        // Геостационарная: ~1 оборот в сутки, малые e и i.
+	RegimeHEO                        // Высокоэллиптическая (Молния, Тундра и т.п.).
+)
+
+// Границы классификации орбит.
+const (
+	leoMaxApogeeKm     = 2000.0 // Верхняя граница LEO по апогею.
+	heoMinEccentricity = 0.25   // Эксцентриситет, начиная с которого орбита считается HEO.
+	geoMeanMotion      = 1.0027 // Mean motion геостационарной орбиты, оборотов/сутки.
+	geoMeanMotionTol   = 0.05   // Допуск по mean motion для GEO.
+	geoMaxInclination  = 15.0   // Максимальное наклонение GEO, градусы.
+	geoMaxEccentricity = 0.1    // Максимальный эксцентриситет GEO.
+)
+
+// earthJ2 — вторая зональная гармоника геопотенциала (WGS84).
+const earthJ2 = 1.08262668e-3
+
+// String возвращает название класса орбиты.
+func (r OrbitRegime) String() string {
+	switch r {
+	case RegimeLEO:
+		return "LEO"
+	case RegimeMEO:
+		return "MEO"
+	case RegimeGEO:
+		return "GEO"
+	case RegimeHEO:
+		return "HEO"
+	case RegimeUnknown:
+		return "unknown"
+	default:
+		return "unknown"
+	}
+}
+
+// OrbitClass классифицирует орбиту спутника по элементам TLE; то же, что Regime.
+func (tle *TLE) OrbitClass() OrbitClass {
+	return tle.Regime()
+}
+
+// Regime классифицирует орбиту спутника по элементам TLE.
+func (tle *TLE) Regime() OrbitRegime {
+	if tle == nil || tle.MeanMotion <= 0 {
+		return RegimeUnknown
+	}
+
+	switch {
+	case tle.Eccentricity >= heoMinEccentricity:
+		return RegimeHEO
+	case tle.Eccentricity < geoMaxEccentricity &&
+		tle.Inclination < geoMaxInclination &&
+		tle.MeanMotion > geoMeanMotion-geoMeanMotionTol &&
+		tle.MeanMotion < geoMeanMotion+geoMeanMotionTol:
+		return RegimeGEO
+	case tle.Apogee() < leoMaxApogeeKm:
+		return RegimeLEO
+	default:
+		return RegimeMEO
+	}
+}
+
+// GroundTrackShiftPerOrbit возвращает смещение восходящего узла трассы к западу
+// (градусы) между соседними витками: поворот Земли за драконический период
+// минус прецессия узла из-за J2. Если за N витков суммарное смещение кратно 360°,
+// трасса повторяется. Для нулевого mean motion возвращает NaN.
+func (tle *TLE) GroundTrackShiftPerOrbit() float64 {
+	if tle == nil || tle.MeanMotion <= 0 {
+		return math.NaN()
+	}
+
+	raanDot, argpDot := tle.j2SecularRates()
+	n := tle.MeanMotion * 2 * math.Pi / 86400 // рад/с.
+
+	// Драконический период — от узла до узла, с учётом вращения линии апсид.
+	nodalPeriod := 2 * math.Pi / (n + argpDot)
+
+	return (OmegaEarth - raanDot) * nodalPeriod * Rad2Deg
+}
+
+// j2SecularRates возвращает вековые скорости изменения долготы восходящего узла
+// и аргумента перигея из-за J2, рад/с.
+func (tle *TLE) j2SecularRates() (raanDot, argpDot float64) {
+	n := tle.MeanMotion * 2 * math.Pi / 86400
+	p := tle.SemiMajorAxis() * (1 - tle.Eccentricity*tle.Eccentricity)
+	k := 1.5 * n * earthJ2 * (WGS84A / p) * (WGS84A / p)
+	cosI := math.Cos(tle.Inclination * Deg2Rad)
+
+	raanDot = -k * cosI
+	argpDot = k / 2 * (5*cosI*cosI - 1)
+
+	return raanDot, argpDot
+}
+
+// ArgumentOfLatitude возвращает аргумент широты u = ω + ν на эпоху TLE, градусы [0, 360).
+// Истинная аномалия получается решением уравнения Кеплера по средней аномалии.
+// Определяет положение спутника в орбитальной плоскости относительно восходящего узла.
+func (tle *TLE) ArgumentOfLatitude() float64 {
+	return normalizeDegrees(tle.ArgOfPerigee + trueAnomalyDeg(tle.MeanAnomaly, tle.Eccentricity))
+}
+
+// RevolutionAt возвращает номер витка в момент t, экстраполируя RevNumber по среднему
+// движению с учётом его первой производной. Новый виток начинается в восходящем узле,
+// поэтому пройденная на эпоху часть витка берётся по аргументу широты.
+// Для моментов до эпохи номер уменьшается.
+func (tle *TLE) RevolutionAt(t time.Time) int {
+	if tle == nil {
+		return 0
+	}
+
+	orbits := tle.ArgumentOfLatitude()/360 + tle.orbitsSinceEpoch(t)
+
+	return tle.RevNumber + int(math.Floor(orbits))
+}
+
+// MeanAnomalyAt возвращает среднюю аномалию в момент t, градусы [0, 360): средняя
+// аномалия эпохи, экстраполированная по среднему движению и его первой производной.
+// Это лёгкая аналитическая оценка фазы на орбите, а не результат SGP4
+// (не учитывает вековые возмущения J2 и сопротивление сверх ṅ).
+func (tle *TLE) MeanAnomalyAt(t time.Time) float64 {
+	if tle == nil {
+		return 0
+	}
+
+	return normalizeDegrees(tle.MeanAnomaly + 360*tle.orbitsSinceEpoch(t))
+}
+
+// orbitsSinceEpoch возвращает число оборотов (дробное), пройденных от эпохи до t.
+func (tle *TLE) orbitsSinceEpoch(t time.Time) float64 {
+	days := t.Sub(tle.Epoch).Hours() / 24
+
+	// MeanMotionDot в TLE уже равно ṅ/2, поэтому вклад ускорения — MeanMotionDot·Δt².
+	return tle.MeanMotion*days + tle.MeanMotionDot*days*days
+}
+
+// CoPlanar сообщает, лежат ли орбиты двух спутников в одной плоскости с точностью
+// toleranceDeg: плоскость задаётся наклонением и долготой восходящего узла, разность
+// RAAN берётся с учётом перехода через 360°. У околоэкваториальных орбит (наклонение
+// в пределах toleranceDeg от 0° или 180°) узел не определён, поэтому для пары таких
+// орбит сравнивается только наклонение. Основа для разбиения спутников группировки
+// по орбитальным плоскостям.
+func CoPlanar(a, b *TLE, toleranceDeg float64) bool {
+	if a == nil || b == nil {
+		return false
+	}
+
+	dInc := math.Abs(a.Inclination - b.Inclination)
+	if dInc > toleranceDeg {
+		return false
+	}
+
+	if nearEquatorial(a.Inclination, toleranceDeg) && nearEquatorial(b.Inclination, toleranceDeg) {
+		return true
+	}
+
+	return math.Abs(math.Remainder(a.RAAN-b.RAAN, 360)) <= toleranceDeg
+}
+
+// nearEquatorial сообщает, что наклонение отличается от 0° или 180° не более чем на toleranceDeg.
+func nearEquatorial(inclinationDeg, toleranceDeg float64) bool {
+	return inclinationDeg <= toleranceDeg || inclinationDeg >= 180-toleranceDeg
+}
+
+// trueAnomalyDeg возвращает истинную аномалию по средней (градусы) для эллиптической орбиты.
+func trueAnomalyDeg(meanAnomalyDeg, ecc float64) float64 {
+	const (
+		keplerTolerance     = 1e-12
+		keplerMaxIterations = 30
+	)
+
+	m := meanAnomalyDeg * Deg2Rad
+	e := m
+
+	for range keplerMaxIterations {
+		delta := (e - ecc*math.Sin(e) - m) / (1 - ecc*math.Cos(e))
+		e -= delta
+
+		if math.Abs(delta) < keplerTolerance {
+			break
+		}
+	}
+
+	nu := 2 * math.Atan2(math.Sqrt(1+ecc)*math.Sin(e/2), math.Sqrt(1-ecc)*math.Cos(e/2))
+
+	return nu * Rad2Deg
+}
+
+// OrbitalElements — классические кеплеровы элементы орбиты.
+type OrbitalElements struct {
+	SemiMajorAxis float64   // Большая полуось, км.
+	Eccentricity  float64   // Эксцентриситет.
+	Inclination   float64   // Наклонение, градусы.
+	RAAN          float64   // Долгота восходящего узла, градусы [0, 360).
+	ArgOfPerigee  float64   // Аргумент перигея, градусы [0, 360).
+	TrueAnomaly   float64   // Истинная аномалия, градусы [0, 360).
+	MeanAnomaly   float64   // Средняя аномалия, градусы [0, 360).
+	Time          time.Time // Момент, на который рассчитаны элементы.
+}
+
+// earthMu — гравитационный параметр Земли, км³/с² (как в TLE.SemiMajorAxis).
+const earthMu = 398600.4418
+
+// elementsSmallValue — порог, ниже которого орбита считается круговой или экваториальной.
+const elementsSmallValue = 1e-10
+
+// OsculatingElements рассчитывает оскулирующие элементы орбиты на момент t.
+// В отличие от средних элементов TLE, они описывают мгновенную кеплерову орбиту,
+// проходящую через положение и скорость спутника, полученные SGP4.
+func (p *Propagator) OsculatingElements(t time.Time) (OrbitalElements, error) {
+	if p == nil {
+		return OrbitalElements{}, ErrNilTLE
+	}
+
+	pos, err := p.Propagate(t)
+	if err != nil {
+		return OrbitalElements{}, err
+	}
+
+	return StateToElements(pos), nil
+}
+
+// StateToElements преобразует вектор состояния ECI в классические элементы орбиты.
+// Для круговых орбит аргумент перигея равен 0, а истинная аномалия отсчитывается от узла;
+// для экваториальных — RAAN равен 0.
+func StateToElements(pos *ECIPosition) OrbitalElements {
+	r := eciVec(pos)
+	v := eciVelocity(pos)
+	rMag := r.norm()
+	vMag := v.norm()
+
+	h := r.cross(v)
+	node := vec3{X: -h.Y, Y: h.X}
+
+	// Вектор эксцентриситета.
+	eVec := r.scale(vMag*vMag - earthMu/rMag).sub(v.scale(r.dot(v))).scale(1 / earthMu)
+	ecc := eVec.norm()
+
+	energy := vMag*vMag/2 - earthMu/rMag
+
+	elements := OrbitalElements{
+		SemiMajorAxis: -earthMu / (2 * energy),
+		Eccentricity:  ecc,
+		Inclination:   math.Acos(h.Z/h.norm()) * Rad2Deg,
+		Time:          pos.Time,
+	}
+
+	if node.norm() > elementsSmallValue {
+		elements.RAAN = normalizeDegrees(math.Atan2(node.Y, node.X) * Rad2Deg)
+	}
+
+	// Опорное направление для отсчёта углов: перигей, либо узел для круговой орбиты.
+	reference := eVec
+	if ecc < elementsSmallValue {
+		reference = node
+		if node.norm() <= elementsSmallValue {
+			reference = vec3{X: 1}
+		}
+	} else if node.norm() > elementsSmallValue {
+		argp := angleBetween(node, eVec)
+		if eVec.Z < 0 {
+			argp = 2*math.Pi - argp
+		}
+
+		elements.ArgOfPerigee = normalizeDegrees(argp * Rad2Deg)
+	}
+
+	nu := angleBetween(reference, r)
+	if r.dot(v) < 0 {
+		nu = 2*math.Pi - nu
+	}
+
+	elements.TrueAnomaly = normalizeDegrees(nu * Rad2Deg)
+
+	if ecc < 1 {
+		eccAnomaly := 2 * math.Atan(math.Sqrt((1-ecc)/(1+ecc))*math.Tan(nu/2))
+		elements.MeanAnomaly = normalizeDegrees((eccAnomaly - ecc*math.Sin(eccAnomaly)) * Rad2Deg)
+	}
+
+	return elements
+}
+
+// normalizeDegrees приводит угол в градусах к диапазону [0, 360).
+func normalizeDegrees(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+
+	return deg
+}
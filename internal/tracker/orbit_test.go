@@ -0,0 +1,280 @@
+package tracker
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Тестовые TLE для классификации орбит (только орбитальные элементы).
+var (
+	regimeTestLEO = &TLE{NoradID: 25544, Name: "ISS (ZARYA)", MeanMotion: 15.4981557, Eccentricity: 0.0006703, Inclination: 51.64}
+	regimeTestMEO = &TLE{NoradID: 48859, Name: "GPS BIII-5", MeanMotion: 2.0056, Eccentricity: 0.0012, Inclination: 55.1}
+	regimeTestGEO = &TLE{NoradID: 41866, Name: "GOES 16", MeanMotion: 1.0027, Eccentricity: 0.0001, Inclination: 0.05}
+	regimeTestHEO = &TLE{NoradID: 28163, Name: "MOLNIYA 1-93", MeanMotion: 2.006, Eccentricity: 0.7, Inclination: 63.4}
+)
+
+// TestTLE_Regime проверяет классификацию орбит.
+func TestTLE_Regime(t *testing.T) {
+	tests := []struct {
+		name string
+		tle  *TLE
+		want OrbitRegime
+	}{
+		{name: "ISS", tle: regimeTestLEO, want: RegimeLEO},
+		{name: "GPS", tle: regimeTestMEO, want: RegimeMEO},
+		{name: "GOES", tle: regimeTestGEO, want: RegimeGEO},
+		{name: "Molniya", tle: regimeTestHEO, want: RegimeHEO},
+		{name: "zero mean motion", tle: &TLE{}, want: RegimeUnknown},
+		{name: "nil", tle: nil, want: RegimeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tle.Regime(); got != tt.want {
+				t.Errorf("Regime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTLEStore_RegimeHistogram проверяет гистограмму классов орбит каталога.
+func TestTLEStore_RegimeHistogram(t *testing.T) {
+	store := NewTLEStore()
+	for _, tle := range []*TLE{regimeTestLEO, regimeTestMEO, regimeTestGEO, regimeTestHEO} {
+		store.Add(tle)
+	}
+
+	histogram := store.RegimeHistogram()
+
+	for _, regime := range []OrbitRegime{RegimeLEO, RegimeMEO, RegimeGEO, RegimeHEO} {
+		if histogram[regime] != 1 {
+			t.Errorf("histogram[%v] = %d, want 1", regime, histogram[regime])
+		}
+	}
+
+	if len(histogram) != 4 {
+		t.Errorf("histogram has %d buckets, want 4", len(histogram))
+	}
+}
+
+// TestPropagator_OsculatingElements сравнивает оскулирующие элементы со средними элементами TLE.
+func TestPropagator_OsculatingElements(t *testing.T) {
+	tle, err := ParseTLE([]string{"ISS (ZARYA)", issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	prop, err := NewPropagator(tle)
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	elements, err := prop.OsculatingElements(tle.Epoch)
+	if err != nil {
+		t.Fatalf("OsculatingElements() error = %v", err)
+	}
+
+	// Оскулирующая большая полуось близка к средней, но из-за J2 не совпадает с ней.
+	diff := math.Abs(elements.SemiMajorAxis - tle.SemiMajorAxis())
+	if diff > 30 || diff < 0.01 {
+		t.Errorf("|a_osc - a_mean| = %.3f km, want 0.01-30 km", diff)
+	}
+
+	if math.Abs(elements.Inclination-tle.Inclination) > 0.1 {
+		t.Errorf("Inclination = %.4f°, want ~%.4f°", elements.Inclination, tle.Inclination)
+	}
+
+	if math.Abs(NormalizeLongitude(elements.RAAN-tle.RAAN)) > 0.1 {
+		t.Errorf("RAAN = %.4f°, want ~%.4f°", elements.RAAN, tle.RAAN)
+	}
+
+	if elements.Eccentricity > 0.01 {
+		t.Errorf("Eccentricity = %.6f, want near-circular", elements.Eccentricity)
+	}
+}
+
+// TestStateToElements проверяет преобразование для известной эллиптической орбиты.
+func TestStateToElements(t *testing.T) {
+	// Перигей на оси X, скорость по Y: e = 0.1, a = 10000 км, экваториальная орбита.
+	const a, e = 10000.0, 0.1
+
+	rp := a * (1 - e)
+	vp := math.Sqrt(earthMu * (1 + e) / rp)
+
+	elements := StateToElements(&ECIPosition{X: rp, Vy: vp, Time: time.Now()})
+
+	if !almostEqual(elements.SemiMajorAxis, a, 1e-6) {
+		t.Errorf("SemiMajorAxis = %.6f, want %.0f", elements.SemiMajorAxis, a)
+	}
+
+	if !almostEqual(elements.Eccentricity, e, 1e-9) {
+		t.Errorf("Eccentricity = %.9f, want %.1f", elements.Eccentricity, e)
+	}
+
+	if !almostEqual(elements.Inclination, 0, 1e-9) || !almostEqual(elements.TrueAnomaly, 0, 1e-6) {
+		t.Errorf("Inclination/TrueAnomaly = %.6f/%.6f, want 0/0", elements.Inclination, elements.TrueAnomaly)
+	}
+}
+
+// TestTLE_GroundTrackShiftPerOrbit проверяет смещение трассы ISS и повторяющейся
+// солнечно-синхронной орбиты (Landsat 8: 233 витка за 16 суток).
+func TestTLE_GroundTrackShiftPerOrbit(t *testing.T) {
+	t.Parallel()
+
+	iss, err := ParseTLE([]string{issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	if shift := iss.GroundTrackShiftPerOrbit(); shift < 22.5 || shift > 24 {
+		t.Errorf("ISS shift = %.3f°, want ~23°", shift)
+	}
+
+	landsat := &TLE{MeanMotion: 14.5711, Inclination: 98.2, Eccentricity: 0.0001}
+	shift := landsat.GroundTrackShiftPerOrbit()
+
+	// За 233 витка трасса смещается на 16 полных оборотов: shift = 360·16/233.
+	if days := shift * 233 / 360; math.Abs(days-16) > 0.01 {
+		t.Errorf("Landsat shift = %.4f°, 233 orbits span %.4f turns, want 16", shift, days)
+	}
+
+	if !math.IsNaN((&TLE{}).GroundTrackShiftPerOrbit()) {
+		t.Error("GroundTrackShiftPerOrbit() with zero mean motion should return NaN")
+	}
+}
+
+// TestTLE_RevolutionAt проверяет экстраполяцию номера витка и его смену в восходящем узле.
+func TestTLE_RevolutionAt(t *testing.T) {
+	t.Parallel()
+
+	tle, err := ParseTLE([]string{issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	if got := tle.RevolutionAt(tle.Epoch); got != tle.RevNumber {
+		t.Errorf("RevolutionAt(epoch) = %d, want %d", got, tle.RevNumber)
+	}
+
+	for _, days := range []int{1, 10} {
+		got := tle.RevolutionAt(tle.Epoch.AddDate(0, 0, days)) - tle.RevNumber
+		want := tle.MeanMotion * float64(days)
+
+		if math.Abs(float64(got)-want) > 1 {
+			t.Errorf("revolutions after %d days = %d, want ~%.1f", days, got, want)
+		}
+	}
+
+	// Номер витка увеличивается при пересечении экватора с юга на север.
+	prop, err := NewPropagator(tle)
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	prev, err := prop.Propagate(tle.Epoch)
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	found := false
+
+	for at := tle.Epoch.Add(10 * time.Second); !found && at.Before(tle.Epoch.Add(2*time.Hour)); at = at.Add(10 * time.Second) {
+		pos, err := prop.Propagate(at)
+		if err != nil {
+			t.Fatalf("Propagate() error = %v", err)
+		}
+
+		if prev.Z < 0 && pos.Z >= 0 {
+			before := tle.RevolutionAt(at.Add(-2 * time.Minute))
+			after := tle.RevolutionAt(at.Add(2 * time.Minute))
+
+			if before != tle.RevNumber || after != tle.RevNumber+1 {
+				t.Errorf("around ascending node at %v: revolutions %d -> %d, want %d -> %d",
+					at, before, after, tle.RevNumber, tle.RevNumber+1)
+			}
+
+			found = true
+		}
+
+		prev = pos
+	}
+
+	if !found {
+		t.Error("ascending node not found within 2 hours of epoch")
+	}
+}
+
+// TestTLE_MeanAnomalyAt проверяет, что на эпоху средняя аномалия совпадает с TLE,
+// а за орбитальный период возрастает на 360°.
+func TestTLE_MeanAnomalyAt(t *testing.T) {
+	t.Parallel()
+
+	tle, err := ParseTLE([]string{issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	if got := tle.MeanAnomalyAt(tle.Epoch); !almostEqual(got, tle.MeanAnomaly, 1e-9) {
+		t.Errorf("MeanAnomalyAt(epoch) = %.6f, want %.6f", got, tle.MeanAnomaly)
+	}
+
+	period := time.Duration(tle.OrbitalPeriod() * float64(time.Minute))
+
+	// Вклад производной среднего движения за виток МКС — порядка 1e-3°.
+	const tolDeg = 1e-2
+
+	for _, k := range []int{1, 5} {
+		got := tle.MeanAnomalyAt(tle.Epoch.Add(time.Duration(k) * period))
+		if diff := math.Abs(math.Remainder(got-tle.MeanAnomaly, 360)); diff > tolDeg {
+			t.Errorf("MeanAnomalyAt(epoch + %d periods) = %.6f, want %.6f ± %v", k, got, tle.MeanAnomaly, tolDeg)
+		}
+	}
+
+	quarter := tle.MeanAnomalyAt(tle.Epoch.Add(period / 4))
+	if want := normalizeDegrees(tle.MeanAnomaly + 90); !almostEqual(quarter, want, tolDeg) {
+		t.Errorf("MeanAnomalyAt(epoch + T/4) = %.6f, want %.6f", quarter, want)
+	}
+
+	for _, got := range []float64{tle.MeanAnomalyAt(tle.Epoch.Add(-3 * period / 4)), quarter} {
+		if got < 0 || got >= 360 {
+			t.Errorf("MeanAnomalyAt() = %v, want in [0, 360)", got)
+		}
+	}
+}
+
+// TestCoPlanar проверяет сравнение орбитальных плоскостей, включая переход RAAN через 360°.
+func TestCoPlanar(t *testing.T) {
+	t.Parallel()
+
+	plane := func(inc, raan float64) *TLE {
+		return &TLE{Inclination: inc, RAAN: raan, MeanMotion: 15.06}
+	}
+
+	tests := []struct {
+		name string
+		a, b *TLE
+		want bool
+	}{
+		{name: "identical", a: plane(53.05, 120), b: plane(53.05, 120), want: true},
+		{name: "within tolerance", a: plane(53.05, 120), b: plane(53.2, 120.4), want: true},
+		{name: "RAAN differs by 30", a: plane(53.05, 120), b: plane(53.05, 150), want: false},
+		{name: "RAAN wrap-around", a: plane(53.05, 359.8), b: plane(53.05, 0.2), want: true},
+		{name: "inclination differs", a: plane(53.05, 120), b: plane(70, 120), want: false},
+		{name: "equatorial RAAN ignored", a: plane(0.03, 80), b: plane(0.2, 271), want: true},
+		{name: "retrograde equatorial", a: plane(179.9, 10), b: plane(179.6, 190), want: true},
+		{name: "prograde vs retrograde equatorial", a: plane(0.1, 10), b: plane(179.9, 10), want: false},
+		{name: "one equatorial", a: plane(0.4, 0), b: plane(0.8, 180), want: false},
+		{name: "nil", a: plane(53.05, 120), b: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := CoPlanar(tt.a, tt.b, 0.5); got != tt.want {
+				t.Errorf("CoPlanar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
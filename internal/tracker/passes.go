@@ -0,0 +1,760 @@
+package tracker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Ошибки предсказания пролётов.
+var (
+	ErrNilObserver    = errors.New("observer is nil")
+	ErrNoPassFound    = errors.New("no pass found within search window")
+	ErrAlwaysVisible  = errors.New("satellite is always above the horizon")
+	ErrInvalidWindow  = errors.New("end time must be after start time")
+	ErrEmptyWaypoints = errors.New("waypoints list is empty")
+)
+
+// Параметры поиска пролётов.
+const (
+	// passCoarseStep — шаг грубого сканирования угла места.
+	passCoarseStep = 30 * time.Second
+
+	// passSearchHorizon — максимальный интервал поиска следующего пролёта.
+	passSearchHorizon = 48 * time.Hour
+)
+
+// Pass описывает один пролёт спутника над наблюдателем.
+type Pass struct {
+	AOS        time.Time // Acquisition of Signal — восход над порогом угла места.
+	TCA        time.Time // Time of Closest Approach — момент максимального угла места.
+	LOS        time.Time // Loss of Signal — заход под порог угла места.
+	MaxElDeg   float64   // Максимальный угол места, градусы.
+	MaxElAzDeg float64   // Азимут в момент TCA, градусы.
+	RiseAzDeg  float64   // Азимут восхода (в момент AOS), градусы.
+	SetAzDeg   float64   // Азимут захода (в момент LOS), градусы.
+	Ascending  bool      // Спутник движется на север (широта подспутниковой точки растёт от AOS к LOS).
+
+	// OpticallyVisible — спутник виден невооружённым глазом хотя бы в части пролёта
+	// (освещён, у наблюдателя темно). Заполняется VisiblePasses и PassesWithVisibility;
+	// false — пролёт доступен только по радио.
+	OpticallyVisible bool
+}
+
+// Duration возвращает длительность пролёта.
+func (pass *Pass) Duration() time.Duration {
+	return pass.LOS.Sub(pass.AOS)
+}
+
+// Score возвращает геометрическое качество пролёта от 0 до 1 — синус максимального
+// угла места: высокий пролёт виден сквозь меньшую толщу атмосферы и ближе к наблюдателю.
+func (pass *Pass) Score() float64 {
+	return math.Max(0, math.Sin(pass.MaxElDeg*Deg2Rad))
+}
+
+// passJSON — представление Pass в ответах API.
+type passJSON struct {
+	AOS            string  `json:"aos"`
+	TCA            string  `json:"tca"`
+	LOS            string  `json:"los"`
+	DurationSec    float64 `json:"duration_s"`
+	MaxElevation   float64 `json:"max_elevation"`
+	MaxElevationAz float64 `json:"max_elevation_az"`
+	RiseAzimuth    float64 `json:"rise_azimuth"`
+	SetAzimuth     float64 `json:"set_azimuth"`
+	Ascending      bool    `json:"ascending"`
+	Visible        bool    `json:"optically_visible"`
+}
+
+// MarshalJSON сериализует пролёт для API: время в RFC 3339 (UTC),
+// углы в градусах, длительность в секундах.
+func (pass *Pass) MarshalJSON() ([]byte, error) {
+	return json.Marshal(passJSON{
+		AOS:            pass.AOS.UTC().Format(time.RFC3339),
+		TCA:            pass.TCA.UTC().Format(time.RFC3339),
+		LOS:            pass.LOS.UTC().Format(time.RFC3339),
+		DurationSec:    pass.Duration().Seconds(),
+		MaxElevation:   pass.MaxElDeg,
+		MaxElevationAz: pass.MaxElAzDeg,
+		RiseAzimuth:    pass.RiseAzDeg,
+		SetAzimuth:     pass.SetAzDeg,
+		Ascending:      pass.Ascending,
+		Visible:        pass.OpticallyVisible,
+	})
+}
+
+// VisibilityStats — сводная статистика пролётов спутника над наблюдателем за интервал.
+type VisibilityStats struct {
+	PassCount        int           // Число пролётов.
+	TotalVisible     time.Duration // Суммарное время над порогом угла места.
+	MeanMaxElDeg     float64       // Средний максимальный угол места пролёта, градусы.
+	MaxElDeg         float64       // Наибольший угол места за интервал, градусы.
+	MeanPassDuration time.Duration // Средняя длительность пролёта.
+}
+
+// PolarPoint — точка траектории пролёта на полярной диаграмме неба.
+type PolarPoint struct {
+	AzDeg float64   `json:"az"`   // Азимут, градусы [0, 360).
+	ElDeg float64   `json:"el"`   // Угол места, градусы [0, 90].
+	Time  time.Time `json:"time"` // Момент точки.
+}
+
+// RoutePass описывает пролёт, видимый с одной из точек маршрута.
+type RoutePass struct {
+	WaypointIndex int       // Индекс точки маршрута.
+	Observer      *Observer // Точка маршрута.
+	LegStart      time.Time // Начало интервала, когда путешественник у этой точки.
+	LegEnd        time.Time // Конец интервала.
+	Pass          *Pass     // Пролёт.
+}
+
+// NextPass находит ближайший пролёт спутника над наблюдателем.
+// Если спутник уже над порогом в момент after, возвращается текущий пролёт
+// (его AOS будет раньше after). Поиск ограничен 48 часами.
+func (p *Propagator) NextPass(obs *Observer, after time.Time, minElDeg float64) (*Pass, error) {
+	return p.findPass(obs, after, after.Add(passSearchHorizon), minElDeg)
+}
+
+// NextPass находит ближайший пролёт спутника, заданного TLE, над наблюдателем
+// после момента from с порогом угла места minElevationDeg. Обёртка над
+// Propagator.NextPass для случаев, когда Propagator не создан заранее.
+// Для спутника, постоянно находящегося над порогом (например, ГСО над
+// наблюдателем), возвращает ErrAlwaysVisible, для невидимого — ErrNoPassFound.
+func (obs *Observer) NextPass(tle *TLE, from time.Time, minElevationDeg float64) (*Pass, error) {
+	if obs == nil {
+		return nil, ErrNilObserver
+	}
+
+	prop, err := NewPropagator(tle)
+	if err != nil {
+		return nil, err
+	}
+
+	return prop.NextPass(obs, from, minElevationDeg)
+}
+
+// TimeToNextPass возвращает время до AOS ближайшего пролёта и сам пролёт.
+// Если спутник уже над порогом в момент now, возвращает нулевую длительность
+// и текущий пролёт. Используется для обратного отсчёта «до пролёта».
+func (p *Propagator) TimeToNextPass(obs *Observer, now time.Time, minElDeg float64) (time.Duration, *Pass, error) {
+	pass, err := p.NextPass(obs, now, minElDeg)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !pass.AOS.After(now) {
+		return 0, pass, nil
+	}
+
+	return pass.AOS.Sub(now), pass, nil
+}
+
+// PassesInWindow возвращает все пролёты, AOS которых попадает в интервал [start, end).
+// Пролёт, уже идущий в момент start, не включается — так соседние окна
+// не учитывают один пролёт дважды. LOS последнего пролёта может быть позже end.
+func (p *Propagator) PassesInWindow(obs *Observer, start, end time.Time, minElDeg float64) ([]*Pass, error) {
+	if p == nil {
+		return nil, ErrNilTLE
+	}
+
+	if obs == nil {
+		return nil, ErrNilObserver
+	}
+
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: start=%v end=%v", ErrInvalidWindow, start, end)
+	}
+
+	var passes []*Pass
+
+	for t := start; t.Before(end); {
+		pass, err := p.findPass(obs, t, end, minElDeg)
+		if errors.Is(err, ErrNoPassFound) || errors.Is(err, ErrAlwaysVisible) {
+			break
+		}
+
+		if err != nil {
+			return passes, err
+		}
+
+		if !pass.AOS.Before(end) {
+			break
+		}
+
+		if !pass.AOS.Before(start) {
+			passes = append(passes, pass)
+		}
+
+		t = pass.LOS.Add(passCoarseStep)
+	}
+
+	return passes, nil
+}
+
+// Passes возвращает все пролёты спутника, заданного TLE, с AOS в интервале [start, end)
+// и максимальным углом места не ниже minElevationDeg. Обёртка над
+// Propagator.PassesInWindow: пролёт, идущий на границе start, не учитывается,
+// а для ГСО и никогда не видимых спутников возвращается пустой список.
+func (obs *Observer) Passes(tle *TLE, start, end time.Time, minElevationDeg float64) ([]*Pass, error) {
+	if obs == nil {
+		return nil, ErrNilObserver
+	}
+
+	prop, err := NewPropagator(tle)
+	if err != nil {
+		return nil, err
+	}
+
+	return prop.PassesInWindow(obs, start, end, minElevationDeg)
+}
+
+// MergeAdjacentPasses объединяет пролёты, разделённые промежутком короче maxGap
+// (кратковременный уход под порог из-за рельефа или на низком пролёте).
+// Объединённый пролёт получает самый ранний AOS с азимутом восхода первого пролёта,
+// самый поздний LOS с азимутом захода последнего и кульминацию с наибольшим углом места.
+// Результат упорядочен по AOS; входные пролёты не изменяются.
+func MergeAdjacentPasses(passes []*Pass, maxGap time.Duration) []*Pass {
+	sorted := make([]*Pass, 0, len(passes))
+
+	for _, pass := range passes {
+		if pass != nil {
+			sorted = append(sorted, pass)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].AOS.Before(sorted[j].AOS) })
+
+	var merged []*Pass
+
+	for _, pass := range sorted {
+		n := len(merged)
+		if n == 0 || pass.AOS.Sub(merged[n-1].LOS) >= maxGap {
+			p := *pass
+			merged = append(merged, &p)
+
+			continue
+		}
+
+		last := merged[n-1]
+
+		if pass.MaxElDeg > last.MaxElDeg {
+			last.TCA, last.MaxElDeg, last.MaxElAzDeg = pass.TCA, pass.MaxElDeg, pass.MaxElAzDeg
+		}
+
+		if pass.LOS.After(last.LOS) {
+			last.LOS, last.SetAzDeg = pass.LOS, pass.SetAzDeg
+		}
+	}
+
+	return merged
+}
+
+// VisibilityStats агрегирует пролёты за интервал [start, end) (см. PassesInWindow):
+// число пролётов, суммарное время видимости, средний и наибольший угол места,
+// среднюю длительность. Используется для оценки площадки при выборе места станции.
+func (p *Propagator) VisibilityStats(obs *Observer, start, end time.Time, minElDeg float64) (VisibilityStats, error) {
+	passes, err := p.PassesInWindow(obs, start, end, minElDeg)
+	if err != nil {
+		return VisibilityStats{}, err
+	}
+
+	var (
+		stats    VisibilityStats
+		sumMaxEl float64
+	)
+
+	for _, pass := range passes {
+		stats.TotalVisible += pass.Duration()
+		stats.MaxElDeg = math.Max(stats.MaxElDeg, pass.MaxElDeg)
+		sumMaxEl += pass.MaxElDeg
+	}
+
+	stats.PassCount = len(passes)
+	if stats.PassCount > 0 {
+		stats.MeanMaxElDeg = sumMaxEl / float64(stats.PassCount)
+		stats.MeanPassDuration = stats.TotalVisible / time.Duration(stats.PassCount)
+	}
+
+	return stats, nil
+}
+
+// visiblePassSampleStep — шаг проверки визуальной видимости внутри пролёта.
+const visiblePassSampleStep = 10 * time.Second
+
+// VisiblePasses возвращает пролёты из Passes, во время которых спутник хотя бы
+// на время виден невооружённым глазом: над порогом угла места, освещён Солнцем,
+// а у наблюдателя темно (Солнце ниже ObserverDarkSunElevationDeg), см. IsVisibleAt.
+// У возвращённых пролётов установлен флаг OpticallyVisible.
+func (obs *Observer) VisiblePasses(tle *TLE, start, end time.Time, minElevationDeg float64) ([]*Pass, error) {
+	passes, err := obs.PassesWithVisibility(tle, start, end, minElevationDeg)
+	if err != nil {
+		return nil, err
+	}
+
+	var visible []*Pass
+
+	for _, pass := range passes {
+		if pass.OpticallyVisible {
+			visible = append(visible, pass)
+		}
+	}
+
+	return visible, nil
+}
+
+// PassesWithVisibility возвращает все пролёты из Passes с явно заданным флагом
+// OpticallyVisible: true для визуально наблюдаемых (см. VisiblePasses),
+// false для пролётов, доступных только по радио.
+func (obs *Observer) PassesWithVisibility(tle *TLE, start, end time.Time, minElevationDeg float64) ([]*Pass, error) {
+	if obs == nil {
+		return nil, ErrNilObserver
+	}
+
+	prop, err := NewPropagator(tle)
+	if err != nil {
+		return nil, err
+	}
+
+	passes, err := prop.PassesInWindow(obs, start, end, minElevationDeg)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pass := range passes {
+		if pass.OpticallyVisible, err = prop.visibleDuringPass(obs, pass, minElevationDeg); err != nil {
+			return nil, err
+		}
+	}
+
+	return passes, nil
+}
+
+// visibleDuringPass проверяет, виден ли спутник визуально хотя бы в одной точке пролёта.
+func (p *Propagator) visibleDuringPass(obs *Observer, pass *Pass, minElDeg float64) (bool, error) {
+	for t := pass.AOS; !t.After(pass.LOS); t = t.Add(visiblePassSampleStep) {
+		visible, err := p.IsVisibleAt(obs, t, minElDeg)
+		if err != nil || visible {
+			return visible, err
+		}
+	}
+
+	return p.IsVisibleAt(obs, pass.TCA, minElDeg)
+}
+
+// spottingMinElevationDeg — порог угла места для визуальных наблюдений: ниже
+// спутник теряется в дымке у горизонта.
+const spottingMinElevationDeg = 10.0
+
+// SpottingScore оценивает пригодность места и интервала [start, end] для визуальных
+// наблюдений спутника: сумма качества (Pass.Score) пролётов выше 10°, каждое
+// взвешенное долей пролёта, в течение которой спутник виден глазом (освещён,
+// у наблюдателя темно, см. IsVisibleAt). Дневные пролёты и пролёты в тени Земли
+// вклада не дают. Позволяет сравнивать места и ночи для наблюдений.
+func (p *Propagator) SpottingScore(obs *Observer, start, end time.Time) (float64, error) {
+	if p == nil {
+		return 0, ErrNilPropagator
+	}
+
+	if obs == nil {
+		return 0, ErrNilObserver
+	}
+
+	passes, err := p.PassesInWindow(obs, start, end, spottingMinElevationDeg)
+	if err != nil {
+		return 0, err
+	}
+
+	score := 0.0
+
+	for _, pass := range passes {
+		fraction, err := p.visibleFraction(obs, pass, spottingMinElevationDeg)
+		if err != nil {
+			return 0, err
+		}
+
+		score += pass.Score() * fraction
+	}
+
+	return score, nil
+}
+
+// visibleFraction возвращает долю точек пролёта (с шагом visiblePassSampleStep),
+// в которых спутник виден визуально.
+func (p *Propagator) visibleFraction(obs *Observer, pass *Pass, minElDeg float64) (float64, error) {
+	total, visible := 0, 0
+
+	for t := pass.AOS; !t.After(pass.LOS); t = t.Add(visiblePassSampleStep) {
+		ok, err := p.IsVisibleAt(obs, t, minElDeg)
+		if err != nil {
+			return 0, err
+		}
+
+		total++
+
+		if ok {
+			visible++
+		}
+	}
+
+	return float64(visible) / float64(total), nil
+}
+
+// PassesAlongRoute находит пролёты, видимые путешественником на маршруте.
+// Интервал [start, end) делится поровну между точками маршрута; для каждой
+// точки ищутся пролёты с AOS внутри её отрезка времени.
+func (p *Propagator) PassesAlongRoute(waypoints []*Observer, start, end time.Time, minElDeg float64) ([]RoutePass, error) {
+	if len(waypoints) == 0 {
+		return nil, ErrEmptyWaypoints
+	}
+
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: start=%v end=%v", ErrInvalidWindow, start, end)
+	}
+
+	legDuration := end.Sub(start) / time.Duration(len(waypoints))
+
+	var result []RoutePass
+
+	for i, obs := range waypoints {
+		legStart := start.Add(time.Duration(i) * legDuration)
+		legEnd := legStart.Add(legDuration)
+
+		// Последний отрезок забирает остаток от целочисленного деления.
+		if i == len(waypoints)-1 {
+			legEnd = end
+		}
+
+		passes, err := p.PassesInWindow(obs, legStart, legEnd, minElDeg)
+		if err != nil {
+			return result, fmt.Errorf("waypoint %d: %w", i, err)
+		}
+
+		for _, pass := range passes {
+			result = append(result, RoutePass{
+				WaypointIndex: i,
+				Observer:      obs,
+				LegStart:      legStart,
+				LegEnd:        legEnd,
+				Pass:          pass,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// findPass ищет пролёт, идущий в момент from или начинающийся до until.
+// Поиск LOS может выходить за until не более чем на passSearchHorizon.
+func (p *Propagator) findPass(obs *Observer, from, until time.Time, minElDeg float64) (*Pass, error) {
+	if p == nil {
+		return nil, ErrNilTLE
+	}
+
+	if obs == nil {
+		return nil, ErrNilObserver
+	}
+
+	el, err := p.elevationDeg(obs, from)
+	if err != nil {
+		return nil, err
+	}
+
+	var aos time.Time
+
+	if el >= minElDeg {
+		// Спутник уже над порогом — ищем восход назад по времени.
+		aos, err = p.scanCrossing(obs, from, from.Add(-passSearchHorizon), minElDeg, false)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		aos, err = p.scanCrossing(obs, from, until, minElDeg, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	los, err := p.scanCrossing(obs, aos.Add(p.refine.Tolerance), aos.Add(passSearchHorizon), minElDeg, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tca, maxEl, err := p.findCulmination(obs, aos, los)
+	if err != nil {
+		return nil, err
+	}
+
+	riseAz, err := p.azimuthDeg(obs, aos)
+	if err != nil {
+		return nil, err
+	}
+
+	maxElAz, err := p.azimuthDeg(obs, tca)
+	if err != nil {
+		return nil, err
+	}
+
+	setAz, err := p.azimuthDeg(obs, los)
+	if err != nil {
+		return nil, err
+	}
+
+	aosLat, err := p.subPointLatDeg(aos)
+	if err != nil {
+		return nil, err
+	}
+
+	losLat, err := p.subPointLatDeg(los)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pass{
+		AOS:        aos,
+		TCA:        tca,
+		LOS:        los,
+		MaxElDeg:   maxEl,
+		MaxElAzDeg: maxElAz,
+		RiseAzDeg:  riseAz,
+		SetAzDeg:   setAz,
+		Ascending:  losLat > aosLat,
+	}, nil
+}
+
+// scanCrossing грубо сканирует угол места от from к to (в любом направлении)
+// и уточняет бисекцией момент пересечения порога minElDeg.
+// rising=true ищет момент, когда спутник оказывается над порогом, false — под порогом.
+// Возвращаемое время всегда соответствует моменту, когда спутник уже над порогом
+// (для AOS) или ещё над порогом (для LOS).
+func (p *Propagator) scanCrossing(obs *Observer, from, to time.Time, minElDeg float64, rising bool) (time.Time, error) {
+	step := passCoarseStep
+	if to.Before(from) {
+		step = -step
+	}
+
+	prev := from
+
+	for t := from.Add(step); ; t = t.Add(step) {
+		if (step > 0 && t.After(to)) || (step < 0 && t.Before(to)) {
+			break
+		}
+
+		el, err := p.elevationDeg(obs, t)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		above := el >= minElDeg
+		if above == rising {
+			return p.bisectCrossing(obs, prev, t, minElDeg)
+		}
+
+		prev = t
+	}
+
+	if rising {
+		return time.Time{}, fmt.Errorf("%w: between %v and %v", ErrNoPassFound, from, to)
+	}
+
+	return time.Time{}, fmt.Errorf("%w: between %v and %v", ErrAlwaysVisible, from, to)
+}
+
+// bisectCrossing уточняет момент пересечения порога между a и b.
+// Возвращает границу интервала, на которой спутник над порогом.
+func (p *Propagator) bisectCrossing(obs *Observer, a, b time.Time, minElDeg float64) (time.Time, error) {
+	elA, err := p.elevationDeg(obs, a)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	aboveA := elA >= minElDeg
+
+	for iter := 0; !p.refine.converged(b.Sub(a), iter); iter++ {
+		mid := a.Add(b.Sub(a) / 2)
+
+		el, err := p.elevationDeg(obs, mid)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if (el >= minElDeg) == aboveA {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+
+	if aboveA {
+		return a, nil
+	}
+
+	return b, nil
+}
+
+// findCulmination находит момент максимального угла места на интервале [aos, los].
+// Сначала выполняется грубое сканирование, затем тернарный поиск вокруг максимума.
+func (p *Propagator) findCulmination(obs *Observer, aos, los time.Time) (time.Time, float64, error) {
+	bestT := aos
+
+	bestEl, err := p.elevationDeg(obs, aos)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	for t := aos.Add(passCoarseStep); !t.After(los); t = t.Add(passCoarseStep) {
+		el, err := p.elevationDeg(obs, t)
+		if err != nil {
+			return time.Time{}, 0, err
+		}
+
+		if el > bestEl {
+			bestT, bestEl = t, el
+		}
+	}
+
+	lo := maxTime(aos, bestT.Add(-passCoarseStep))
+	hi := minTime(los, bestT.Add(passCoarseStep))
+
+	for iter := 0; !p.refine.converged(hi.Sub(lo), iter); iter++ {
+		third := hi.Sub(lo) / 3
+		m1, m2 := lo.Add(third), hi.Add(-third)
+
+		el1, err := p.elevationDeg(obs, m1)
+		if err != nil {
+			return time.Time{}, 0, err
+		}
+
+		el2, err := p.elevationDeg(obs, m2)
+		if err != nil {
+			return time.Time{}, 0, err
+		}
+
+		if el1 < el2 {
+			lo = m1
+		} else {
+			hi = m2
+		}
+	}
+
+	mid := lo.Add(hi.Sub(lo) / 2)
+
+	el, err := p.elevationDeg(obs, mid)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	if el > bestEl {
+		bestT, bestEl = mid, el
+	}
+
+	return bestT, bestEl, nil
+}
+
+// elevationDeg возвращает угол места спутника для наблюдателя в градусах.
+func (p *Propagator) elevationDeg(obs *Observer, t time.Time) (float64, error) {
+	aer, err := p.lookAngle(obs, t)
+	if err != nil {
+		return 0, err
+	}
+
+	return aer.ElDeg(), nil
+}
+
+// azimuthDeg возвращает азимут спутника для наблюдателя в градусах.
+func (p *Propagator) azimuthDeg(obs *Observer, t time.Time) (float64, error) {
+	aer, err := p.lookAngle(obs, t)
+	if err != nil {
+		return 0, err
+	}
+
+	return aer.AzDeg(), nil
+}
+
+// PassPolarPlot возвращает траекторию пролёта для полярной диаграммы неба: точки
+// от AOS до LOS с шагом step, последняя точка — ровно LOS. Угол места ограничен
+// снизу горизонтом, поэтому крайние точки, рассчитанные бисекцией с небольшой
+// погрешностью, не уходят под горизонт.
+func (obs *Observer) PassPolarPlot(prop *Propagator, pass *Pass, step time.Duration) ([]PolarPoint, error) {
+	if obs == nil {
+		return nil, ErrNilObserver
+	}
+
+	if prop == nil {
+		return nil, ErrNilPropagator
+	}
+
+	if pass == nil {
+		return nil, ErrNilPass
+	}
+
+	if step <= 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStep, step)
+	}
+
+	var points []PolarPoint
+
+	for t := pass.AOS; ; t = t.Add(step) {
+		t = minTime(t, pass.LOS)
+
+		aer, err := prop.lookAngle(obs, t)
+		if err != nil {
+			return nil, err
+		}
+
+		points = append(points, PolarPoint{AzDeg: aer.AzDeg(), ElDeg: math.Max(0, aer.ElDeg()), Time: t})
+
+		if !t.Before(pass.LOS) {
+			return points, nil
+		}
+	}
+}
+
+// subPointLatDeg возвращает широту подспутниковой точки в момент t, градусы.
+func (p *Propagator) subPointLatDeg(t time.Time) (float64, error) {
+	pos, err := p.propagatePrecise(t)
+	if err != nil {
+		return 0, err
+	}
+
+	return ECEFToLLA(ECIToECEF(pos)).LatDeg(), nil
+}
+
+// lookAngle возвращает направление с наблюдателя на спутник в момент t.
+func (p *Propagator) lookAngle(obs *Observer, t time.Time) (*AER, error) {
+	pos, err := p.propagatePrecise(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return obs.GetAER(pos), nil
+}
+
+// absDuration возвращает модуль длительности.
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+
+	return d
+}
+
+// minTime возвращает более раннее из двух времён.
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
+
+// maxTime возвращает более позднее из двух времён.
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,680 @@
+package tracker
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// Наблюдатели для тестов пролётов.
+var (
+	passTestMoscow = NewObserver(55.7558, 37.6173, 0.15)
+	passTestRostov = NewObserver(47.2357, 39.7015, 0.07)
+)
+
+// passTestStart — эпоха тестового TLE ISS (1 января 2024, 12:00 UTC).
+var passTestStart = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// TestPassesInWindow проверяет поиск пролётов ISS над Москвой за сутки.
+func TestPassesInWindow(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+	end := passTestStart.Add(24 * time.Hour)
+
+	passes, err := prop.PassesInWindow(passTestMoscow, passTestStart, end, 10)
+	if err != nil {
+		t.Fatalf("PassesInWindow() error = %v", err)
+	}
+
+	if len(passes) == 0 {
+		t.Fatal("PassesInWindow() returned no passes")
+	}
+
+	for i, pass := range passes {
+		if pass.AOS.Before(passTestStart) || !pass.AOS.Before(end) {
+			t.Errorf("pass[%d] AOS %v outside window", i, pass.AOS)
+		}
+
+		if !pass.AOS.Before(pass.TCA) || !pass.TCA.Before(pass.LOS) {
+			t.Errorf("pass[%d] expected AOS < TCA < LOS, got %v, %v, %v", i, pass.AOS, pass.TCA, pass.LOS)
+		}
+
+		if pass.MaxElDeg < 10 || pass.MaxElDeg > 90 {
+			t.Errorf("pass[%d] MaxElDeg = %.2f, expected 10-90", i, pass.MaxElDeg)
+		}
+
+		if pass.MaxElAzDeg < 0 || pass.MaxElAzDeg >= 360 {
+			t.Errorf("pass[%d] MaxElAzDeg = %.2f, expected [0, 360)", i, pass.MaxElAzDeg)
+		}
+
+		if pass.Duration() <= 0 || pass.Duration() > 20*time.Minute {
+			t.Errorf("pass[%d] Duration = %v, expected 0-20m", i, pass.Duration())
+		}
+
+		if i > 0 && !passes[i-1].LOS.Before(pass.AOS) {
+			t.Errorf("pass[%d] overlaps previous pass", i)
+		}
+	}
+}
+
+// TestPassesInWindow_InvalidInput проверяет обработку некорректных аргументов.
+func TestPassesInWindow_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+
+	if _, err := prop.PassesInWindow(nil, passTestStart, passTestStart.Add(time.Hour), 0); err == nil {
+		t.Error("PassesInWindow(nil observer) expected error")
+	}
+
+	if _, err := prop.PassesInWindow(passTestMoscow, passTestStart, passTestStart, 0); err == nil {
+		t.Error("PassesInWindow(empty window) expected error")
+	}
+}
+
+// TestPassesAlongRoute проверяет распределение пролётов по отрезкам маршрута.
+func TestPassesAlongRoute(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+	end := passTestStart.Add(24 * time.Hour)
+	mid := passTestStart.Add(12 * time.Hour)
+	waypoints := []*Observer{passTestMoscow, passTestRostov}
+
+	routePasses, err := prop.PassesAlongRoute(waypoints, passTestStart, end, 10)
+	if err != nil {
+		t.Fatalf("PassesAlongRoute() error = %v", err)
+	}
+
+	firstLeg, err := prop.PassesInWindow(passTestMoscow, passTestStart, mid, 10)
+	if err != nil {
+		t.Fatalf("PassesInWindow(first leg) error = %v", err)
+	}
+
+	secondLeg, err := prop.PassesInWindow(passTestRostov, mid, end, 10)
+	if err != nil {
+		t.Fatalf("PassesInWindow(second leg) error = %v", err)
+	}
+
+	if len(routePasses) != len(firstLeg)+len(secondLeg) {
+		t.Fatalf("PassesAlongRoute() returned %d passes, want %d+%d",
+			len(routePasses), len(firstLeg), len(secondLeg))
+	}
+
+	for i, rp := range routePasses {
+		wantIdx := 0
+		if !rp.Pass.AOS.Before(mid) {
+			wantIdx = 1
+		}
+
+		if rp.WaypointIndex != wantIdx {
+			t.Errorf("routePass[%d] AOS %v attributed to waypoint %d, want %d",
+				i, rp.Pass.AOS, rp.WaypointIndex, wantIdx)
+		}
+
+		if rp.Observer != waypoints[wantIdx] {
+			t.Errorf("routePass[%d] observer mismatch", i)
+		}
+
+		if rp.Pass.AOS.Before(rp.LegStart) || !rp.Pass.AOS.Before(rp.LegEnd) {
+			t.Errorf("routePass[%d] AOS %v outside leg [%v, %v)", i, rp.Pass.AOS, rp.LegStart, rp.LegEnd)
+		}
+	}
+
+	if _, err := prop.PassesAlongRoute(nil, passTestStart, end, 10); err == nil {
+		t.Error("PassesAlongRoute(nil waypoints) expected error")
+	}
+}
+
+// TestNextPass_RiseSetAzimuth проверяет азимуты восхода и захода пролёта ISS.
+func TestNextPass_RiseSetAzimuth(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+
+	pass, err := prop.NextPass(passTestMoscow, passTestStart.Add(30*time.Minute), 0)
+	if err != nil {
+		t.Fatalf("NextPass() error = %v", err)
+	}
+
+	for name, az := range map[string]float64{"RiseAzDeg": pass.RiseAzDeg, "SetAzDeg": pass.SetAzDeg} {
+		if az < 0 || az >= 360 {
+			t.Errorf("%s = %.2f, expected [0, 360)", name, az)
+		}
+	}
+
+	// Восход и заход находятся на разных сторонах горизонта.
+	if diff := math.Abs(NormalizeLongitude(pass.SetAzDeg - pass.RiseAzDeg)); diff < 30 {
+		t.Errorf("rise %.1f° and set %.1f° azimuths should differ", pass.RiseAzDeg, pass.SetAzDeg)
+	}
+}
+
+// TestObserver_NextPass проверяет поиск пролёта по TLE и обработку
+// постоянно видимого и никогда не видимого геостационарного спутника.
+func TestObserver_NextPass(t *testing.T) {
+	t.Parallel()
+
+	tle, err := ParseTLE([]string{issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	from := passTestStart.Add(30 * time.Minute)
+
+	got, err := passTestMoscow.NextPass(tle, from, 10)
+	if err != nil {
+		t.Fatalf("NextPass() error = %v", err)
+	}
+
+	want, err := createTestPropagator(t).NextPass(passTestMoscow, from, 10)
+	if err != nil {
+		t.Fatalf("Propagator.NextPass() error = %v", err)
+	}
+
+	if *got != *want {
+		t.Errorf("Observer.NextPass() = %+v, want %+v", got, want)
+	}
+
+	geo, subLon := geoTestTLE(t)
+
+	below := NewObserver(0, subLon, 0)
+	if _, err := below.NextPass(geo, passTestStart, 10); !errors.Is(err, ErrAlwaysVisible) {
+		t.Errorf("NextPass() under GEO error = %v, want ErrAlwaysVisible", err)
+	}
+
+	farSide := NewObserver(0, NormalizeLongitude(subLon+180), 0)
+	if _, err := farSide.NextPass(geo, passTestStart, 10); !errors.Is(err, ErrNoPassFound) {
+		t.Errorf("NextPass() on the far side error = %v, want ErrNoPassFound", err)
+	}
+
+	if _, err := (*Observer)(nil).NextPass(tle, from, 10); !errors.Is(err, ErrNilObserver) {
+		t.Errorf("nil observer error = %v, want ErrNilObserver", err)
+	}
+}
+
+// countElevationPeaks перебором считает пролёты с максимумом угла места не ниже minElDeg,
+// начавшиеся в окне [start, end): пролёт, идущий в момент start, не учитывается.
+func countElevationPeaks(t *testing.T, tle *TLE, obs *Observer, start, end time.Time, minElDeg float64) int {
+	t.Helper()
+
+	prop, err := NewPropagator(tle)
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	var (
+		count, peaks int
+		above        bool
+		maxEl        float64
+		started      bool
+	)
+
+	for at := start; ; at = at.Add(10 * time.Second) {
+		pos, err := prop.Propagate(at)
+		if err != nil {
+			t.Fatalf("Propagate() error = %v", err)
+		}
+
+		el := obs.GetAER(pos).ElDeg()
+
+		switch {
+		case el > 0 && !above:
+			above, started, maxEl = true, at.After(start), el
+		case el > 0:
+			maxEl = max(maxEl, el)
+		case above:
+			above = false
+
+			if started && maxEl >= minElDeg {
+				count++
+			}
+
+			peaks++
+		}
+
+		// Идущий в конце окна пролёт досчитывается до захода.
+		if !at.Before(end) && !above {
+			break
+		}
+	}
+
+	if peaks == 0 {
+		t.Fatal("brute-force scan found no passes at all")
+	}
+
+	return count
+}
+
+// geoTestTLE создаёт синтетический TLE геостационарного спутника и возвращает
+// долготу его подспутниковой точки.
+func geoTestTLE(t *testing.T) (*TLE, float64) {
+	t.Helper()
+
+	var err error
+
+	geo := &TLE{NoradID: 99001, Epoch: passTestStart, Inclination: 0.05, MeanMotion: 1.00273791}
+	if geo.Line1, geo.Line2, err = geo.ToLines(); err != nil {
+		t.Fatalf("ToLines() error = %v", err)
+	}
+
+	prop, err := NewPropagator(geo)
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	pos, err := prop.Propagate(passTestStart)
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	return geo, ECEFToLLA(ECIToECEF(pos)).LonDeg()
+}
+
+// TestObserver_Passes проверяет список пролётов МКС над Москвой за сутки
+// и завершение поиска для ГСО.
+func TestObserver_Passes(t *testing.T) {
+	t.Parallel()
+
+	tle, err := ParseTLE([]string{issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	const minEl = 10
+
+	end := passTestStart.Add(24 * time.Hour)
+
+	passes, err := passTestMoscow.Passes(tle, passTestStart, end, minEl)
+	if err != nil {
+		t.Fatalf("Passes() error = %v", err)
+	}
+
+	// Наклонение МКС (51.6°) меньше широты Москвы (55.8°): Москва на краю полосы трассы,
+	// и за сутки над 10° поднимаются лишь 3 из 5–6 пролётов (13:28, 15:05 и 11:04 UTC).
+	// Количество сверяется с независимым перебором угла места с шагом 10 с.
+	if want := countElevationPeaks(t, tle, passTestMoscow, passTestStart, end, minEl); len(passes) != want || want != 3 {
+		t.Errorf("Passes() returned %d passes, brute-force scan finds %d, want 3", len(passes), want)
+	}
+
+	for i, pass := range passes {
+		if pass.MaxElDeg < minEl {
+			t.Errorf("pass[%d] MaxElDeg = %.2f below threshold", i, pass.MaxElDeg)
+		}
+
+		// МКС над Москвой в 12:00 — идущий пролёт не должен попасть в список.
+		if pass.AOS.Before(passTestStart) {
+			t.Errorf("pass[%d] AOS %v before window start", i, pass.AOS)
+		}
+	}
+
+	geo, subLon := geoTestTLE(t)
+
+	for _, obs := range []*Observer{NewObserver(0, subLon, 0), NewObserver(0, NormalizeLongitude(subLon+180), 0)} {
+		geoPasses, err := obs.Passes(geo, passTestStart, passTestStart.Add(24*time.Hour), minEl)
+		if err != nil || len(geoPasses) != 0 {
+			t.Errorf("GEO Passes() = %d passes, error = %v, want none", len(geoPasses), err)
+		}
+	}
+}
+
+// TestTimeToNextPass проверяет обратный отсчёт в промежутке между пролётами и во время пролёта.
+func TestTimeToNextPass(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+
+	// 12:30 — между пролётами, следующий начинается около 13:26.
+	now := passTestStart.Add(30 * time.Minute)
+
+	wait, pass, err := prop.TimeToNextPass(passTestMoscow, now, 0)
+	if err != nil {
+		t.Fatalf("TimeToNextPass() error = %v", err)
+	}
+
+	if wait <= 0 || wait != pass.AOS.Sub(now) {
+		t.Errorf("TimeToNextPass() = %v, want positive AOS-now = %v", wait, pass.AOS.Sub(now))
+	}
+
+	// Во время пролёта отсчёт нулевой, возвращается текущий пролёт.
+	wait, current, err := prop.TimeToNextPass(passTestMoscow, pass.TCA, 0)
+	if err != nil {
+		t.Fatalf("TimeToNextPass(during pass) error = %v", err)
+	}
+
+	if wait != 0 {
+		t.Errorf("TimeToNextPass(during pass) = %v, want 0", wait)
+	}
+
+	if absDuration(current.AOS.Sub(pass.AOS)) > 2*time.Second {
+		t.Errorf("in-progress pass AOS = %v, want %v", current.AOS, pass.AOS)
+	}
+}
+
+// TestPropagator_VisibilityStats проверяет, что агрегаты за сутки согласованы
+// с отдельными пролётами МКС над Москвой.
+func TestPropagator_VisibilityStats(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+	end := passTestStart.Add(24 * time.Hour)
+
+	stats, err := prop.VisibilityStats(passTestMoscow, passTestStart, end, 0)
+	if err != nil {
+		t.Fatalf("VisibilityStats() error = %v", err)
+	}
+
+	passes, err := prop.PassesInWindow(passTestMoscow, passTestStart, end, 0)
+	if err != nil {
+		t.Fatalf("PassesInWindow() error = %v", err)
+	}
+
+	var total time.Duration
+
+	maxEl := 0.0
+
+	for _, pass := range passes {
+		total += pass.Duration()
+		maxEl = math.Max(maxEl, pass.MaxElDeg)
+	}
+
+	if stats.PassCount != len(passes) || stats.PassCount == 0 {
+		t.Errorf("PassCount = %d, want %d (non-zero)", stats.PassCount, len(passes))
+	}
+
+	if absDuration(stats.TotalVisible-total) > time.Second {
+		t.Errorf("TotalVisible = %v, sum of pass durations = %v", stats.TotalVisible, total)
+	}
+
+	if stats.MaxElDeg != maxEl || stats.MeanMaxElDeg <= 0 || stats.MeanMaxElDeg > stats.MaxElDeg {
+		t.Errorf("MeanMaxElDeg = %.2f, MaxElDeg = %.2f (want max %.2f)", stats.MeanMaxElDeg, stats.MaxElDeg, maxEl)
+	}
+
+	if stats.MeanPassDuration <= 0 || stats.MeanPassDuration > 15*time.Minute {
+		t.Errorf("MeanPassDuration = %v, expected 0-15m", stats.MeanPassDuration)
+	}
+}
+
+// TestPass_Ascending проверяет, что флаг направления совпадает со знаком
+// изменения широты подспутниковой точки от AOS к LOS.
+func TestPass_Ascending(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+
+	passes, err := prop.PassesInWindow(passTestMoscow, passTestStart, passTestStart.Add(24*time.Hour), 0)
+	if err != nil {
+		t.Fatalf("PassesInWindow() error = %v", err)
+	}
+
+	var ascending, descending int
+
+	for i, pass := range passes {
+		aosLat, err := prop.subPointLatDeg(pass.AOS)
+		if err != nil {
+			t.Fatalf("subPointLatDeg() error = %v", err)
+		}
+
+		losLat, err := prop.subPointLatDeg(pass.LOS)
+		if err != nil {
+			t.Fatalf("subPointLatDeg() error = %v", err)
+		}
+
+		if pass.Ascending != (losLat > aosLat) {
+			t.Errorf("pass[%d] Ascending = %v, latitude %.2f° -> %.2f°", i, pass.Ascending, aosLat, losLat)
+		}
+
+		if pass.Ascending {
+			ascending++
+		} else {
+			descending++
+		}
+	}
+
+	// За сутки МКС проходит над Москвой как на восходящих, так и на нисходящих витках.
+	if ascending == 0 || descending == 0 {
+		t.Errorf("got %d ascending and %d descending passes, want both", ascending, descending)
+	}
+}
+
+// TestObserver_PassPolarPlot проверяет, что траектория пролёта не уходит
+// под горизонт и азимут меняется непрерывно.
+func TestObserver_PassPolarPlot(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+
+	pass, err := prop.NextPass(passTestMoscow, passTestStart.Add(30*time.Minute), 0)
+	if err != nil {
+		t.Fatalf("NextPass() error = %v", err)
+	}
+
+	points, err := passTestMoscow.PassPolarPlot(prop, pass, 10*time.Second)
+	if err != nil {
+		t.Fatalf("PassPolarPlot() error = %v", err)
+	}
+
+	if len(points) < 10 {
+		t.Fatalf("PassPolarPlot() returned %d points", len(points))
+	}
+
+	if !points[0].Time.Equal(pass.AOS) || !points[len(points)-1].Time.Equal(pass.LOS) {
+		t.Errorf("plot spans %v-%v, want AOS %v - LOS %v", points[0].Time, points[len(points)-1].Time, pass.AOS, pass.LOS)
+	}
+
+	for i, p := range points {
+		if p.ElDeg < 0 || p.ElDeg > 90 {
+			t.Errorf("point[%d] ElDeg = %.3f, want [0, 90]", i, p.ElDeg)
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		if step := math.Abs(NormalizeLongitude(p.AzDeg - points[i-1].AzDeg)); step > 15 {
+			t.Errorf("azimuth jumps %.1f° between points %d and %d", step, i-1, i)
+		}
+	}
+
+	if _, err := passTestMoscow.PassPolarPlot(prop, pass, 0); !errors.Is(err, ErrInvalidStep) {
+		t.Errorf("PassPolarPlot(step=0) error = %v, want ErrInvalidStep", err)
+	}
+}
+
+// TestMergeAdjacentPasses проверяет объединение пролёта, разорванного кратким
+// уходом под порог, и сохранение далеко отстоящих пролётов.
+func TestMergeAdjacentPasses(t *testing.T) {
+	t.Parallel()
+
+	first := &Pass{
+		AOS: passTestStart, TCA: passTestStart.Add(2 * time.Minute), LOS: passTestStart.Add(4 * time.Minute),
+		MaxElDeg: 12, MaxElAzDeg: 200, RiseAzDeg: 250, SetAzDeg: 180,
+	}
+	second := &Pass{
+		AOS: first.LOS.Add(20 * time.Second), TCA: first.LOS.Add(2 * time.Minute), LOS: first.LOS.Add(5 * time.Minute),
+		MaxElDeg: 18, MaxElAzDeg: 150, RiseAzDeg: 175, SetAzDeg: 90,
+	}
+	later := &Pass{AOS: passTestStart.Add(90 * time.Minute), LOS: passTestStart.Add(100 * time.Minute), MaxElDeg: 40}
+
+	merged := MergeAdjacentPasses([]*Pass{later, second, first}, time.Minute)
+	if len(merged) != 2 {
+		t.Fatalf("MergeAdjacentPasses() returned %d passes, want 2", len(merged))
+	}
+
+	got := merged[0]
+	want := Pass{
+		AOS: first.AOS, TCA: second.TCA, LOS: second.LOS,
+		MaxElDeg: 18, MaxElAzDeg: 150, RiseAzDeg: 250, SetAzDeg: 90,
+	}
+
+	if *got != want {
+		t.Errorf("merged pass = %+v, want %+v", *got, want)
+	}
+
+	if merged[1].AOS != later.AOS {
+		t.Errorf("second result should be the later pass, got AOS %v", merged[1].AOS)
+	}
+
+	if first.LOS != passTestStart.Add(4*time.Minute) || first.MaxElDeg != 12 {
+		t.Error("MergeAdjacentPasses() must not modify input passes")
+	}
+
+	if n := len(MergeAdjacentPasses([]*Pass{first, second}, 10*time.Second)); n != 2 {
+		t.Errorf("gap larger than maxGap: got %d passes, want 2", n)
+	}
+}
+
+// TestObserver_VisiblePasses проверяет отбор визуально видимых пролётов МКС
+// летними вечерами в средних широтах.
+func TestObserver_VisiblePasses(t *testing.T) {
+	t.Parallel()
+
+	tle := issTestTLE(t, issSolsticeEpoch, nil)
+	start, end := tle.Epoch, tle.Epoch.Add(48*time.Hour)
+
+	all, err := passTestRostov.Passes(tle, start, end, 10)
+	if err != nil {
+		t.Fatalf("Passes() error = %v", err)
+	}
+
+	visible, err := passTestRostov.VisiblePasses(tle, start, end, 10)
+	if err != nil {
+		t.Fatalf("VisiblePasses() error = %v", err)
+	}
+
+	if len(visible) == 0 || len(visible) >= len(all) {
+		t.Fatalf("got %d visible of %d passes, want some but not all", len(visible), len(all))
+	}
+
+	prop, err := NewPropagator(tle)
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	for i, pass := range visible {
+		if !pass.OpticallyVisible {
+			t.Errorf("visible[%d] OpticallyVisible = false", i)
+		}
+
+		if ok, err := prop.visibleDuringPass(passTestRostov, pass, 10); err != nil || !ok {
+			t.Errorf("visible[%d] is not visible during pass (err = %v)", i, err)
+		}
+	}
+
+	// В общем списке флаг задан у каждого пролёта: радиопролёты помечены false.
+	mixed, err := passTestRostov.PassesWithVisibility(tle, start, end, 10)
+	if err != nil {
+		t.Fatalf("PassesWithVisibility() error = %v", err)
+	}
+
+	if len(mixed) != len(all) {
+		t.Fatalf("PassesWithVisibility() returned %d passes, want %d", len(mixed), len(all))
+	}
+
+	optical := 0
+
+	for i, pass := range mixed {
+		want, err := prop.visibleDuringPass(passTestRostov, pass, 10)
+		if err != nil {
+			t.Fatalf("visibleDuringPass() error = %v", err)
+		}
+
+		if pass.OpticallyVisible != want {
+			t.Errorf("mixed[%d] at %v OpticallyVisible = %v, want %v", i, pass.AOS, pass.OpticallyVisible, want)
+		}
+
+		if pass.OpticallyVisible {
+			optical++
+		}
+	}
+
+	if optical != len(visible) {
+		t.Errorf("PassesWithVisibility() marked %d passes visible, VisiblePasses() returned %d", optical, len(visible))
+	}
+}
+
+// TestPropagator_SpottingScore проверяет, что ночное окно с освещёнными пролётами
+// оценивается выше утреннего окна, где пролёты выше, но проходят при свете дня.
+func TestPropagator_SpottingScore(t *testing.T) {
+	t.Parallel()
+
+	prop := issTestPropagator(t, issSolsticeEpoch, nil)
+
+	// Ростов, UTC+3: ночь 00:00–04:00 и утро 05:30–09:00 местного времени.
+	nightStart := time.Date(2024, 6, 20, 21, 0, 0, 0, time.UTC)
+	dayStart := time.Date(2024, 6, 21, 2, 30, 0, 0, time.UTC)
+
+	night, err := prop.SpottingScore(passTestRostov, nightStart, nightStart.Add(4*time.Hour))
+	if err != nil {
+		t.Fatalf("SpottingScore(night) error = %v", err)
+	}
+
+	day, err := prop.SpottingScore(passTestRostov, dayStart, dayStart.Add(210*time.Minute))
+	if err != nil {
+		t.Fatalf("SpottingScore(day) error = %v", err)
+	}
+
+	dayPasses, err := prop.PassesInWindow(passTestRostov, dayStart, dayStart.Add(210*time.Minute), spottingMinElevationDeg)
+	if err != nil {
+		t.Fatalf("PassesInWindow() error = %v", err)
+	}
+
+	if len(dayPasses) == 0 {
+		t.Fatal("no daytime passes in test window")
+	}
+
+	if day != 0 {
+		t.Errorf("SpottingScore(day) = %.3f, want 0 for daylight passes", day)
+	}
+
+	if night <= day {
+		t.Errorf("SpottingScore(night) = %.3f, want above day %.3f", night, day)
+	}
+}
+
+// TestPass_MarshalJSON проверяет формат пролёта в ответах API.
+func TestPass_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	aos := time.Date(2024, 1, 1, 16, 26, 5, 0, time.FixedZone("MSK", 3*60*60))
+	pass := &Pass{
+		AOS:        aos,
+		TCA:        aos.Add(5 * time.Minute),
+		LOS:        aos.Add(10 * time.Minute),
+		MaxElDeg:   63.5,
+		MaxElAzDeg: 170,
+		RiseAzDeg:  290.5,
+		SetAzDeg:   95.25,
+		Ascending:  true,
+	}
+
+	data, err := json.Marshal(pass)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	want := map[string]any{
+		"aos":              "2024-01-01T13:26:05Z",
+		"tca":              "2024-01-01T13:31:05Z",
+		"los":              "2024-01-01T13:36:05Z",
+		"duration_s":       600.0,
+		"max_elevation":    63.5,
+		"max_elevation_az": 170.0,
+		"rise_azimuth":     290.5,
+		"set_azimuth":      95.25,
+		"ascending":        true,
+	}
+
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %v, want %v", key, got[key], value)
+		}
+	}
+}
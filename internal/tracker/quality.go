@@ -0,0 +1,177 @@
+package tracker
+
+import (
+	"math"
+	"math/bits"
+	"time"
+)
+
+// QualityFlag — набор признаков сомнительного качества TLE (битовая маска).
+type QualityFlag uint8
+
+// Признаки качества TLE.
+const (
+	// QualityBadChecksum — строки Line1/Line2 отсутствуют или контрольная сумма неверна.
+	QualityBadChecksum QualityFlag = 1 << iota
+	// QualityImplausibleElements — элементы орбиты вне физически допустимых пределов.
+	QualityImplausibleElements
+	// QualityZeroBstar — нулевой B* у низкоорбитального спутника, где торможение существенно.
+	QualityZeroBstar
+)
+
+// qualityCorrupt — признаки, при которых запись считается повреждённой.
+const qualityCorrupt = QualityBadChecksum | QualityImplausibleElements
+
+// Пределы правдоподобных элементов орбиты.
+const (
+	minPlausibleMeanMotion  = 0.5   // Оборотов/сутки; ниже — орбита дальше ~67 000 км.
+	maxPlausibleMeanMotion  = 17.0  // Оборотов/сутки; выше — орбита ниже ~100 км.
+	maxPlausibleInclination = 180.0 // Градусы.
+)
+
+// Has проверяет, установлен ли признак flag.
+func (f QualityFlag) Has(flag QualityFlag) bool {
+	return f&flag != 0
+}
+
+// Corrupt сообщает, что запись явно повреждена: неверная контрольная сумма
+// или неправдоподобные элементы. Нулевой B* сам по себе повреждением не считается.
+func (f QualityFlag) Corrupt() bool {
+	return f&qualityCorrupt != 0
+}
+
+// QualityFlags проверяет TLE эвристиками качества: контрольные суммы строк,
+// правдоподобие элементов (эксцентриситет, наклонение, среднее движение, перигей
+// над поверхностью) и ненулевой B* для LEO. Для nil возвращает признаки повреждения.
+func (tle *TLE) QualityFlags() QualityFlag {
+	if tle == nil {
+		return qualityCorrupt
+	}
+
+	var flags QualityFlag
+
+	if !validateChecksum(tle.Line1) || !validateChecksum(tle.Line2) {
+		flags |= QualityBadChecksum
+	}
+
+	if tle.Eccentricity < 0 || tle.Eccentricity >= 1 ||
+		tle.Inclination < 0 || tle.Inclination > maxPlausibleInclination ||
+		tle.MeanMotion <= 0 || tle.MeanMotion > maxPlausibleMeanMotion ||
+		tle.Perigee() <= 0 {
+		flags |= QualityImplausibleElements
+	}
+
+	if tle.Bstar == 0 && tle.Regime() == RegimeLEO {
+		flags |= QualityZeroBstar
+	}
+
+	return flags
+}
+
+// BestTLE выбирает лучший TLE среди дубликатов одного спутника из разных источников
+// или эпох: явно повреждённые записи (см. QualityFlag.Corrupt) отбрасываются, если есть
+// другие; среди оставшихся побеждает самая свежая эпоха, при равных эпохах —
+// запись с меньшим числом признаков. Возвращает nil для пустого списка.
+func BestTLE(candidates []*TLE) *TLE {
+	var (
+		best      *TLE
+		bestFlags QualityFlag
+	)
+
+	for _, tle := range candidates {
+		if tle == nil {
+			continue
+		}
+
+		flags := tle.QualityFlags()
+
+		if best == nil || betterTLE(tle, flags, best, bestFlags) {
+			best, bestFlags = tle, flags
+		}
+	}
+
+	return best
+}
+
+// betterTLE сравнивает кандидата a с текущим лучшим b по правилам BestTLE.
+func betterTLE(a *TLE, aFlags QualityFlag, b *TLE, bFlags QualityFlag) bool {
+	if aFlags.Corrupt() != bFlags.Corrupt() {
+		return !aFlags.Corrupt()
+	}
+
+	if !a.Epoch.Equal(b.Epoch) {
+		return a.Epoch.After(b.Epoch)
+	}
+
+	return bits.OnesCount8(uint8(aFlags)) < bits.OnesCount8(uint8(bFlags))
+}
+
+// Модель роста ошибки положения TLE (эмпирическая, порядок величин по сравнениям SGP4
+// с точными эфемеридами): σ(Δt) = σ₀ + r·Δt + q·Δt², Δt в сутках от эпохи.
+const (
+	// uncertaintyAtEpochKm — ошибка положения в эпоху TLE.
+	uncertaintyAtEpochKm = 1.0
+
+	// uncertaintyDragFraction — доля ṅ/2, которую составляет ошибка её оценки;
+	// даёт квадратичный рост ошибки вдоль орбиты у тормозящихся спутников.
+	uncertaintyDragFraction = 0.1
+
+	// maxRefreshInterval — верхняя граница интервала обновления: даже стабильные
+	// орбиты обновляются не реже раза в месяц (манёвры в модели не учитываются).
+	maxRefreshInterval = 30 * 24 * time.Hour
+)
+
+// uncertaintyGrowthKmPerDay — линейный рост ошибки по классам орбит, км/сутки.
+var uncertaintyGrowthKmPerDay = map[OrbitRegime]float64{
+	RegimeUnknown: 3.0,
+	RegimeLEO:     2.0,
+	RegimeMEO:     1.0,
+	RegimeGEO:     0.5,
+	RegimeHEO:     3.0,
+}
+
+// uncertaintyCoefficients возвращает коэффициенты r (км/сутки) и q (км/сутки²) модели роста ошибки.
+// Квадратичный член — смещение вдоль орбиты 2π·a·δ(ṅ/2)·Δt² из-за ошибки оценки торможения.
+func (tle *TLE) uncertaintyCoefficients() (linear, quadratic float64) {
+	linear = uncertaintyGrowthKmPerDay[tle.Regime()]
+	quadratic = 2 * math.Pi * tle.SemiMajorAxis() * uncertaintyDragFraction * math.Abs(tle.MeanMotionDot)
+
+	return linear, quadratic
+}
+
+// PositionUncertaintyKm оценивает ошибку положения SGP4 по этому TLE в момент t, км.
+// Ошибка растёт в обе стороны от эпохи; модель грубая и служит для планирования обновлений.
+func (tle *TLE) PositionUncertaintyKm(t time.Time) float64 {
+	days := math.Abs(t.Sub(tle.Epoch).Hours()) / 24
+	linear, quadratic := tle.uncertaintyCoefficients()
+
+	return uncertaintyAtEpochKm + linear*days + quadratic*days*days
+}
+
+// RefreshIntervalFor возвращает, сколько времени после эпохи TLE ошибка положения
+// (см. PositionUncertaintyKm) остаётся в пределах maxErrorKm, — момент, к которому
+// следует загрузить новый TLE. Для быстро тормозящихся LEO это часы, для GEO — недели;
+// результат ограничен 30 сутками. Если бюджет не больше ошибки в эпоху, возвращает 0.
+func (tle *TLE) RefreshIntervalFor(maxErrorKm float64) time.Duration {
+	budget := maxErrorKm - uncertaintyAtEpochKm
+	if tle == nil || budget <= 0 {
+		return 0
+	}
+
+	linear, quadratic := tle.uncertaintyCoefficients()
+
+	// Положительный корень q·d² + r·d − budget = 0 (или линейный случай при q = 0).
+	var days float64
+	if quadratic > 0 {
+		days = (-linear + math.Sqrt(linear*linear+4*quadratic*budget)) / (2 * quadratic)
+	} else {
+		days = budget / linear
+	}
+
+	// Ограничение до перевода в Duration защищает от переполнения при огромном бюджете.
+	if days*24 >= maxRefreshInterval.Hours() {
+		return maxRefreshInterval
+	}
+
+	return time.Duration(days * 24 * float64(time.Hour))
+}
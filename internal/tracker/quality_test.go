@@ -0,0 +1,114 @@
+package tracker
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestTLE_QualityFlags проверяет эвристики качества TLE.
+func TestTLE_QualityFlags(t *testing.T) {
+	t.Parallel()
+
+	epoch := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	badChecksum := issTestTLE(t, epoch, nil)
+	badChecksum.Line2 = badChecksum.Line2[:68] + "X"
+
+	tests := []struct {
+		name string
+		tle  *TLE
+		want QualityFlag
+	}{
+		{name: "clean", tle: issTestTLE(t, epoch, nil), want: 0},
+		{name: "zero BSTAR in LEO", tle: issTestTLE(t, epoch, func(tle *TLE) { tle.Bstar = 0 }), want: QualityZeroBstar},
+		{name: "perigee below surface", tle: issTestTLE(t, epoch, func(tle *TLE) { tle.Eccentricity = 0.5 }), want: QualityImplausibleElements},
+		{name: "bad checksum", tle: badChecksum, want: QualityBadChecksum},
+		{name: "nil", tle: nil, want: QualityBadChecksum | QualityImplausibleElements},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.tle.QualityFlags(); got != tt.want {
+				t.Errorf("QualityFlags() = %03b, want %03b", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBestTLE проверяет, что свежая запись с незначительным признаком предпочтительнее
+// старой чистой, а явно повреждённая — нет.
+func TestBestTLE(t *testing.T) {
+	t.Parallel()
+
+	epoch := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	older := issTestTLE(t, epoch, nil)
+	newerZeroBstar := issTestTLE(t, epoch.Add(6*time.Hour), func(tle *TLE) { tle.Bstar = 0 })
+	newerCorrupt := issTestTLE(t, epoch.Add(12*time.Hour), func(tle *TLE) { tle.MeanMotion = 0 })
+	sameEpochFlagged := issTestTLE(t, epoch, func(tle *TLE) { tle.Bstar = 0 })
+
+	tests := []struct {
+		name       string
+		candidates []*TLE
+		want       *TLE
+	}{
+		{name: "newer with zero BSTAR wins", candidates: []*TLE{older, newerZeroBstar}, want: newerZeroBstar},
+		{name: "newer corrupt loses", candidates: []*TLE{newerCorrupt, older}, want: older},
+		{name: "same epoch prefers fewer flags", candidates: []*TLE{sameEpochFlagged, older}, want: older},
+		{name: "only corrupt", candidates: []*TLE{nil, newerCorrupt}, want: newerCorrupt},
+		{name: "empty", candidates: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := BestTLE(tt.candidates); got != tt.want {
+				t.Errorf("BestTLE() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTLE_RefreshIntervalFor проверяет, что при одинаковом бюджете ошибки
+// GEO без торможения обновляется гораздо реже низкого спутника с сильным торможением.
+func TestTLE_RefreshIntervalFor(t *testing.T) {
+	t.Parallel()
+
+	const budgetKm = 5.0
+
+	leo := issTestTLE(t, passTestStart, func(tle *TLE) {
+		tle.MeanMotionDot = 1e-3 // Сильное торможение перед сходом с орбиты.
+	})
+	geo := &TLE{Epoch: passTestStart, MeanMotion: 1.0027, Eccentricity: 0.0001, Inclination: 0.05}
+
+	leoInterval := leo.RefreshIntervalFor(budgetKm)
+	geoInterval := geo.RefreshIntervalFor(budgetKm)
+
+	if leoInterval <= 0 || leoInterval > 24*time.Hour {
+		t.Errorf("LEO interval = %v, want (0, 24h]", leoInterval)
+	}
+
+	if geoInterval < 10*leoInterval {
+		t.Errorf("GEO interval = %v, want at least 10× LEO %v", geoInterval, leoInterval)
+	}
+
+	// На границе интервала модель даёт ровно бюджет ошибки.
+	for name, tle := range map[string]*TLE{"LEO": leo, "GEO": geo} {
+		at := tle.Epoch.Add(tle.RefreshIntervalFor(budgetKm))
+		if got := tle.PositionUncertaintyKm(at); math.Abs(got-budgetKm) > 1e-3 {
+			t.Errorf("%s uncertainty at refresh = %.4f km, want %.1f", name, got, budgetKm)
+		}
+	}
+
+	if got := geo.RefreshIntervalFor(1e6); got != maxRefreshInterval {
+		t.Errorf("huge budget interval = %v, want cap %v", got, maxRefreshInterval)
+	}
+
+	if got := leo.RefreshIntervalFor(uncertaintyAtEpochKm); got != 0 {
+		t.Errorf("budget below epoch error interval = %v, want 0", got)
+	}
+}
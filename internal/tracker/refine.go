@@ -0,0 +1,45 @@
+package tracker
+
+import "time"
+
+// RefineOptions задаёт точность уточнения моментов событий (AOS/LOS, кульминация,
+// узлы, начало видимости, транзиты, сближения) бисекцией или тернарным поиском.
+// Итерации прекращаются, когда интервал не больше Tolerance
+// или выполнено MaxIterations итераций.
+type RefineOptions struct {
+	Tolerance     time.Duration // Требуемая ширина интервала.
+	MaxIterations int           // Защита от зацикливания.
+}
+
+// DefaultRefineOptions — параметры уточнения по умолчанию.
+var DefaultRefineOptions = RefineOptions{
+	Tolerance:     100 * time.Millisecond,
+	MaxIterations: 50,
+}
+
+// WithRefineOptions возвращает копию пропагатора с указанными параметрами уточнения.
+// Нулевые или отрицательные значения заменяются значениями по умолчанию.
+func (p *Propagator) WithRefineOptions(opts RefineOptions) *Propagator {
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = DefaultRefineOptions.Tolerance
+	}
+
+	if opts.MaxIterations <= 0 {
+		opts.MaxIterations = DefaultRefineOptions.MaxIterations
+	}
+
+	cp := *p
+	cp.refine = opts
+
+	return &cp
+}
+
+// RefineOptions возвращает параметры уточнения пропагатора.
+func (p *Propagator) RefineOptions() RefineOptions {
+	return p.refine
+}
+
+// converged сообщает, нужно ли прекратить уточнение интервала width на итерации iter.
+func (o RefineOptions) converged(width time.Duration, iter int) bool {
+	return absDuration(width) <= o.Tolerance || iter >= o.MaxIterations
+}
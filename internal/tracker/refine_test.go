@@ -0,0 +1,49 @@
+package tracker
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRefineOptions_AOSPrecision проверяет, что меньший допуск даёт AOS
+// ближе к истинному пересечению горизонта, чем грубый.
+func TestRefineOptions_AOSPrecision(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+	after := passTestStart.Add(30 * time.Minute)
+
+	aosWith := func(opts RefineOptions) time.Time {
+		pass, err := prop.WithRefineOptions(opts).NextPass(passTestMoscow, after, 0)
+		if err != nil {
+			t.Fatalf("NextPass(%+v) error = %v", opts, err)
+		}
+
+		return pass.AOS
+	}
+
+	// Эталон — очень малый допуск.
+	truth := aosWith(RefineOptions{Tolerance: time.Millisecond, MaxIterations: 100})
+	tight := aosWith(RefineOptions{Tolerance: 100 * time.Millisecond, MaxIterations: 50})
+	loose := aosWith(RefineOptions{Tolerance: 20 * time.Second, MaxIterations: 50})
+
+	tightErr, looseErr := absDuration(tight.Sub(truth)), absDuration(loose.Sub(truth))
+
+	if tightErr > 100*time.Millisecond {
+		t.Errorf("tight AOS error = %v, want <= 100ms", tightErr)
+	}
+
+	if tightErr >= looseErr {
+		t.Errorf("tight AOS error %v should be smaller than loose %v", tightErr, looseErr)
+	}
+
+	// Ограничение итераций прерывает уточнение даже при недостижимом допуске.
+	limited := prop.WithRefineOptions(RefineOptions{Tolerance: time.Nanosecond, MaxIterations: 3})
+	if _, err := limited.NextPass(passTestMoscow, after, 0); err != nil {
+		t.Errorf("NextPass(MaxIterations=3) error = %v", err)
+	}
+
+	if got := prop.WithRefineOptions(RefineOptions{}).RefineOptions(); got != DefaultRefineOptions {
+		t.Errorf("zero RefineOptions = %+v, want defaults %+v", got, DefaultRefineOptions)
+	}
+}
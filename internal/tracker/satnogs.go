@@ -0,0 +1,261 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Константы SatNOGS DB API.
+const (
+	// SatNOGSBaseURL базовый URL SatNOGS DB.
+	SatNOGSBaseURL = "https://db.satnogs.org"
+
+	// satnogsSatellitesPath путь списка спутников.
+	satnogsSatellitesPath = "/api/satellites/"
+
+	// satnogsTransmittersPath путь списка передатчиков.
+	satnogsTransmittersPath = "/api/transmitters/"
+
+	// satnogsTransmitterActive статус действующего передатчика.
+	satnogsTransmitterActive = "active"
+)
+
+// Ошибки SatNOGS клиента.
+var (
+	ErrSatNOGSNotFound         = errors.New("satellite not found in SatNOGS DB")
+	ErrSatNOGSUnexpectedStatus = errors.New("unexpected SatNOGS HTTP status")
+)
+
+// SatelliteMetadata — сведения о спутнике из SatNOGS DB.
+type SatelliteMetadata struct {
+	NoradID   int              `json:"norad_id"`
+	Name      string           `json:"name"`
+	AltNames  []string         `json:"alt_names,omitempty"`
+	Status    string           `json:"status"`         // alive, dead, re-entered, future.
+	Mode      string           `json:"mode,omitempty"` // Модуляция первого действующего нисходящего канала.
+	Uplinks   []FrequencyRange `json:"uplinks,omitempty"`
+	Downlinks []FrequencyRange `json:"downlinks,omitempty"`
+}
+
+// FrequencyRange — частота или полоса канала действующего передатчика.
+// Для одиночной частоты HighHz равна LowHz.
+type FrequencyRange struct {
+	LowHz       int64  `json:"low_hz"`
+	HighHz      int64  `json:"high_hz"`
+	Mode        string `json:"mode,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// satnogsSatellite — запись спутника в ответе SatNOGS DB.
+type satnogsSatellite struct {
+	NoradID *int   `json:"norad_cat_id"`
+	Name    string `json:"name"`
+	Names   string `json:"names"` // Альтернативные имена через запятую.
+	Status  string `json:"status"`
+}
+
+// satnogsTransmitter — запись передатчика в ответе SatNOGS DB.
+type satnogsTransmitter struct {
+	NoradID      *int   `json:"norad_cat_id"`
+	Description  string `json:"description"`
+	Status       string `json:"status"`
+	Mode         string `json:"mode"`
+	UplinkLow    *int64 `json:"uplink_low"`
+	UplinkHigh   *int64 `json:"uplink_high"`
+	DownlinkLow  *int64 `json:"downlink_low"`
+	DownlinkHigh *int64 `json:"downlink_high"`
+}
+
+// SatNOGSClient HTTP клиент SatNOGS DB: сведения о спутниках и частоты передатчиков.
+type SatNOGSClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// SatNOGSOption функция настройки клиента SatNOGS.
+type SatNOGSOption func(*SatNOGSClient)
+
+// WithSatNOGSHTTPClient устанавливает кастомный HTTP клиент.
+func WithSatNOGSHTTPClient(client *http.Client) SatNOGSOption {
+	return func(c *SatNOGSClient) {
+		c.httpClient = client
+	}
+}
+
+// WithSatNOGSBaseURL устанавливает базовый URL (для тестирования).
+func WithSatNOGSBaseURL(url string) SatNOGSOption {
+	return func(c *SatNOGSClient) {
+		c.baseURL = url
+	}
+}
+
+// NewSatNOGSClient создаёт новый клиент SatNOGS DB.
+func NewSatNOGSClient(opts ...SatNOGSOption) *SatNOGSClient {
+	c := &SatNOGSClient{
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		baseURL:    SatNOGSBaseURL,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// FetchByNoradID загружает сведения и действующие передатчики одного спутника.
+// Если спутника нет в SatNOGS DB, возвращается ErrSatNOGSNotFound.
+func (c *SatNOGSClient) FetchByNoradID(ctx context.Context, noradID int) (*SatelliteMetadata, error) {
+	id := strconv.Itoa(noradID)
+
+	var sats []satnogsSatellite
+	if err := c.getJSON(ctx, satnogsSatellitesPath, url.Values{"norad_cat_id": {id}}, &sats); err != nil {
+		return nil, fmt.Errorf("fetching SatNOGS satellite %d: %w", noradID, err)
+	}
+
+	var transmitters []satnogsTransmitter
+	if err := c.getJSON(ctx, satnogsTransmittersPath, url.Values{"satellite__norad_cat_id": {id}}, &transmitters); err != nil {
+		return nil, fmt.Errorf("fetching SatNOGS transmitters %d: %w", noradID, err)
+	}
+
+	meta, ok := buildSatelliteMetadata(sats, transmitters)[noradID]
+	if !ok {
+		return nil, fmt.Errorf("%w: NORAD ID %d", ErrSatNOGSNotFound, noradID)
+	}
+
+	return meta, nil
+}
+
+// FetchAll загружает сведения обо всех спутниках SatNOGS DB двумя запросами
+// (спутники и передатчики); результат индексирован по NORAD ID.
+func (c *SatNOGSClient) FetchAll(ctx context.Context) (map[int]*SatelliteMetadata, error) {
+	var sats []satnogsSatellite
+	if err := c.getJSON(ctx, satnogsSatellitesPath, nil, &sats); err != nil {
+		return nil, fmt.Errorf("fetching SatNOGS satellites: %w", err)
+	}
+
+	var transmitters []satnogsTransmitter
+	if err := c.getJSON(ctx, satnogsTransmittersPath, nil, &transmitters); err != nil {
+		return nil, fmt.Errorf("fetching SatNOGS transmitters: %w", err)
+	}
+
+	return buildSatelliteMetadata(sats, transmitters), nil
+}
+
+// getJSON выполняет GET запрос к SatNOGS DB и декодирует JSON ответ в out.
+func (c *SatNOGSClient) getJSON(ctx context.Context, path string, query url.Values, out any) error {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	query.Set("format", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "Satellite Scout/1.0 (https://github.com/art-injener/satellite-scout)")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %d", ErrSatNOGSUnexpectedStatus, resp.StatusCode)
+	}
+
+	body, err := readResponseBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decoding SatNOGS response: %w", err)
+	}
+
+	return nil
+}
+
+// buildSatelliteMetadata сопоставляет спутникам их действующие передатчики.
+// Записи без NORAD ID (ещё не каталогизированные объекты) пропускаются.
+func buildSatelliteMetadata(sats []satnogsSatellite, transmitters []satnogsTransmitter) map[int]*SatelliteMetadata {
+	result := make(map[int]*SatelliteMetadata, len(sats))
+
+	for _, sat := range sats {
+		if sat.NoradID == nil {
+			continue
+		}
+
+		result[*sat.NoradID] = &SatelliteMetadata{
+			NoradID:  *sat.NoradID,
+			Name:     sat.Name,
+			AltNames: splitSatNOGSNames(sat.Names),
+			Status:   sat.Status,
+		}
+	}
+
+	for _, tx := range transmitters {
+		if tx.NoradID == nil || tx.Status != satnogsTransmitterActive {
+			continue
+		}
+
+		meta, ok := result[*tx.NoradID]
+		if !ok {
+			continue
+		}
+
+		if r, ok := newFrequencyRange(tx.UplinkLow, tx.UplinkHigh, tx); ok {
+			meta.Uplinks = append(meta.Uplinks, r)
+		}
+
+		if r, ok := newFrequencyRange(tx.DownlinkLow, tx.DownlinkHigh, tx); ok {
+			meta.Downlinks = append(meta.Downlinks, r)
+
+			if meta.Mode == "" {
+				meta.Mode = tx.Mode
+			}
+		}
+	}
+
+	return result
+}
+
+// newFrequencyRange строит канал из нижней и (необязательной) верхней частоты передатчика.
+func newFrequencyRange(low, high *int64, tx satnogsTransmitter) (FrequencyRange, bool) {
+	if low == nil {
+		return FrequencyRange{}, false
+	}
+
+	r := FrequencyRange{LowHz: *low, HighHz: *low, Mode: tx.Mode, Description: tx.Description}
+	if high != nil {
+		r.HighHz = *high
+	}
+
+	return r, true
+}
+
+// splitSatNOGSNames разбирает список альтернативных имён через запятую.
+func splitSatNOGSNames(names string) []string {
+	var result []string
+
+	for name := range strings.SplitSeq(names, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
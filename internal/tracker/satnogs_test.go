@@ -0,0 +1,176 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// Ответы mock сервера SatNOGS DB: МКС с двумя передатчиками (один недействующий)
+// и объект без NORAD ID.
+const (
+	satnogsTestSatellites = `[
+		{"sat_id":"XSKZ-5603","norad_cat_id":25544,"name":"ISS","names":"ZARYA, RS0ISS","status":"alive"},
+		{"sat_id":"AAAA-0000","norad_cat_id":null,"name":"UNKNOWN","names":"","status":"future"}
+	]`
+	satnogsTestTransmitters = `[
+		{"uuid":"a","norad_cat_id":25544,"description":"Mode V/U FM","status":"active","mode":"FM",
+		 "uplink_low":145990000,"uplink_high":null,"downlink_low":437800000,"downlink_high":null},
+		{"uuid":"b","norad_cat_id":25544,"description":"APRS","status":"active","mode":"AFSK",
+		 "uplink_low":null,"uplink_high":null,"downlink_low":145825000,"downlink_high":null},
+		{"uuid":"c","norad_cat_id":25544,"description":"Old beacon","status":"inactive","mode":"CW",
+		 "uplink_low":null,"uplink_high":null,"downlink_low":145800000,"downlink_high":null}
+	]`
+)
+
+// newSatNOGSTestServer создаёт mock сервер SatNOGS DB. Фильтр по NORAD ID
+// поддерживается только для МКС; для остальных возвращаются пустые списки.
+func newSatNOGSTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if id := query.Get("norad_cat_id") + query.Get("satellite__norad_cat_id"); id != "" && id != "25544" {
+			_, _ = w.Write([]byte(`[]`))
+			return
+		}
+
+		switch r.URL.Path {
+		case satnogsSatellitesPath:
+			_, _ = w.Write([]byte(satnogsTestSatellites))
+		case satnogsTransmittersPath:
+			_, _ = w.Write([]byte(satnogsTestTransmitters))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// TestSatNOGSClient_FetchByNoradID проверяет разбор сведений и частот передатчиков.
+func TestSatNOGSClient_FetchByNoradID(t *testing.T) {
+	t.Parallel()
+
+	server := newSatNOGSTestServer(t)
+	client := NewSatNOGSClient(WithSatNOGSBaseURL(server.URL))
+
+	meta, err := client.FetchByNoradID(context.Background(), 25544)
+	if err != nil {
+		t.Fatalf("FetchByNoradID() error = %v", err)
+	}
+
+	if meta.Status != "alive" || meta.Mode != "FM" {
+		t.Errorf("Status, Mode = %q, %q, want alive, FM", meta.Status, meta.Mode)
+	}
+
+	if len(meta.AltNames) != 2 || meta.AltNames[1] != "RS0ISS" {
+		t.Errorf("AltNames = %q, want [ZARYA RS0ISS]", meta.AltNames)
+	}
+
+	if len(meta.Uplinks) != 1 || meta.Uplinks[0].LowHz != 145990000 || meta.Uplinks[0].HighHz != 145990000 {
+		t.Errorf("Uplinks = %+v, want single 145.990 MHz", meta.Uplinks)
+	}
+
+	// Недействующий маяк не попадает в список.
+	if len(meta.Downlinks) != 2 {
+		t.Errorf("Downlinks = %+v, want 2 active", meta.Downlinks)
+	}
+
+	if _, err := client.FetchByNoradID(context.Background(), 99999); !errors.Is(err, ErrSatNOGSNotFound) {
+		t.Errorf("FetchByNoradID(99999) error = %v, want ErrSatNOGSNotFound", err)
+	}
+}
+
+// TestTLEStore_Metadata проверяет загрузку метаданных при Start и спутники без записи в SatNOGS.
+func TestTLEStore_Metadata(t *testing.T) {
+	t.Parallel()
+
+	server := newSatNOGSTestServer(t)
+	source := &fakeTLESource{groups: map[SatelliteGroup][]*TLE{
+		GroupStations: {createTestTLE(), issVariant(t, "40001", "229.6000")},
+	}}
+
+	store := NewTLEStore(
+		WithTLESource(source),
+		WithAutoUpdate(false),
+		WithMetadata(true),
+		WithSatNOGSClient(NewSatNOGSClient(WithSatNOGSBaseURL(server.URL))),
+	)
+
+	if err := store.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	meta, ok := store.GetMetadata(25544)
+	if !ok {
+		t.Fatal("GetMetadata(25544) not found")
+	}
+
+	if meta.Name != "ISS" || len(meta.Downlinks) != 2 {
+		t.Errorf("GetMetadata(25544) = %+v", meta)
+	}
+
+	if _, ok := store.GetMetadata(40001); ok {
+		t.Error("GetMetadata(40001) found, satellite has no SatNOGS entry")
+	}
+
+	// Без WithMetadata сведения не загружаются.
+	plain := NewTLEStore(WithTLESource(source), WithAutoUpdate(false))
+	if err := plain.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if _, ok := plain.GetMetadata(25544); ok {
+		t.Error("GetMetadata() found without WithMetadata")
+	}
+}
+
+// TestTLEStore_FindByFrequency проверяет поиск по диапазону нисходящих частот и модуляции.
+func TestTLEStore_FindByFrequency(t *testing.T) {
+	t.Parallel()
+
+	store := NewTLEStore()
+	for _, id := range []string{"25544", "27607", "43017", "40001"} {
+		store.Add(issVariant(t, id, "229.6000"))
+	}
+
+	store.metadata = map[int]*SatelliteMetadata{
+		25544: {NoradID: 25544, Name: "ISS", Downlinks: []FrequencyRange{
+			{LowHz: 437800000, HighHz: 437800000, Mode: "FM"},
+			{LowHz: 145825000, HighHz: 145825000, Mode: "AFSK"},
+		}},
+		27607: {NoradID: 27607, Name: "SO-50", Downlinks: []FrequencyRange{{LowHz: 436795000, HighHz: 436795000, Mode: "FM"}}},
+		43017: {NoradID: 43017, Name: "AO-91", Downlinks: []FrequencyRange{{LowHz: 145960000, HighHz: 145960000, Mode: "FM"}}},
+		// Транспондер, полоса которого заходит в диапазон снизу.
+		40001: {NoradID: 40001, Name: "LINEAR", Downlinks: []FrequencyRange{{LowHz: 144950000, HighHz: 145050000, Mode: "SSB"}}},
+		// Метаданные спутника, которого нет в каталоге, игнорируются.
+		99999: {NoradID: 99999, Downlinks: []FrequencyRange{{LowHz: 145500000, HighHz: 145500000, Mode: "FM"}}},
+	}
+
+	tests := []struct {
+		name  string
+		modes []string
+		want  []int
+	}{
+		{name: "any mode sorted by frequency", want: []int{40001, 25544, 43017}},
+		{name: "fm only", modes: []string{"fm"}, want: []int{43017}},
+		{name: "fm or afsk", modes: []string{"FM", "AFSK"}, want: []int{25544, 43017}},
+		{name: "no matching mode", modes: []string{"BPSK"}, want: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := noradIDs(store.FindByFrequency(145e6, 146e6, tt.modes...))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindByFrequency(145–146 MHz, %v) = %v, want %v", tt.modes, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrCoverageGap возвращается, если кандидаты не покрывают окно целиком.
+var ErrCoverageGap = errors.New("candidate satellites leave a coverage gap")
+
+// visibilityInterval — интервал видимости спутника над целью.
+type visibilityInterval struct {
+	start, end time.Time
+}
+
+// SelectCoveringSatellites жадно выбирает минимальный набор спутников, чьи пролёты
+// над целью вместе непрерывно покрывают окно [start, end], и возвращает их NORAD ID
+// в порядке выбора. На каждом шаге покрытие сначала продлевается уже выбранными
+// спутниками, затем добавляется спутник, чей пролёт накрывает текущий момент
+// и заканчивается позже всех (жадный алгоритм покрытия отрезка).
+// Если окно покрыть нельзя, возвращает ErrCoverageGap с моментом разрыва.
+func SelectCoveringSatellites(candidates []*Propagator, target *Observer, start, end time.Time, minElDeg float64) ([]int, error) {
+	if target == nil {
+		return nil, ErrNilObserver
+	}
+
+	if !end.After(start) {
+		return nil, ErrInvalidWindow
+	}
+
+	intervals := make([][]visibilityInterval, len(candidates))
+
+	for i, p := range candidates {
+		if p == nil {
+			return nil, ErrNilPropagator
+		}
+
+		var err error
+
+		intervals[i], err = p.visibilityIntervals(target, start, end, minElDeg)
+		if err != nil {
+			return nil, fmt.Errorf("satellite %d: %w", p.tle.NoradID, err)
+		}
+	}
+
+	selected := make([]bool, len(candidates))
+
+	var ids []int
+
+	for cur := start; ; {
+		cur = extendCoverage(cur, intervals, selected)
+		if !cur.Before(end) {
+			return ids, nil
+		}
+
+		best, bestEnd := -1, cur
+
+		for i := range candidates {
+			if selected[i] {
+				continue
+			}
+
+			for _, iv := range intervals[i] {
+				if !iv.start.After(cur) && iv.end.After(bestEnd) {
+					best, bestEnd = i, iv.end
+				}
+			}
+		}
+
+		if best < 0 {
+			return ids, fmt.Errorf("%w: at %v", ErrCoverageGap, cur)
+		}
+
+		selected[best] = true
+		ids = append(ids, candidates[best].tle.NoradID)
+	}
+}
+
+// extendCoverage продлевает покрытие от cur интервалами выбранных спутников,
+// пока они непрерывно стыкуются, и возвращает конец покрытия.
+func extendCoverage(cur time.Time, intervals [][]visibilityInterval, selected []bool) time.Time {
+	for extended := true; extended; {
+		extended = false
+
+		for i, ivs := range intervals {
+			if !selected[i] {
+				continue
+			}
+
+			for _, iv := range ivs {
+				if !iv.start.After(cur) && iv.end.After(cur) {
+					cur = iv.end
+					extended = true
+				}
+			}
+		}
+	}
+
+	return cur
+}
+
+// visibilityIntervals возвращает интервалы видимости над наблюдателем, обрезанные
+// по окну [start, end], включая пролёт, уже идущий в момент start.
+// Для постоянно видимого спутника возвращает всё окно.
+func (p *Propagator) visibilityIntervals(obs *Observer, start, end time.Time, minElDeg float64) ([]visibilityInterval, error) {
+	var result []visibilityInterval
+
+	for t := start; t.Before(end); {
+		pass, err := p.findPass(obs, t, end, minElDeg)
+
+		switch {
+		case errors.Is(err, ErrAlwaysVisible):
+			return []visibilityInterval{{start: start, end: end}}, nil
+		case errors.Is(err, ErrNoPassFound):
+			return result, nil
+		case err != nil:
+			return nil, err
+		}
+
+		result = append(result, visibilityInterval{start: maxTime(pass.AOS, start), end: minTime(pass.LOS, end)})
+		t = pass.LOS.Add(passCoarseStep)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package tracker
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestSelectCoveringSatellites проверяет выбор минимального набора из трёх
+// спутников с перекрывающимися пролётами: средний спутник избыточен.
+func TestSelectCoveringSatellites(t *testing.T) {
+	t.Parallel()
+
+	// Варианты МКС, смещённые по орбите на ±10° (~2,5 мин), пролетают над Москвой
+	// раньше и позже оригинала; их пролёты перекрываются.
+	tles := []*TLE{
+		issVariant(t, "90001", "335.0288"),
+		issVariant(t, "90002", "325.0288"),
+		issVariant(t, "90003", "315.0288"),
+	}
+
+	props := make([]*Propagator, len(tles))
+
+	for i, tle := range tles {
+		prop, err := NewPropagator(tle)
+		if err != nil {
+			t.Fatalf("NewPropagator() error = %v", err)
+		}
+
+		props[i] = prop
+	}
+
+	first, err := props[0].NextPass(passTestMoscow, passTestStart.Add(30*time.Minute), 0)
+	if err != nil {
+		t.Fatalf("NextPass() error = %v", err)
+	}
+
+	last, err := props[2].NextPass(passTestMoscow, passTestStart.Add(30*time.Minute), 0)
+	if err != nil {
+		t.Fatalf("NextPass() error = %v", err)
+	}
+
+	if !last.AOS.Before(first.LOS) {
+		t.Fatalf("fixture passes do not overlap: %v-%v and %v-%v", first.AOS, first.LOS, last.AOS, last.LOS)
+	}
+
+	got, err := SelectCoveringSatellites(props, passTestMoscow, first.AOS, last.LOS, 0)
+	if err != nil {
+		t.Fatalf("SelectCoveringSatellites() error = %v", err)
+	}
+
+	if want := []int{90001, 90003}; !slices.Equal(got, want) {
+		t.Errorf("SelectCoveringSatellites() = %v, want %v", got, want)
+	}
+
+	// Окно, выходящее за последний пролёт, покрыть нельзя.
+	if _, err := SelectCoveringSatellites(props, passTestMoscow, first.AOS, last.LOS.Add(time.Hour), 0); !errors.Is(err, ErrCoverageGap) {
+		t.Errorf("SelectCoveringSatellites() beyond passes error = %v, want ErrCoverageGap", err)
+	}
+}
@@ -1,9 +1,13 @@
 package tracker
 
 import (
+	"container/list"
+	"context"
 	"errors"
 	"fmt"
 	"math"
+	"runtime"
+	"sync"
 	"time"
 
 	satellite "github.com/joshuaferrara/go-satellite"
@@ -15,6 +19,7 @@ var (
 	ErrPropagationFailed        = errors.New("SGP4 propagation failed")
 	ErrNilTLE                   = errors.New("TLE is nil")
 	ErrInvalidStep              = errors.New("step must be positive")
+	ErrUnsupportedGravity       = errors.New("unsupported gravity model")
 )
 
 // GravityModel определяет модель гравитации для SGP4.
@@ -25,6 +30,9 @@ const (
 	GravityWGS72 GravityModel = iota
 	// GravityWGS84 — модель WGS-84 (более точная).
 	GravityWGS84
+	// GravityWGS72Old — устаревший набор констант WGS-72 (как в исходном коде Spacetrack Report #3).
+	// Нужен для сравнения с историческими реализациями SGP4.
+	GravityWGS72Old
 )
 
 // ECIPosition представляет позицию и скорость спутника в системе ECI (TEME).
@@ -52,6 +60,7 @@ type Propagator struct {
 	tle       *TLE                // Исходный TLE (наш формат).
 	satellite satellite.Satellite // Внутренняя структура go-satellite.
 	gravity   GravityModel        // Модель гравитации.
+	refine    RefineOptions       // Параметры уточнения моментов событий.
 }
 
 // NewPropagator создаёт новый Propagator из TLE.
@@ -61,6 +70,8 @@ func NewPropagator(tle *TLE) (*Propagator, error) {
 }
 
 // NewPropagatorWithGravity создаёт Propagator с указанной моделью гравитации.
+// Для неизвестного значения GravityModel возвращает ErrUnsupportedGravity.
+// Инициализация SGP4 кэшируется по строкам TLE и модели гравитации (см. sgp4Cache).
 func NewPropagatorWithGravity(tle *TLE, gravity GravityModel) (*Propagator, error) {
 	if tle == nil {
 		return nil, ErrNilTLE
@@ -79,20 +90,120 @@ func NewPropagatorWithGravity(tle *TLE, gravity GravityModel) (*Propagator, erro
 		gravConst = satellite.GravityWGS72
 	case GravityWGS84:
 		gravConst = satellite.GravityWGS84
+	case GravityWGS72Old:
+		gravConst = satellite.GravityWGS72Old
 	default:
-		gravConst = satellite.GravityWGS84
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedGravity, gravity)
 	}
 
-	// Инициализируем спутник через go-satellite.
-	sat := satellite.TLEToSat(tle.Line1, tle.Line2, gravConst)
-
 	return &Propagator{
 		tle:       tle,
-		satellite: sat,
+		satellite: initSatellite(tle.Line1, tle.Line2, gravity, gravConst),
 		gravity:   gravity,
+		refine:    DefaultRefineOptions,
 	}, nil
 }
 
+// sgp4CacheMaxEntries — предельный размер кэша инициализированных спутников;
+// при переполнении вытесняется давно не использовавшийся спутник.
+const sgp4CacheMaxEntries = 16384
+
+// sgp4CacheKey идентифицирует результат инициализации SGP4: строки TLE и модель гравитации.
+type sgp4CacheKey struct {
+	line1, line2 string
+	gravity      GravityModel
+}
+
+// sgp4CacheEntry — элемент списка LRU: ключ нужен для удаления из карты при вытеснении.
+type sgp4CacheEntry struct {
+	key sgp4CacheKey
+	sat satellite.Satellite
+}
+
+// sgp4LRU — кэш инициализированных спутников с вытеснением по давности использования.
+// Не потокобезопасен: доступ защищается мьютексом владельца.
+type sgp4LRU struct {
+	capacity int
+	order    *list.List // Элементы *sgp4CacheEntry, в начале — последние использованные.
+	items    map[sgp4CacheKey]*list.Element
+}
+
+// newSGP4LRU создаёт пустой кэш на capacity элементов.
+func newSGP4LRU(capacity int) *sgp4LRU {
+	return &sgp4LRU{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[sgp4CacheKey]*list.Element),
+	}
+}
+
+// get возвращает спутник по ключу и отмечает его как последний использованный.
+func (c *sgp4LRU) get(key sgp4CacheKey) (satellite.Satellite, bool) {
+	elem, ok := c.items[key]
+	if !ok {
+		return satellite.Satellite{}, false
+	}
+
+	c.order.MoveToFront(elem)
+
+	return elem.Value.(*sgp4CacheEntry).sat, true
+}
+
+// put добавляет спутник, вытесняя давно не использовавшиеся при переполнении.
+func (c *sgp4LRU) put(key sgp4CacheKey, sat satellite.Satellite) {
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*sgp4CacheEntry).sat = sat
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	for c.order.Len() >= c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*sgp4CacheEntry).key)
+	}
+
+	c.items[key] = c.order.PushFront(&sgp4CacheEntry{key: key, sat: sat})
+}
+
+// len возвращает число спутников в кэше.
+func (c *sgp4LRU) len() int {
+	return c.order.Len()
+}
+
+// sgp4Cache хранит инициализированные структуры go-satellite, чтобы повторные
+// NewPropagator для того же TLE (например, при анимации трассы) не повторяли
+// дорогую инициализацию SGP4. Ключ включает сами строки, поэтому изменённый TLE
+// инициализируется заново.
+var sgp4Cache = struct {
+	sync.Mutex
+	lru *sgp4LRU
+}{lru: newSGP4LRU(sgp4CacheMaxEntries)}
+
+// initSatellite возвращает инициализированную структуру go-satellite из кэша
+// или создаёт её через satellite.TLEToSat.
+func initSatellite(line1, line2 string, gravity GravityModel, gravConst satellite.Gravity) satellite.Satellite {
+	key := sgp4CacheKey{line1: line1, line2: line2, gravity: gravity}
+
+	sgp4Cache.Lock()
+	sat, ok := sgp4Cache.lru.get(key)
+	sgp4Cache.Unlock()
+
+	if ok {
+		return sat
+	}
+
+	// Инициализация выполняется без блокировки: параллельные вызовы для разных TLE не ждут друг друга.
+	sat = satellite.TLEToSat(line1, line2, gravConst)
+
+	sgp4Cache.Lock()
+	sgp4Cache.lru.put(key, sat)
+	sgp4Cache.Unlock()
+
+	return sat
+}
+
 // Propagate рассчитывает положение спутника на указанное время.
 // Возвращает позицию и скорость в системе координат ECI (TEME).
 func (p *Propagator) Propagate(t time.Time) (*ECIPosition, error) {
@@ -100,9 +211,20 @@ func (p *Propagator) Propagate(t time.Time) (*ECIPosition, error) {
 		return nil, ErrNilTLE
 	}
 
-	// Извлекаем компоненты времени.
-	year, month, day := t.Date()
-	hour, minute, sec := t.Clock()
+	pos := &ECIPosition{}
+	if err := p.propagateInto(t, pos); err != nil {
+		return nil, err
+	}
+
+	return pos, nil
+}
+
+// propagateInto рассчитывает положение на время t и записывает его в pos без выделения памяти.
+func (p *Propagator) propagateInto(t time.Time, pos *ECIPosition) error {
+	// Извлекаем компоненты времени в UTC: SGP4 ожидает UTC, а t может быть в местной зоне.
+	utc := t.UTC()
+	year, month, day := utc.Date()
+	hour, minute, sec := utc.Clock()
 
 	// Вызываем SGP4 пропагатор.
 	position, velocity := satellite.Propagate(
@@ -113,10 +235,10 @@ func (p *Propagator) Propagate(t time.Time) (*ECIPosition, error) {
 
 	// Проверяем результат на NaN (признак ошибки пропагации).
 	if isNaN(position.X) || isNaN(position.Y) || isNaN(position.Z) {
-		return nil, fmt.Errorf("%w: position contains NaN (possible orbital decay or invalid TLE)", ErrPropagationFailed)
+		return fmt.Errorf("%w: position contains NaN (possible orbital decay or invalid TLE)", ErrPropagationFailed)
 	}
 
-	return &ECIPosition{
+	*pos = ECIPosition{
 		X:    position.X,
 		Y:    position.Y,
 		Z:    position.Z,
@@ -124,7 +246,9 @@ func (p *Propagator) Propagate(t time.Time) (*ECIPosition, error) {
 		Vy:   velocity.Y,
 		Vz:   velocity.Z,
 		Time: t,
-	}, nil
+	}
+
+	return nil
 }
 
 // PropagateRange рассчитывает положения спутника на интервале времени.
@@ -157,6 +281,196 @@ func (p *Propagator) PropagateRange(start, end time.Time, step time.Duration) ([
 	return positions, nil
 }
 
+// PropagateRangeInto работает как PropagateRange, но записывает результат в dst[:0],
+// повторно используя его базовый массив и уже выделенные элементы. Если ёмкости dst
+// не хватает, слайс расширяется через append. Предназначен для живого сопровождения,
+// где буфер переиспользуется между тактами для снижения нагрузки на GC.
+// Возвращённые позиции действительны до следующего вызова с тем же буфером.
+func (p *Propagator) PropagateRangeInto(start, end time.Time, step time.Duration, dst []*ECIPosition) ([]*ECIPosition, error) {
+	if p == nil {
+		return dst[:0], ErrNilTLE
+	}
+
+	if step <= 0 {
+		return dst[:0], fmt.Errorf("%w: %v", ErrInvalidStep, step)
+	}
+
+	if end.Before(start) {
+		start, end = end, start
+	}
+
+	positions := dst[:0]
+
+	for t := start; !t.After(end); t = t.Add(step) {
+		n := len(positions)
+
+		if n < cap(positions) {
+			positions = positions[:n+1]
+			if positions[n] == nil {
+				positions[n] = &ECIPosition{}
+			}
+		} else {
+			positions = append(positions, &ECIPosition{})
+		}
+
+		if err := p.propagateInto(t, positions[n]); err != nil {
+			return positions[:n], fmt.Errorf("propagation at %v: %w", t, err)
+		}
+	}
+
+	return positions, nil
+}
+
+// PropagateAll рассчитывает положения спутников tles на момент t, распределяя работу
+// между workers горутинами (при workers < 1 — по числу GOMAXPROCS). Результаты
+// и ошибки возвращаются в порядке входного слайса: для спутника, который не удалось
+// пропагировать (например, сошедшего с орбиты), в соответствующей позиции
+// positions будет nil, а в errs — ошибка; остальные спутники рассчитываются.
+func PropagateAll(tles []*TLE, t time.Time, workers int) (positions []*ECIPosition, errs []error) {
+	positions = make([]*ECIPosition, len(tles))
+	errs = make([]error, len(tles))
+
+	// Инициализация SGP4 дороже шага пропагации, поэтому она тоже выполняется в обработчиках.
+	parallelRange(len(tles), workers, func(i int) {
+		prop, err := NewPropagator(tles[i])
+		if err != nil {
+			errs[i] = err
+			return
+		}
+
+		positions[i], errs[i] = prop.propagateBatch(t)
+	})
+
+	return positions, errs
+}
+
+// PropagateEach работает как PropagateAll, но принимает уже созданные пропагаторы.
+// Инициализация SGP4 в NewPropagator заметно дороже одного шага пропагации, поэтому
+// при периодическом пересчёте каталога пропагаторы стоит создать один раз и переиспользовать.
+// Для nil-пропагатора в соответствующей позиции errs возвращается ErrNilTLE.
+func PropagateEach(props []*Propagator, t time.Time, workers int) (positions []*ECIPosition, errs []error) {
+	positions = make([]*ECIPosition, len(props))
+	errs = make([]error, len(props))
+
+	parallelRange(len(props), workers, func(i int) {
+		if props[i] == nil {
+			errs[i] = ErrNilTLE
+			return
+		}
+
+		positions[i], errs[i] = props[i].propagateBatch(t)
+	})
+
+	return positions, errs
+}
+
+// propagateBatch рассчитывает положение для пакетной пропагации, дополняя ошибку номером NORAD.
+func (p *Propagator) propagateBatch(t time.Time) (*ECIPosition, error) {
+	pos, err := p.Propagate(t)
+	if err != nil {
+		return nil, fmt.Errorf("NORAD %d: %w", p.tle.NoradID, err)
+	}
+
+	return pos, nil
+}
+
+// parallelRange вызывает fn для индексов [0, n) в workers горутинах (при workers < 1 —
+// по числу GOMAXPROCS). Каждый обработчик получает непрерывный диапазон индексов,
+// поэтому fn может писать в свою позицию результирующих слайсов без блокировок.
+func parallelRange(n, workers int, fn func(i int)) {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	chunk := (n + workers - 1) / workers
+
+	var wg sync.WaitGroup
+
+	for from := 0; from < n; from += chunk {
+		to := min(from+chunk, n)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := from; i < to; i++ {
+				fn(i)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// propagatePrecise рассчитывает положение с точностью до долей секунды.
+// SGP4 в go-satellite принимает целые секунды, поэтому дробная часть
+// учитывается линейной экстраполяцией по скорости (ошибка порядка метра).
+func (p *Propagator) propagatePrecise(t time.Time) (*ECIPosition, error) {
+	whole := t.Truncate(time.Second)
+
+	pos, err := p.Propagate(whole)
+	if err != nil {
+		return nil, err
+	}
+
+	dt := t.Sub(whole).Seconds()
+
+	pos.X += pos.Vx * dt
+	pos.Y += pos.Vy * dt
+	pos.Z += pos.Vz * dt
+	pos.Time = t
+
+	return pos, nil
+}
+
+// Stream выдаёт положения спутника в реальном времени с периодом interval,
+// пока не отменён ctx, после чего канал закрывается. Положение рассчитывается
+// на текущий момент каждого тика. Если потребитель не успевает забрать предыдущее
+// положение, оно заменяется новым, чтобы не задерживать тикер. При ошибке расчёта
+// (например, спутник сошёл с орбиты) поток завершается.
+func (p *Propagator) Stream(ctx context.Context, interval time.Duration) (<-chan *ECIPosition, error) {
+	if p == nil {
+		return nil, ErrNilPropagator
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStep, interval)
+	}
+
+	out := make(chan *ECIPosition, 1)
+
+	go func() {
+		defer close(out)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				pos, err := p.propagatePrecise(now.UTC())
+				if err != nil {
+					return
+				}
+
+				// Медленный потребитель получает самое свежее положение:
+				// устаревшее значение в буфере заменяется новым.
+				select {
+				case <-out:
+				default:
+				}
+
+				out <- pos
+			}
+		}
+	}()
+
+	return out, nil
+}
+
 // TLE возвращает исходный TLE.
 func (p *Propagator) TLE() *TLE {
 	if p == nil {
@@ -175,19 +489,19 @@ func (p *Propagator) GravityModel() GravityModel {
 	return p.gravity
 }
 
-// GMST рассчитывает Greenwich Mean Sidereal Time для указанного времени.
-// Используется для преобразования ECI -> ECEF.
+// GMST рассчитывает Greenwich Mean Sidereal Time для указанного времени
+// (в любом часовом поясе). Используется для преобразования ECI -> ECEF.
 func GMST(t time.Time) float64 {
-	year, month, day := t.Date()
-	hour, minute, sec := t.Clock()
+	year, month, day := t.UTC().Date()
+	hour, minute, sec := t.UTC().Clock()
 
 	return satellite.GSTimeFromDate(year, int(month), day, hour, minute, sec)
 }
 
 // JulianDay рассчитывает юлианскую дату для указанного времени.
 func JulianDay(t time.Time) float64 {
-	year, month, day := t.Date()
-	hour, minute, sec := t.Clock()
+	year, month, day := t.UTC().Date()
+	hour, minute, sec := t.UTC().Clock()
 
 	return satellite.JDay(year, int(month), day, hour, minute, sec)
 }
@@ -223,3 +537,31 @@ func (pos *ECIPosition) Altitude() float64 {
 func (pos *ECIPosition) Speed() float64 {
 	return math.Sqrt(pos.Vx*pos.Vx + pos.Vy*pos.Vy + pos.Vz*pos.Vz)
 }
+
+// RadialVelocity возвращает радиальную составляющую скорости (r·v)/|r| в км/с.
+// Положительное значение — спутник удаляется от Земли (движется к апогею),
+// отрицательное — приближается (движется к перигею). В апсидах близка к нулю.
+func (pos *ECIPosition) RadialVelocity() float64 {
+	r := pos.Magnitude()
+	if r == 0 {
+		return 0
+	}
+
+	return (pos.X*pos.Vx + pos.Y*pos.Vy + pos.Z*pos.Vz) / r
+}
+
+// RSWOffset раскладывает разность положений candidate − reference (км) по осям
+// орбитальной системы RSW опорного состояния: radial — вдоль радиус-вектора,
+// cross — вдоль нормали к плоскости орбиты r×v, along — вдоль трассы (W×R,
+// в сторону движения). Так аналитики сравнивают два решения для одного спутника,
+// например TLE до и после обновления. Требует скорости опорного состояния.
+func RSWOffset(reference, candidate *ECIPosition) (along, cross, radial float64) {
+	r := eciVec(reference)
+	rHat := r.unit()
+	wHat := r.cross(eciVelocity(reference)).unit()
+	sHat := wHat.cross(rHat)
+
+	d := eciVec(candidate).sub(r)
+
+	return d.dot(sHat), d.dot(wHat), d.dot(rHat)
+}
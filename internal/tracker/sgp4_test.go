@@ -1,9 +1,15 @@
 package tracker
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math"
+	"strconv"
 	"testing"
 	"time"
+
+	satellite "github.com/joshuaferrara/go-satellite"
 )
 
 // Эталонный TLE для ISS (ZARYA) — для SGP4 тестов.
@@ -204,6 +210,60 @@ func TestPropagateRange(t *testing.T) {
 	}
 }
 
+// TestPropagateRangeInto проверяет совпадение с PropagateRange и переиспользование буфера.
+func TestPropagateRangeInto(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(1 * time.Hour)
+	step := 10 * time.Minute
+
+	want, err := prop.PropagateRange(start, end, step)
+	if err != nil {
+		t.Fatalf("PropagateRange() error = %v", err)
+	}
+
+	// Буфер меньше результата — часть элементов переиспользуется, остальные добавляются.
+	buf := make([]*ECIPosition, 3, 4)
+	reused := &ECIPosition{}
+	buf[0] = reused
+
+	got, err := prop.PropagateRangeInto(start, end, step, buf)
+	if err != nil {
+		t.Fatalf("PropagateRangeInto() error = %v", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("PropagateRangeInto() returned %d positions, want %d", len(got), len(want))
+	}
+
+	for i := range want {
+		if *got[i] != *want[i] {
+			t.Errorf("position[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if got[0] != reused {
+		t.Error("PropagateRangeInto() should reuse existing elements of dst")
+	}
+
+	// Повторный вызов с достаточной ёмкостью не перевыделяет массив.
+	again, err := prop.PropagateRangeInto(start, start.Add(step), step, got)
+	if err != nil {
+		t.Fatalf("PropagateRangeInto() error = %v", err)
+	}
+
+	if len(again) != 2 || &again[0] != &got[0] {
+		t.Errorf("PropagateRangeInto() should reuse the backing array, got len %d", len(again))
+	}
+
+	if _, err := prop.PropagateRangeInto(start, end, 0, nil); !errors.Is(err, ErrInvalidStep) {
+		t.Errorf("PropagateRangeInto() with zero step error = %v, want ErrInvalidStep", err)
+	}
+}
+
 // TestPropagateRangeInvalidStep проверяет обработку некорректного шага.
 func TestPropagateRangeInvalidStep(t *testing.T) {
 	t.Parallel()
@@ -271,6 +331,29 @@ func TestGravityModels(t *testing.T) {
 	t.Logf("WGS84: X=%.3f, Y=%.3f, Z=%.3f", pos84.X, pos84.Y, pos84.Z)
 }
 
+// TestGravityModelValidation проверяет обработку неизвестной модели гравитации.
+func TestGravityModelValidation(t *testing.T) {
+	t.Parallel()
+
+	tle := createTestTLE()
+
+	prop, err := NewPropagatorWithGravity(tle, GravityWGS72Old)
+	if err != nil {
+		t.Fatalf("NewPropagatorWithGravity(WGS72Old) error = %v", err)
+	}
+
+	if prop.GravityModel() != GravityWGS72Old {
+		t.Errorf("GravityModel() = %d, want %d", prop.GravityModel(), GravityWGS72Old)
+	}
+
+	for _, model := range []GravityModel{GravityModel(-1), GravityModel(42)} {
+		_, err := NewPropagatorWithGravity(tle, model)
+		if !errors.Is(err, ErrUnsupportedGravity) {
+			t.Errorf("NewPropagatorWithGravity(%d) error = %v, want ErrUnsupportedGravity", model, err)
+		}
+	}
+}
+
 // TestGMST проверяет расчёт GMST.
 func TestGMST(t *testing.T) {
 	t.Parallel()
@@ -369,6 +452,175 @@ func TestECIPositionMethods(t *testing.T) {
 	t.Logf("ECIPosition.String(): %s", str)
 }
 
+// TestECIPosition_RadialVelocity проверяет радиальную скорость в апсидах и между ними.
+func TestECIPosition_RadialVelocity(t *testing.T) {
+	t.Parallel()
+
+	prop, err := NewPropagator(&TLE{Line1: molniyaLine1, Line2: molniyaLine2})
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Один виток Molniya (~12 часов) с шагом 1 секунда.
+	positions, err := prop.PropagateRange(start, start.Add(12*time.Hour), time.Second)
+	if err != nil {
+		t.Fatalf("PropagateRange() error = %v", err)
+	}
+
+	perigee, apogee := positions[0], positions[0]
+	for _, pos := range positions {
+		if pos.Magnitude() < perigee.Magnitude() {
+			perigee = pos
+		}
+
+		if pos.Magnitude() > apogee.Magnitude() {
+			apogee = pos
+		}
+	}
+
+	const apsisTolerance = 0.02 // км/с.
+
+	if vr := perigee.RadialVelocity(); math.Abs(vr) > apsisTolerance {
+		t.Errorf("RadialVelocity at perigee = %.4f km/s, want ~0", vr)
+	}
+
+	if vr := apogee.RadialVelocity(); math.Abs(vr) > apsisTolerance {
+		t.Errorf("RadialVelocity at apogee = %.4f km/s, want ~0", vr)
+	}
+
+	// Между перигеем и апогеем радиальная скорость заметно отлична от нуля.
+	middle := positions[len(positions)/4]
+	if vr := middle.RadialVelocity(); math.Abs(vr) < 0.5 {
+		t.Errorf("RadialVelocity between apsides = %.4f km/s, want |vr| > 0.5", vr)
+	}
+}
+
+// TestRSWOffset проверяет разложение разности положений по осям RSW: сдвиг вдоль
+// трассы даёт только along-составляющую, смещения по нормали и радиусу — свои оси.
+func TestRSWOffset(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+
+	ref, err := prop.Propagate(passTestStart)
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	// Тот же спутник секундой позже — смещение ~7.7 км вдоль трассы.
+	later, err := prop.Propagate(passTestStart.Add(time.Second))
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	along, cross, radial := RSWOffset(ref, later)
+	if !almostEqual(along, ref.Speed(), 0.05) {
+		t.Errorf("along = %.4f km, want ~%.4f (speed × 1 s)", along, ref.Speed())
+	}
+
+	if math.Abs(cross) > 0.01 || math.Abs(radial) > 0.05 {
+		t.Errorf("cross, radial = %.4f, %.4f km, want near zero", cross, radial)
+	}
+
+	// Смещения вдоль нормали к орбите и радиус-вектора.
+	r := eciVec(ref)
+	normal := r.cross(eciVelocity(ref)).unit()
+	shifted := r.add(normal.scale(2)).add(r.unit().scale(-3))
+	candidate := &ECIPosition{X: shifted.X, Y: shifted.Y, Z: shifted.Z}
+
+	along, cross, radial = RSWOffset(ref, candidate)
+	if !almostEqual(along, 0, 1e-9) || !almostEqual(cross, 2, 1e-9) || !almostEqual(radial, -3, 1e-9) {
+		t.Errorf("RSWOffset() = %.6f, %.6f, %.6f, want 0, 2, -3", along, cross, radial)
+	}
+}
+
+// TestSGP4LRU проверяет, что при переполнении вытесняется давно не использовавшийся
+// спутник, а остальные остаются в кэше.
+func TestSGP4LRU(t *testing.T) {
+	t.Parallel()
+
+	key := func(n int) sgp4CacheKey {
+		return sgp4CacheKey{line1: strconv.Itoa(n), gravity: GravityWGS84}
+	}
+
+	cache := newSGP4LRU(3)
+	for n := range 3 {
+		cache.put(key(n), satellite.Satellite{Line1: strconv.Itoa(n)})
+	}
+
+	// Обращение к 0 делает самым старым 1.
+	if sat, ok := cache.get(key(0)); !ok || sat.Line1 != "0" {
+		t.Fatalf("get(0) = %q, %v, want \"0\", true", sat.Line1, ok)
+	}
+
+	cache.put(key(3), satellite.Satellite{Line1: "3"})
+
+	if _, ok := cache.get(key(1)); ok {
+		t.Error("get(1) found, want evicted as least recently used")
+	}
+
+	for _, n := range []int{0, 2, 3} {
+		if _, ok := cache.get(key(n)); !ok {
+			t.Errorf("get(%d) not found after eviction of 1", n)
+		}
+	}
+
+	if got := cache.len(); got != 3 {
+		t.Errorf("len() = %d, want 3", got)
+	}
+
+	// Повторный put обновляет значение без вытеснения.
+	cache.put(key(2), satellite.Satellite{Line1: "2b"})
+
+	if sat, _ := cache.get(key(2)); sat.Line1 != "2b" || cache.len() != 3 {
+		t.Errorf("after re-put get(2) = %q, len() = %d, want \"2b\", 3", sat.Line1, cache.len())
+	}
+}
+
+// TestNewPropagator_Cache проверяет, что кэш инициализации SGP4 различает
+// модели гравитации и изменённые строки TLE.
+func TestNewPropagator_Cache(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	positionAt := func(tle *TLE, gravity GravityModel) ECIPosition {
+		t.Helper()
+
+		prop, err := NewPropagatorWithGravity(tle, gravity)
+		if err != nil {
+			t.Fatalf("NewPropagatorWithGravity() error = %v", err)
+		}
+
+		pos, err := prop.Propagate(at)
+		if err != nil {
+			t.Fatalf("Propagate() error = %v", err)
+		}
+
+		return *pos
+	}
+
+	tle := createTestTLE()
+
+	first := positionAt(tle, GravityWGS84)
+	if again := positionAt(tle, GravityWGS84); again != first {
+		t.Errorf("repeated NewPropagator position = %v, want %v", again, first)
+	}
+
+	if wgs72 := positionAt(tle, GravityWGS72); wgs72 == first {
+		t.Error("WGS72 position equals WGS84: cache must be per gravity model")
+	}
+
+	moved := *tle
+	moved.Line2 = issVariant(t, "25544", "335.0288").Line2
+
+	if pos := positionAt(&moved, GravityWGS84); pos == first {
+		t.Error("position unchanged after Line2 change: cache must be keyed by TLE lines")
+	}
+}
+
 // BenchmarkPropagate измеряет производительность пропагации.
 func BenchmarkPropagate(b *testing.B) {
 	tle := createTestTLE()
@@ -393,20 +645,66 @@ func BenchmarkPropagate(b *testing.B) {
 	}
 }
 
-// BenchmarkNewPropagator измеряет производительность создания Propagator.
-func BenchmarkNewPropagator(b *testing.B) {
-	tle := createTestTLE()
+// BenchmarkPropagateRange измеряет пропагацию витка с выделением нового слайса.
+func BenchmarkPropagateRange(b *testing.B) {
+	prop, err := NewPropagator(createTestTLE())
+	if err != nil {
+		b.Fatalf("NewPropagator() error = %v", err)
+	}
 
-	b.ResetTimer()
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+
+	b.ReportAllocs()
 
 	for b.Loop() {
-		_, err := NewPropagator(tle)
-		if err != nil {
-			b.Fatalf("NewPropagator() error = %v", err)
+		if _, err := prop.PropagateRange(start, end, 10*time.Second); err != nil {
+			b.Fatalf("PropagateRange() error = %v", err)
 		}
 	}
 }
 
+// BenchmarkPropagateRangeInto измеряет пропагацию с переиспользуемым буфером.
+func BenchmarkPropagateRangeInto(b *testing.B) {
+	prop, err := NewPropagator(createTestTLE())
+	if err != nil {
+		b.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+
+	var buf []*ECIPosition
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if buf, err = prop.PropagateRangeInto(start, end, 10*time.Second, buf); err != nil {
+			b.Fatalf("PropagateRangeInto() error = %v", err)
+		}
+	}
+}
+
+// BenchmarkNewPropagator сравнивает создание пропагатора для того же TLE
+// (инициализация SGP4 из кэша) с прямой инициализацией go-satellite.
+func BenchmarkNewPropagator(b *testing.B) {
+	tle := createTestTLE()
+
+	b.Run("cached", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := NewPropagator(tle); err != nil {
+				b.Fatalf("NewPropagator() error = %v", err)
+			}
+		}
+	})
+
+	b.Run("TLEToSat", func(b *testing.B) {
+		for b.Loop() {
+			satellite.TLEToSat(tle.Line1, tle.Line2, satellite.GravityWGS84)
+		}
+	})
+}
+
 // --- Вспомогательные функции ---
 
 // createTestTLE создаёт TLE для тестов.
@@ -419,6 +717,65 @@ func createTestTLE() *TLE {
 	}
 }
 
+// issSolsticeEpoch — эпоха летнего солнцестояния, на которую тесты видимости
+// переносят элементы МКС: короткие ночи и освещённые вечерние пролёты в средних широтах.
+var issSolsticeEpoch = time.Date(2024, 6, 20, 12, 0, 0, 0, time.UTC)
+
+// issTestTLE возвращает копию TLE ISS с эпохой epoch (нулевая — эпоха issLine1),
+// изменённую modify (может быть nil), и согласованными строками.
+func issTestTLE(t *testing.T, epoch time.Time, modify func(*TLE)) *TLE {
+	t.Helper()
+
+	tle, err := ParseTLE([]string{"ISS (ZARYA)", issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	if !epoch.IsZero() {
+		tle.Epoch = epoch
+	}
+
+	if modify != nil {
+		modify(tle)
+	}
+
+	if tle.Line1, tle.Line2, err = tle.ToLines(); err != nil {
+		t.Fatalf("ToLines() error = %v", err)
+	}
+
+	return tle
+}
+
+// issTestPropagator создаёт Propagator для issTestTLE.
+func issTestPropagator(t *testing.T, epoch time.Time, modify func(*TLE)) *Propagator {
+	t.Helper()
+
+	prop, err := NewPropagator(issTestTLE(t, epoch, modify))
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	return prop
+}
+
+// createGEOTestPropagator создаёт Propagator геостационарного спутника над 0° долготы
+// на эпоху 2024-01-01 12:00 UTC (элементы МКС, заменённые на геостационарные).
+func createGEOTestPropagator(t *testing.T) *Propagator {
+	t.Helper()
+
+	return issTestPropagator(t, time.Time{}, func(tle *TLE) {
+		tle.Name = "GEO TEST"
+		tle.MeanMotion = 1.00273791
+		tle.Eccentricity = 0.0001
+		tle.Inclination = 0.05
+		tle.MeanMotionDot, tle.Bstar = 0, 0
+
+		// Средняя долгота λ = RAAN + ω + M − GMST; подбираем M так, чтобы спутник стоял над 0°.
+		tle.RAAN, tle.ArgOfPerigee = 0, 0
+		tle.MeanAnomaly = normalizeDegrees(GMST(tle.Epoch) * Rad2Deg)
+	})
+}
+
 // createTestPropagator создаёт Propagator для тестов.
 func createTestPropagator(t *testing.T) *Propagator {
 	t.Helper()
@@ -477,3 +834,241 @@ func checkPositionsInRange(t *testing.T, positions []*ECIPosition) {
 		}
 	}
 }
+
+// batchTestTLEs возвращает n копий TLE ISS, равномерно разнесённых по средней аномалии.
+func batchTestTLEs(tb testing.TB, n int) []*TLE {
+	tb.Helper()
+
+	tles := make([]*TLE, n)
+
+	for i := range tles {
+		norad := fmt.Sprintf("%05d", 10000+i)
+		meanAnomaly := fmt.Sprintf("%8.4f", float64(i)*360/float64(n))
+
+		line1 := makeTLELine("1 " + norad + "U 98067A   24001.50000000  .00016717  00000-0  10270-3 0  999")
+		line2 := makeTLELine("2 " + norad + "  51.6400 247.4627 0006703 130.5360 " + meanAnomaly + " 15.4981557142340")
+
+		tle, err := ParseTLE([]string{line1, line2})
+		if err != nil {
+			tb.Fatalf("ParseTLE() error = %v", err)
+		}
+
+		tles[i] = tle
+	}
+
+	return tles
+}
+
+// TestPropagateAll проверяет порядок результатов пакетной пропагации
+// и изоляцию ошибок отдельных спутников.
+func TestPropagateAll(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tles := batchTestTLEs(t, 20)
+	tles[3] = nil
+	tles[7] = &TLE{NoradID: 99999} // Без строк Line1/Line2.
+
+	for _, workers := range []int{0, 1, 4, 64} {
+		positions, errs := PropagateAll(tles, at, workers)
+
+		if len(positions) != len(tles) || len(errs) != len(tles) {
+			t.Fatalf("workers=%d: got %d positions, %d errors, want %d", workers, len(positions), len(errs), len(tles))
+		}
+
+		if !errors.Is(errs[3], ErrNilTLE) || positions[3] != nil {
+			t.Errorf("workers=%d: slot 3 = %v, %v, want nil, ErrNilTLE", workers, positions[3], errs[3])
+		}
+
+		if !errors.Is(errs[7], ErrInvalidTLEForPropagation) || positions[7] != nil {
+			t.Errorf("workers=%d: slot 7 = %v, %v, want nil, ErrInvalidTLEForPropagation", workers, positions[7], errs[7])
+		}
+
+		for i, tle := range tles {
+			if i == 3 || i == 7 {
+				continue
+			}
+
+			if errs[i] != nil {
+				t.Fatalf("workers=%d: slot %d error = %v", workers, i, errs[i])
+			}
+
+			prop, err := NewPropagator(tle)
+			if err != nil {
+				t.Fatalf("NewPropagator() error = %v", err)
+			}
+
+			want, err := prop.Propagate(at)
+			if err != nil {
+				t.Fatalf("Propagate() error = %v", err)
+			}
+
+			if *positions[i] != *want {
+				t.Errorf("workers=%d: slot %d = %v, want %v", workers, i, positions[i], want)
+			}
+		}
+	}
+}
+
+// BenchmarkPropagateAll измеряет пакетную пропагацию каталога из 1000 спутников
+// вместе с инициализацией SGP4 (кэш инициализации очищается перед каждой итерацией)
+// на одном и на 8 обработчиках.
+func BenchmarkPropagateAll(b *testing.B) {
+	tles := batchTestTLEs(b, 1000)
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, workers := range []int{1, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for b.Loop() {
+				b.StopTimer()
+				resetSGP4Cache()
+				b.StartTimer()
+
+				if _, errs := PropagateAll(tles, at, workers); errs[0] != nil {
+					b.Fatalf("PropagateAll() error = %v", errs[0])
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkPropagateEach сравнивает последовательную пропагацию каталога
+// из 1000 спутников с пакетной на 8 обработчиках (пропагаторы создаются заранее).
+func BenchmarkPropagateEach(b *testing.B) {
+	tles := batchTestTLEs(b, 1000)
+	props := make([]*Propagator, len(tles))
+
+	for i, tle := range tles {
+		prop, err := NewPropagator(tle)
+		if err != nil {
+			b.Fatalf("NewPropagator() error = %v", err)
+		}
+
+		props[i] = prop
+	}
+
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	b.Run("serial", func(b *testing.B) {
+		for b.Loop() {
+			for _, prop := range props {
+				if _, err := prop.Propagate(at); err != nil {
+					b.Fatalf("Propagate() error = %v", err)
+				}
+			}
+		}
+	})
+
+	b.Run("workers=8", func(b *testing.B) {
+		for b.Loop() {
+			PropagateEach(props, at, 8)
+		}
+	})
+}
+
+// resetSGP4Cache очищает глобальный кэш инициализации SGP4.
+func resetSGP4Cache() {
+	sgp4Cache.Lock()
+	defer sgp4Cache.Unlock()
+
+	sgp4Cache.lru = newSGP4LRU(sgp4CacheMaxEntries)
+}
+
+// TestPropagator_Stream проверяет выдачу положений в реальном времени и закрытие канала
+// после отмены контекста.
+func TestPropagator_Stream(t *testing.T) {
+	t.Parallel()
+
+	// Геостационарный спутник: SGP4 не прекращает расчёт на любом удалении от эпохи,
+	// поэтому тест не зависит от текущей даты.
+	prop := createGEOTestPropagator(t)
+
+	if _, err := prop.Stream(context.Background(), 0); !errors.Is(err, ErrInvalidStep) {
+		t.Errorf("Stream(0) error = %v, want ErrInvalidStep", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	positions, err := prop.Stream(ctx, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	var prev *ECIPosition
+
+	for range 3 {
+		select {
+		case pos, ok := <-positions:
+			if !ok {
+				t.Fatal("channel closed before cancel")
+			}
+
+			if prev != nil && !pos.Time.After(prev.Time) {
+				t.Errorf("position time %v not after previous %v", pos.Time, prev.Time)
+			}
+
+			prev = pos
+		case <-time.After(time.Second):
+			t.Fatal("no position within 1s")
+		}
+	}
+
+	// Медленный потребитель получает свежее положение, а не застрявшее в буфере.
+	time.Sleep(100 * time.Millisecond)
+
+	select {
+	case pos := <-positions:
+		if age := time.Since(pos.Time); age > 50*time.Millisecond {
+			t.Errorf("buffered position is %v old, want the latest tick", age)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no position within 1s")
+	}
+
+	cancel()
+
+	// Канал закрывается; оставшееся в буфере положение может быть прочитано до закрытия.
+	deadline := time.After(time.Second)
+
+	for {
+		select {
+		case _, ok := <-positions:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("channel not closed after cancel")
+		}
+	}
+}
+
+// TestPropagator_Propagate_TimeZone проверяет, что пропагация, GMST и юлианская дата
+// не зависят от часового пояса переданного времени.
+func TestPropagator_Propagate_TimeZone(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+	at := time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)
+	local := at.In(time.FixedZone("MSK", 3*60*60))
+
+	if GMST(local) != GMST(at) || JulianDay(local) != JulianDay(at) {
+		t.Errorf("GMST/JulianDay depend on time zone: %v vs %v, %v vs %v",
+			GMST(local), GMST(at), JulianDay(local), JulianDay(at))
+	}
+
+	want, err := prop.Propagate(at)
+	if err != nil {
+		t.Fatalf("Propagate(UTC) error = %v", err)
+	}
+
+	got, err := prop.Propagate(local)
+	if err != nil {
+		t.Fatalf("Propagate(MSK) error = %v", err)
+	}
+
+	if d := eciVec(got).sub(eciVec(want)).norm(); d > 1e-9 {
+		t.Errorf("Propagate(MSK) differs from UTC by %.3f km", d)
+	}
+}
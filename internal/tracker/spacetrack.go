@@ -0,0 +1,259 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Константы Space-Track API.
+const (
+	// SpaceTrackBaseURL базовый URL Space-Track.
+	SpaceTrackBaseURL = "https://www.space-track.org"
+
+	// DefaultSpaceTrackRateLimit минимальный интервал между запросами:
+	// Space-Track допускает не более 30 запросов в минуту и 300 в час.
+	DefaultSpaceTrackRateLimit = 12 * time.Second
+
+	// spaceTrackLoginPath путь авторизации; сессия хранится в cookie.
+	spaceTrackLoginPath = "/ajaxauth/login"
+
+	// spaceTrackQueryPath префикс запросов актуальных элементов (класс gp) в формате 3LE.
+	spaceTrackQueryPath = "/basicspacedata/query/class/gp/"
+
+	// spaceTrackFormat суффикс запроса: формат 3LE (имя + две строки TLE).
+	spaceTrackFormat = "/format/3le"
+)
+
+// Ошибки Space-Track клиента.
+var (
+	ErrSpaceTrackAuth             = errors.New("space-track login failed")
+	ErrSpaceTrackUnauthorized     = errors.New("space-track session is not authorized")
+	ErrSpaceTrackNotFound         = errors.New("no data found on space-track")
+	ErrSpaceTrackRateLimit        = errors.New("space-track rate limited (429)")
+	ErrSpaceTrackUnexpectedStatus = errors.New("unexpected space-track HTTP status")
+	ErrSpaceTrackUnsupportedGroup = errors.New("group is not available on space-track")
+)
+
+// Проверка соответствия интерфейсу TLESource на этапе компиляции.
+var _ TLESource = (*SpaceTrackClient)(nil)
+
+// spaceTrackGroupQueries сопоставляет группы Celestrak фильтрам запроса Space-Track
+// (без префикса класса и формата). Группы Space-Track не ведёт, поэтому доступны
+// только те, что однозначно выражаются фильтром по имени или дате.
+var spaceTrackGroupQueries = map[SatelliteGroup]string{
+	GroupActive:     "decay_date/null-val/epoch/>now-30/orderby/norad_cat_id",
+	GroupStarlink:   "OBJECT_NAME/STARLINK~~/decay_date/null-val/orderby/norad_cat_id",
+	GroupOneWeb:     "OBJECT_NAME/ONEWEB~~/decay_date/null-val/orderby/norad_cat_id",
+	GroupIridium:    "OBJECT_NAME/IRIDIUM~~/decay_date/null-val/orderby/norad_cat_id",
+	GroupGlobalstar: "OBJECT_NAME/GLOBALSTAR~~/decay_date/null-val/orderby/norad_cat_id",
+	GroupOrbcomm:    "OBJECT_NAME/ORBCOMM~~/decay_date/null-val/orderby/norad_cat_id",
+	GroupGPS:        "OBJECT_NAME/NAVSTAR~~/decay_date/null-val/orderby/norad_cat_id",
+	GroupLastLaunch: "LAUNCH_DATE/>now-30/decay_date/null-val/orderby/norad_cat_id",
+}
+
+// SpaceTrackClient HTTP клиент для загрузки TLE с Space-Track.org.
+// Требует авторизации через Login; сессионная cookie хранится в клиенте.
+type SpaceTrackClient struct {
+	httpClient  *http.Client
+	baseURL     string
+	rateLimit   time.Duration
+	lastRequest time.Time
+	mu          sync.Mutex
+}
+
+// SpaceTrackOption функция настройки клиента Space-Track.
+type SpaceTrackOption func(*SpaceTrackClient)
+
+// WithSpaceTrackHTTPClient устанавливает кастомный HTTP клиент.
+// Для сохранения сессии у клиента должен быть cookie jar.
+func WithSpaceTrackHTTPClient(client *http.Client) SpaceTrackOption {
+	return func(c *SpaceTrackClient) {
+		c.httpClient = client
+	}
+}
+
+// WithSpaceTrackBaseURL устанавливает базовый URL (для тестирования).
+func WithSpaceTrackBaseURL(url string) SpaceTrackOption {
+	return func(c *SpaceTrackClient) {
+		c.baseURL = url
+	}
+}
+
+// WithSpaceTrackRateLimit устанавливает интервал между запросами.
+func WithSpaceTrackRateLimit(d time.Duration) SpaceTrackOption {
+	return func(c *SpaceTrackClient) {
+		c.rateLimit = d
+	}
+}
+
+// NewSpaceTrackClient создаёт новый клиент Space-Track.
+func NewSpaceTrackClient(opts ...SpaceTrackOption) *SpaceTrackClient {
+	jar, _ := cookiejar.New(nil) // Ошибка возможна только при заданных опциях.
+
+	c := &SpaceTrackClient{
+		httpClient: &http.Client{
+			Timeout: DefaultTimeout,
+			Jar:     jar,
+		},
+		baseURL:   SpaceTrackBaseURL,
+		rateLimit: DefaultSpaceTrackRateLimit,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Login выполняет авторизацию на Space-Track. Сессионная cookie сохраняется
+// в cookie jar HTTP клиента и используется последующими запросами.
+func (c *SpaceTrackClient) Login(ctx context.Context, user, password string) error {
+	if err := c.waitForRateLimit(ctx); err != nil {
+		return err
+	}
+
+	form := url.Values{"identity": {user}, "password": {password}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+spaceTrackLoginPath, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSpaceTrackAuth, err)
+	}
+
+	// При неверных учётных данных Space-Track отвечает 200 с {"Login":"Failed"}.
+	if strings.Contains(body, `"Login":"Failed"`) {
+		return fmt.Errorf("%w: invalid credentials", ErrSpaceTrackAuth)
+	}
+
+	return nil
+}
+
+// FetchByNoradID загружает актуальный TLE по NORAD ID.
+func (c *SpaceTrackClient) FetchByNoradID(ctx context.Context, noradID int) (*TLE, error) {
+	tles, err := c.query(ctx, fmt.Sprintf("NORAD_CAT_ID/%d", noradID))
+	if err != nil {
+		return nil, fmt.Errorf("fetching NORAD ID %d: %w", noradID, err)
+	}
+
+	if len(tles) == 0 {
+		return nil, fmt.Errorf("%w: NORAD ID %d", ErrSpaceTrackNotFound, noradID)
+	}
+
+	return tles[0], nil
+}
+
+// FetchGroup загружает TLE группы. Space-Track не ведёт группы Celestrak,
+// поэтому поддерживаются только группы из spaceTrackGroupQueries;
+// для остальных возвращается ErrSpaceTrackUnsupportedGroup.
+func (c *SpaceTrackClient) FetchGroup(ctx context.Context, group SatelliteGroup) ([]*TLE, error) {
+	filter, ok := spaceTrackGroupQueries[group]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSpaceTrackUnsupportedGroup, group)
+	}
+
+	tles, err := c.query(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("fetching group %s: %w", group, err)
+	}
+
+	return tles, nil
+}
+
+// query выполняет запрос класса gp с фильтром и разбирает ответ в формате 3LE.
+func (c *SpaceTrackClient) query(ctx context.Context, filter string) ([]*TLE, error) {
+	if err := c.waitForRateLimit(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+spaceTrackQueryPath+filter+spaceTrackFormat, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	body, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	tles, err := ParseTLEBatch(body)
+	if err != nil {
+		return nil, fmt.Errorf(errMsgParsingTLE, err)
+	}
+
+	return tles, nil
+}
+
+// waitForRateLimit ждёт соблюдения rate limit или отмены ctx. Момент запроса
+// резервируется под мьютексом, а ожидание идёт без него, поэтому параллельные
+// запросы выстраиваются в очередь с интервалом rateLimit, не блокируя друг друга.
+func (c *SpaceTrackClient) waitForRateLimit(ctx context.Context) error {
+	c.mu.Lock()
+	now := time.Now()
+	slot := c.lastRequest.Add(c.rateLimit)
+	if slot.Before(now) {
+		slot = now
+	}
+	c.lastRequest = slot
+	c.mu.Unlock()
+
+	wait := slot.Sub(now)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// do выполняет HTTP запрос и возвращает тело ответа.
+func (c *SpaceTrackClient) do(req *http.Request) (string, error) {
+	req.Header.Set("User-Agent", "Satellite Scout/1.0 (https://github.com/art-injener/satellite-scout)")
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("executing request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// OK
+	case http.StatusUnauthorized:
+		return "", ErrSpaceTrackUnauthorized
+	case http.StatusTooManyRequests:
+		return "", ErrSpaceTrackRateLimit
+	default:
+		return "", fmt.Errorf("%w: %d", ErrSpaceTrackUnexpectedStatus, resp.StatusCode)
+	}
+
+	body, err := readResponseBody(resp)
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
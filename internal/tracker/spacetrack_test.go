@@ -0,0 +1,140 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newSpaceTrackServer создаёт mock сервер Space-Track с cookie-авторизацией.
+func newSpaceTrackServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	const sessionCookie = "chocolatechip"
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST "+spaceTrackLoginPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("identity") != "user" || r.FormValue("password") != "secret" {
+			_, _ = w.Write([]byte(`{"Login":"Failed"}`))
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: "session", Path: "/"})
+		_, _ = w.Write([]byte(`""`))
+	})
+
+	mux.HandleFunc("GET "+spaceTrackQueryPath, func(w http.ResponseWriter, r *http.Request) {
+		if _, err := r.Cookie(sessionCookie); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch {
+		case strings.Contains(r.URL.Path, "NORAD_CAT_ID/25544/"), strings.Contains(r.URL.Path, "OBJECT_NAME/STARLINK~~/"):
+			_, _ = w.Write([]byte(issTLE + "\n"))
+		default:
+			// Space-Track отвечает пустым телом, если данных нет.
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// TestSpaceTrackClient_LoginAndFetch проверяет авторизацию и загрузку TLE с Space-Track.
+func TestSpaceTrackClient_LoginAndFetch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := newSpaceTrackServer(t)
+	client := NewSpaceTrackClient(WithSpaceTrackBaseURL(server.URL), WithSpaceTrackRateLimit(0))
+
+	if _, err := client.FetchByNoradID(ctx, 25544); !errors.Is(err, ErrSpaceTrackUnauthorized) {
+		t.Errorf("FetchByNoradID() before login error = %v, want ErrSpaceTrackUnauthorized", err)
+	}
+
+	if err := client.Login(ctx, "user", "wrong"); !errors.Is(err, ErrSpaceTrackAuth) {
+		t.Errorf("Login() with wrong password error = %v, want ErrSpaceTrackAuth", err)
+	}
+
+	if err := client.Login(ctx, "user", "secret"); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	tle, err := client.FetchByNoradID(ctx, 25544)
+	if err != nil {
+		t.Fatalf("FetchByNoradID() error = %v", err)
+	}
+
+	if tle.NoradID != 25544 || tle.Name != "ISS (ZARYA)" {
+		t.Errorf("FetchByNoradID() = %d %q, want 25544 ISS (ZARYA)", tle.NoradID, tle.Name)
+	}
+
+	if _, err := client.FetchByNoradID(ctx, 1); !errors.Is(err, ErrSpaceTrackNotFound) {
+		t.Errorf("FetchByNoradID(unknown) error = %v, want ErrSpaceTrackNotFound", err)
+	}
+
+	if _, err := client.FetchGroup(ctx, GroupAmateur); !errors.Is(err, ErrSpaceTrackUnsupportedGroup) {
+		t.Errorf("FetchGroup(amateur) error = %v, want ErrSpaceTrackUnsupportedGroup", err)
+	}
+
+	// TLEStore загружает группу через интерфейс TLESource.
+	store := NewTLEStore(WithTLESource(client), WithGroups(GroupStarlink), WithAutoUpdate(false))
+	if err := store.LoadGroup(ctx, GroupStarlink); err != nil {
+		t.Fatalf("LoadGroup() error = %v", err)
+	}
+
+	if got := store.GetByGroup(GroupStarlink); len(got) != 1 || got[0].NoradID != 25544 {
+		t.Errorf("GetByGroup() = %v, want ISS", got)
+	}
+}
+
+// TestSpaceTrackClient_RateLimitContext проверяет, что ожидание rate limit прерывается
+// отменой контекста и не блокирует параллельные вызовы на время ожидания.
+func TestSpaceTrackClient_RateLimitContext(t *testing.T) {
+	t.Parallel()
+
+	client := NewSpaceTrackClient(WithSpaceTrackRateLimit(time.Hour))
+
+	if err := client.waitForRateLimit(context.Background()); err != nil {
+		t.Fatalf("first waitForRateLimit() error = %v", err)
+	}
+
+	// Первый ожидающий занимает очередь на час, но не держит мьютекс.
+	waiting, cancelWaiting := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		done <- client.waitForRateLimit(waiting)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	if err := client.waitForRateLimit(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("waitForRateLimit() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("waitForRateLimit() returned after %v, want prompt return on deadline", elapsed)
+	}
+
+	cancelWaiting()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("waiting waitForRateLimit() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("waitForRateLimit() did not return after cancel")
+	}
+}
@@ -0,0 +1,222 @@
+package tracker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrGroupNotStored возвращается, если группа отсутствует в постоянном хранилище.
+var ErrGroupNotStored = errors.New("group is not stored")
+
+// sqliteSchema создаёт таблицу каталога. Первичный ключ (group_name, norad_id)
+// служит индексом по группе; отдельный индекс ускоряет выборку по NORAD ID.
+var sqliteSchema = []string{
+	`CREATE TABLE IF NOT EXISTS tle (
+		norad_id   INTEGER NOT NULL,
+		group_name TEXT    NOT NULL,
+		name       TEXT    NOT NULL,
+		epoch      TEXT    NOT NULL,
+		line1      TEXT    NOT NULL,
+		line2      TEXT    NOT NULL,
+		PRIMARY KEY (group_name, norad_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS tle_norad_id_idx ON tle (norad_id)`,
+}
+
+// Проверка соответствия интерфейсу на этапе компиляции.
+var _ StoreBackend = (*SQLiteBackend)(nil)
+
+// SQLiteBackend хранит каталог TLE в SQLite (см. WithStoreBackend).
+// Драйвер SQLite выбирает вызывающий: пакет работает только через database/sql,
+// поэтому сам не добавляет зависимость от cgo или конкретной реализации.
+type SQLiteBackend struct {
+	db *sql.DB
+}
+
+// NewSQLiteBackend создаёт хранилище поверх открытой базы SQLite и при необходимости
+// создаёт схему. Закрытие db остаётся за вызывающим.
+func NewSQLiteBackend(ctx context.Context, db *sql.DB) (*SQLiteBackend, error) {
+	for _, stmt := range sqliteSchema {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return nil, fmt.Errorf("creating sqlite schema: %w", err)
+		}
+	}
+
+	return &SQLiteBackend{db: db}, nil
+}
+
+// SaveGroup заменяет состав группы в одной транзакции.
+func (b *SQLiteBackend) SaveGroup(ctx context.Context, group SatelliteGroup, tles []*TLE) error {
+	return b.inTx(ctx, func(tx *sql.Tx) error {
+		return saveGroupTx(ctx, tx, group, tles)
+	})
+}
+
+// inTx выполняет fn в транзакции: фиксирует её при успехе fn и откатывает при ошибке.
+func (b *SQLiteBackend) inTx(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+// saveGroupTx заменяет состав группы в транзакции tx.
+func saveGroupTx(ctx context.Context, tx *sql.Tx, group SatelliteGroup, tles []*TLE) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tle WHERE group_name = ?`, string(group)); err != nil {
+		return fmt.Errorf("deleting group %s: %w", group, err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx,
+		`INSERT OR REPLACE INTO tle (norad_id, group_name, name, epoch, line1, line2) VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("preparing insert: %w", err)
+	}
+
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	for _, tle := range tles {
+		if _, err := stmt.ExecContext(ctx, tle.NoradID, string(group), tle.Name,
+			tle.Epoch.UTC().Format(time.RFC3339Nano), tle.Line1, tle.Line2); err != nil {
+			return fmt.Errorf("inserting NORAD %d: %w", tle.NoradID, err)
+		}
+	}
+
+	return nil
+}
+
+// LoadGroup возвращает TLE группы в порядке NORAD ID.
+// Для отсутствующей группы возвращается ErrGroupNotStored.
+func (b *SQLiteBackend) LoadGroup(ctx context.Context, group SatelliteGroup) ([]*TLE, error) {
+	rows, err := b.db.QueryContext(ctx,
+		`SELECT name, line1, line2 FROM tle WHERE group_name = ? ORDER BY norad_id`, string(group))
+	if err != nil {
+		return nil, fmt.Errorf("querying group %s: %w", group, err)
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var tles []*TLE
+
+	for rows.Next() {
+		var name, line1, line2 string
+		if err := rows.Scan(&name, &line1, &line2); err != nil {
+			return nil, fmt.Errorf("scanning group %s: %w", group, err)
+		}
+
+		lines := []string{line1, line2}
+		if name != "" {
+			lines = []string{name, line1, line2}
+		}
+
+		tle, err := ParseTLE(lines)
+		if err != nil {
+			return nil, fmt.Errorf(errMsgParsingTLE, err)
+		}
+
+		tles = append(tles, tle)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading group %s: %w", group, err)
+	}
+
+	if len(tles) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrGroupNotStored, group)
+	}
+
+	return tles, nil
+}
+
+// ImportFileCache переносит группы из файлового кэша (*.tle в dir) в базу.
+// Импорт выполняется только при первом запуске, пока таблица пуста, и целиком
+// в одной транзакции: при ошибке база остаётся пустой и импорт повторяется
+// при следующем запуске. Возвращает число импортированных групп.
+func (b *SQLiteBackend) ImportFileCache(ctx context.Context, dir string) (int, error) {
+	// Заполненная база — обычный случай: файлы кэша не читаются.
+	if empty, err := tleTableEmpty(ctx, b.db); err != nil || !empty {
+		return 0, err
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+cacheFileExt))
+	if err != nil {
+		return 0, fmt.Errorf("listing cache: %w", err)
+	}
+
+	groups := make(map[SatelliteGroup][]*TLE, len(paths))
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return 0, fmt.Errorf("reading cache: %w", err)
+		}
+
+		tles, err := ParseTLEBatch(string(data))
+		if err != nil {
+			return 0, fmt.Errorf("%s: "+errMsgParsingTLE, filepath.Base(path), err)
+		}
+
+		groups[SatelliteGroup(strings.TrimSuffix(filepath.Base(path), cacheFileExt))] = tles
+	}
+
+	imported := 0
+
+	err = b.inTx(ctx, func(tx *sql.Tx) error {
+		// Повторная проверка внутри транзакции: параллельный импорт не выполнится дважды.
+		if empty, err := tleTableEmpty(ctx, tx); err != nil || !empty {
+			return err
+		}
+
+		for group, tles := range groups {
+			if err := saveGroupTx(ctx, tx, group, tles); err != nil {
+				return err
+			}
+		}
+
+		imported = len(groups)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return imported, nil
+}
+
+// tleTableEmpty сообщает, что таблица каталога пуста.
+func tleTableEmpty(ctx context.Context, q interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}) (bool, error) {
+	var count int
+	if err := q.QueryRowContext(ctx, `SELECT COUNT(*) FROM tle`).Scan(&count); err != nil {
+		return false, fmt.Errorf("counting rows: %w", err)
+	}
+
+	return count == 0, nil
+}
@@ -0,0 +1,189 @@
+package tracker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite" // Драйвер SQLite на чистом Go (без cgo).
+)
+
+// newTestSQLiteBackend открывает базу SQLite во временной директории теста.
+func newTestSQLiteBackend(t *testing.T) *SQLiteBackend {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "tle.db"))
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	backend, err := NewSQLiteBackend(context.Background(), db)
+	if err != nil {
+		t.Fatalf("NewSQLiteBackend() error = %v", err)
+	}
+
+	return backend
+}
+
+// TestSQLiteBackend_SaveLoad проверяет сохранение, замену и чтение групп.
+func TestSQLiteBackend_SaveLoad(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	backend := newTestSQLiteBackend(t)
+
+	batch, err := ParseTLEBatch(meteorTLE + "\n" + issTLE + "\n" + hstTLE)
+	if err != nil {
+		t.Fatalf("ParseTLEBatch() error = %v", err)
+	}
+
+	if err := backend.SaveGroup(ctx, GroupStations, batch); err != nil {
+		t.Fatalf("SaveGroup() error = %v", err)
+	}
+
+	got, err := backend.LoadGroup(ctx, GroupStations)
+	if err != nil {
+		t.Fatalf("LoadGroup() error = %v", err)
+	}
+
+	wantNorad := []int{20580, 25544, 40069}
+	if len(got) != len(wantNorad) {
+		t.Fatalf("LoadGroup() returned %d TLEs, want %d", len(got), len(wantNorad))
+	}
+
+	for i, want := range wantNorad {
+		if got[i].NoradID != want {
+			t.Errorf("LoadGroup()[%d].NoradID = %d, want %d", i, got[i].NoradID, want)
+		}
+	}
+
+	if got[1].Name != "ISS (ZARYA)" || got[1].Line1 != issLine1 || got[1].Line2 != issLine2 {
+		t.Errorf("LoadGroup()[1] = %q %q %q, want ISS lines", got[1].Name, got[1].Line1, got[1].Line2)
+	}
+
+	if got[0].Name != "" {
+		t.Errorf("LoadGroup()[0].Name = %q, want empty for 2-line TLE", got[0].Name)
+	}
+
+	// Повторное сохранение заменяет состав группы целиком.
+	if err := backend.SaveGroup(ctx, GroupStations, batch[:1]); err != nil {
+		t.Fatalf("SaveGroup() replace error = %v", err)
+	}
+
+	got, err = backend.LoadGroup(ctx, GroupStations)
+	if err != nil || len(got) != 1 || got[0].NoradID != 40069 {
+		t.Errorf("LoadGroup() after replace = %v, %v, want only METEOR-M2", got, err)
+	}
+
+	if _, err := backend.LoadGroup(ctx, GroupWeather); !errors.Is(err, ErrGroupNotStored) {
+		t.Errorf("LoadGroup(%s) error = %v, want ErrGroupNotStored", GroupWeather, err)
+	}
+}
+
+// TestSQLiteBackend_ImportFileCache проверяет перенос файлового кэша в пустую базу
+// и пропуск импорта, если база уже заполнена.
+func TestSQLiteBackend_ImportFileCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	backend := newTestSQLiteBackend(t)
+	dir := t.TempDir()
+
+	writeTestFile(t, filepath.Join(dir, string(GroupStations)+cacheFileExt), issTLE+"\n"+hstTLE)
+	writeTestFile(t, filepath.Join(dir, string(GroupWeather)+cacheFileExt), meteorTLE)
+	writeTestFile(t, filepath.Join(dir, "notes.txt"), "not a cache file")
+
+	imported, err := backend.ImportFileCache(ctx, dir)
+	if err != nil || imported != 2 {
+		t.Fatalf("ImportFileCache() = %d, %v, want 2, nil", imported, err)
+	}
+
+	stations, err := backend.LoadGroup(ctx, GroupStations)
+	if err != nil || len(stations) != 2 {
+		t.Errorf("LoadGroup(%s) = %d TLEs, %v, want 2", GroupStations, len(stations), err)
+	}
+
+	weather, err := backend.LoadGroup(ctx, GroupWeather)
+	if err != nil || len(weather) != 1 || weather[0].NoradID != 40069 {
+		t.Errorf("LoadGroup(%s) = %v, %v, want METEOR-M2", GroupWeather, weather, err)
+	}
+
+	if imported, err := backend.ImportFileCache(ctx, dir); err != nil || imported != 0 {
+		t.Errorf("second ImportFileCache() = %d, %v, want 0, nil", imported, err)
+	}
+}
+
+// TestSQLiteBackend_ImportFileCacheAtomic проверяет, что ошибка в одном файле кэша
+// не оставляет в базе частичный импорт и импорт повторяется после исправления файла.
+func TestSQLiteBackend_ImportFileCacheAtomic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	backend := newTestSQLiteBackend(t)
+	dir := t.TempDir()
+
+	writeTestFile(t, filepath.Join(dir, string(GroupStations)+cacheFileExt), issTLE)
+	writeTestFile(t, filepath.Join(dir, string(GroupWeather)+cacheFileExt), "BROKEN\n1 garbage\n2 garbage")
+
+	if imported, err := backend.ImportFileCache(ctx, dir); err == nil || imported != 0 {
+		t.Fatalf("ImportFileCache() with broken file = %d, %v, want 0 and error", imported, err)
+	}
+
+	if _, err := backend.LoadGroup(ctx, GroupStations); !errors.Is(err, ErrGroupNotStored) {
+		t.Errorf("LoadGroup(%s) after failed import error = %v, want ErrGroupNotStored", GroupStations, err)
+	}
+
+	writeTestFile(t, filepath.Join(dir, string(GroupWeather)+cacheFileExt), meteorTLE)
+
+	if imported, err := backend.ImportFileCache(ctx, dir); err != nil || imported != 2 {
+		t.Errorf("retried ImportFileCache() = %d, %v, want 2, nil", imported, err)
+	}
+}
+
+// TestTLEStore_StartImportsFileCache проверяет, что Start переносит файловый кэш в SQLiteBackend,
+// и при недоступном источнике группы читаются из базы.
+func TestTLEStore_StartImportsFileCache(t *testing.T) {
+	t.Parallel()
+
+	backend := newTestSQLiteBackend(t)
+	dir := t.TempDir()
+
+	writeTestFile(t, filepath.Join(dir, string(GroupStations)+cacheFileExt), issTLE)
+
+	store := NewTLEStore(
+		WithTLESource(&fakeTLESource{err: ErrCelestrakServerError}),
+		WithStoreBackend(backend),
+		WithCacheDir(dir),
+		WithGroups(GroupStations),
+		WithAutoUpdate(false),
+	)
+	t.Cleanup(store.Stop)
+
+	if err := store.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if _, ok := store.Get(25544); !ok {
+		t.Error("Get(25544) not found after Start with imported file cache")
+	}
+
+	if tles, err := backend.LoadGroup(context.Background(), GroupStations); err != nil || len(tles) != 1 {
+		t.Errorf("backend LoadGroup() = %d TLEs, %v, want 1", len(tles), err)
+	}
+}
+
+// writeTestFile записывает файл теста.
+func writeTestFile(t *testing.T, path, data string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
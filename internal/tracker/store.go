@@ -0,0 +1,1154 @@
+package tracker
+
+import (
+	"cmp"
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Константы хранилища TLE.
+const (
+	// DefaultUpdateInterval интервал фонового обновления TLE.
+	DefaultUpdateInterval = 6 * time.Hour
+
+	// cacheFileExt расширение файлов кэша групп.
+	cacheFileExt = ".tle"
+
+	// cacheFilePerm права на файлы кэша.
+	cacheFilePerm = 0o600
+
+	// cacheDirPerm права на директорию кэша.
+	cacheDirPerm = 0o750
+
+	// Ключи структурированного лога.
+	slogKeyErr     = "error"
+	slogKeyGroup   = "group"
+	slogKeyNorad   = "norad_id"
+	slogKeyOldName = "old_name"
+	slogKeyNewName = "new_name"
+)
+
+// Ошибки хранилища TLE.
+var (
+	ErrStoreAlreadyStarted = errors.New("store already started")
+	ErrCacheDisabled       = errors.New("cache directory is not configured")
+	ErrNotInCatalog        = errors.New("satellite not in catalog")
+)
+
+// TLESource — источник TLE для TLEStore. Реализуется CelestrakClient и SpaceTrackClient;
+// в тестах можно подставить собственную реализацию без HTTP сервера.
+type TLESource interface {
+	// FetchGroup загружает TLE группы спутников.
+	FetchGroup(ctx context.Context, group SatelliteGroup) ([]*TLE, error)
+	// FetchByNoradID загружает TLE одного спутника.
+	FetchByNoradID(ctx context.Context, noradID int) (*TLE, error)
+}
+
+// StoreBackend — постоянное хранилище групп TLE для TLEStore. По умолчанию
+// используется файловый кэш (WithCacheDir); SQLiteBackend позволяет хранить
+// каталог в базе данных.
+type StoreBackend interface {
+	// SaveGroup заменяет сохранённый состав группы.
+	SaveGroup(ctx context.Context, group SatelliteGroup, tles []*TLE) error
+	// LoadGroup возвращает сохранённые TLE группы.
+	LoadGroup(ctx context.Context, group SatelliteGroup) ([]*TLE, error)
+}
+
+// Проверка соответствия интерфейсам на этапе компиляции.
+var (
+	_ TLESource           = (*CelestrakClient)(nil)
+	_ validatingTLESource = (*CelestrakClient)(nil)
+)
+
+// validatingTLESource — источник, поддерживающий условные запросы групп
+// и сообщающий валидаторы HTTP кэша последнего успешного ответа.
+type validatingTLESource interface {
+	FetchGroupIfModified(ctx context.Context, group SatelliteGroup, etag, lastModified string) ([]*TLE, error)
+	GroupValidators(group SatelliteGroup) (etag, lastModified string)
+}
+
+// CacheMeta — сведения о свежести данных группы.
+type CacheMeta struct {
+	UpdatedAt    time.Time `json:"updated_at"`              // Момент последней успешной проверки данных у источника.
+	ETag         string    `json:"etag,omitempty"`          // ETag последнего ответа источника.
+	LastModified string    `json:"last_modified,omitempty"` // Last-Modified последнего ответа источника.
+}
+
+// TLEStore хранит каталог TLE, загружает группы из источника TLE (по умолчанию Celestrak)
+// и при необходимости периодически обновляет их в фоне.
+type TLEStore struct {
+	mu       sync.RWMutex
+	catalog  map[int]*TLE             // NORAD ID → TLE.
+	byGroup  map[SatelliteGroup][]int // Группа → NORAD ID.
+	byName   map[string]int           // Имя (в верхнем регистре) → NORAD ID.
+	meta     map[SatelliteGroup]CacheMeta
+	metadata map[int]*SatelliteMetadata // NORAD ID → сведения SatNOGS (при WithMetadata).
+
+	client         TLESource
+	groups         []SatelliteGroup
+	cacheDir       string
+	backend        StoreBackend
+	updateInterval time.Duration
+	autoUpdate     bool
+	logger         *slog.Logger
+
+	metadataEnabled bool
+	satnogs         *SatNOGSClient
+
+	parsedCacheEnabled bool
+	parsedMu           sync.Mutex
+	parsedCache        map[SatelliteGroup]parsedCacheEntry // Группа → разобранный файл кэша.
+	parseBatch         func(data string) ([]*TLE, error)   // Парсер файла кэша (подменяется в тестах).
+
+	started  bool
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// parsedCacheEntry — разобранное содержимое файла кэша группы.
+type parsedCacheEntry struct {
+	modTime time.Time
+	size    int64
+	tles    []*TLE
+}
+
+// StoreOption функция настройки хранилища.
+type StoreOption func(*TLEStore)
+
+// WithCelestrakClient устанавливает клиент Celestrak.
+func WithCelestrakClient(client *CelestrakClient) StoreOption {
+	return func(s *TLEStore) {
+		if client != nil {
+			s.client = client
+		}
+	}
+}
+
+// WithTLESource устанавливает источник TLE, например SpaceTrackClient.
+// По умолчанию используется CelestrakClient.
+func WithTLESource(source TLESource) StoreOption {
+	return func(s *TLEStore) {
+		s.client = source
+	}
+}
+
+// WithGroups устанавливает список загружаемых групп.
+func WithGroups(groups ...SatelliteGroup) StoreOption {
+	return func(s *TLEStore) {
+		s.groups = groups
+	}
+}
+
+// WithCacheDir устанавливает директорию файлового кэша групп.
+// Пустая строка отключает кэш.
+func WithCacheDir(dir string) StoreOption {
+	return func(s *TLEStore) {
+		s.cacheDir = dir
+	}
+}
+
+// WithStoreBackend устанавливает постоянное хранилище групп вместо файлового кэша.
+// При заданном backend директория WithCacheDir для кэша групп не используется;
+// если backend поддерживает импорт (SQLiteBackend), Start переносит в него
+// существующий файловый кэш из этой директории.
+func WithStoreBackend(backend StoreBackend) StoreOption {
+	return func(s *TLEStore) {
+		s.backend = backend
+	}
+}
+
+// WithMetadata включает загрузку сведений о спутниках и частот передатчиков
+// из SatNOGS DB при Start (см. GetMetadata).
+func WithMetadata(enabled bool) StoreOption {
+	return func(s *TLEStore) {
+		s.metadataEnabled = enabled
+	}
+}
+
+// WithSatNOGSClient устанавливает клиент SatNOGS DB для загрузки сведений о спутниках.
+func WithSatNOGSClient(client *SatNOGSClient) StoreOption {
+	return func(s *TLEStore) {
+		if client != nil {
+			s.satnogs = client
+		}
+	}
+}
+
+// WithUpdateInterval устанавливает интервал фонового обновления.
+// Значение 0 означает «никогда» — то же, что WithAutoUpdate(false).
+func WithUpdateInterval(d time.Duration) StoreOption {
+	return func(s *TLEStore) {
+		s.updateInterval = d
+	}
+}
+
+// WithAutoUpdate включает или отключает фоновое обновление.
+// При отключённом обновлении Start загружает группы один раз,
+// а LoadGroup/LoadAllGroups остаются доступны для ручного вызова.
+func WithAutoUpdate(enabled bool) StoreOption {
+	return func(s *TLEStore) {
+		s.autoUpdate = enabled
+	}
+}
+
+// WithParsedCache включает кэширование разобранных файлов кэша в памяти процесса.
+// Повторное чтение неизменённого файла (по времени модификации и размеру)
+// не требует повторного парсинга.
+func WithParsedCache(enabled bool) StoreOption {
+	return func(s *TLEStore) {
+		s.parsedCacheEnabled = enabled
+	}
+}
+
+// WithLogger устанавливает логгер хранилища.
+func WithLogger(logger *slog.Logger) StoreOption {
+	return func(s *TLEStore) {
+		s.logger = logger
+	}
+}
+
+// NewTLEStore создаёт новое хранилище TLE.
+// По умолчанию загружает группу stations и обновляет её каждые 6 часов.
+func NewTLEStore(opts ...StoreOption) *TLEStore {
+	s := &TLEStore{
+		catalog:        make(map[int]*TLE),
+		byGroup:        make(map[SatelliteGroup][]int),
+		byName:         make(map[string]int),
+		meta:           make(map[SatelliteGroup]CacheMeta),
+		metadata:       make(map[int]*SatelliteMetadata),
+		groups:         []SatelliteGroup{GroupStations},
+		updateInterval: DefaultUpdateInterval,
+		autoUpdate:     true,
+		logger:         slog.Default(),
+		parsedCache:    make(map[SatelliteGroup]parsedCacheEntry),
+		parseBatch:     ParseTLEBatch,
+		stopCh:         make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.client == nil {
+		s.client = NewCelestrakClient()
+	}
+
+	if s.satnogs == nil {
+		s.satnogs = NewSatNOGSClient()
+	}
+
+	return s
+}
+
+// Start выполняет начальную загрузку всех групп и запускает фоновое обновление,
+// если оно включено. Ошибка начальной загрузки возвращается, но фоновое
+// обновление всё равно запускается, чтобы хранилище могло восстановиться.
+func (s *TLEStore) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.started {
+		s.mu.Unlock()
+		return ErrStoreAlreadyStarted
+	}
+	s.started = true
+
+	// Горутина обновления учитывается в wg до долгой начальной загрузки и под s.mu:
+	// Stop закрывает stopCh под тем же мьютексом, поэтому wg.Add либо предшествует
+	// wg.Wait в Stop, либо не выполняется вовсе.
+	updater := s.autoUpdate && s.updateInterval > 0 && !s.stopped()
+	if updater {
+		s.wg.Add(1)
+	}
+	s.mu.Unlock()
+
+	s.importFileCache(ctx)
+
+	loadErr := s.LoadAllGroups(ctx)
+
+	// Сведения SatNOGS необязательны: их недоступность не мешает работе с TLE.
+	if s.metadataEnabled {
+		if err := s.LoadMetadata(ctx); err != nil {
+			s.logger.Warn("failed to load satellite metadata", slogKeyErr, err)
+		}
+	}
+
+	if updater {
+		go s.startUpdater(ctx)
+	}
+
+	return loadErr
+}
+
+// fileCacheImporter — постоянное хранилище, умеющее перенести в себя файловый кэш
+// (см. SQLiteBackend.ImportFileCache).
+type fileCacheImporter interface {
+	ImportFileCache(ctx context.Context, dir string) (int, error)
+}
+
+// importFileCache переносит файловый кэш в backend, если заданы и backend с поддержкой
+// импорта, и WithCacheDir. Ошибка импорта не мешает запуску: группы будут загружены из источника.
+func (s *TLEStore) importFileCache(ctx context.Context) {
+	importer, ok := s.backend.(fileCacheImporter)
+	if !ok || s.cacheDir == "" {
+		return
+	}
+
+	imported, err := importer.ImportFileCache(ctx, s.cacheDir)
+	if err != nil {
+		s.logger.Warn("failed to import file cache into store backend", slogKeyErr, err)
+		return
+	}
+
+	if imported > 0 {
+		s.logger.Info("file cache imported into store backend", "groups", imported)
+	}
+}
+
+// Stop останавливает фоновое обновление и дожидается его завершения.
+// Безопасен для повторного вызова, при отключённом обновлении и одновременно с Start:
+// в последнем случае дожидается окончания начальной загрузки.
+func (s *TLEStore) Stop() {
+	s.mu.Lock()
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// stopped сообщает, был ли вызван Stop. Вызывающий должен держать s.mu.
+func (s *TLEStore) stopped() bool {
+	select {
+	case <-s.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// startUpdater периодически перезагружает группы до вызова Stop или отмены контекста.
+func (s *TLEStore) startUpdater(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.updateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			if err := s.LoadAllGroups(ctx); err != nil {
+				s.logger.Warn("scheduled TLE update failed", slogKeyErr, err)
+			}
+		}
+	}
+}
+
+// LoadAllGroups загружает все настроенные группы.
+// Ошибки отдельных групп объединяются, остальные группы загружаются.
+func (s *TLEStore) LoadAllGroups(ctx context.Context) error {
+	var errs []error
+
+	for _, group := range s.groups {
+		if err := s.LoadGroup(ctx, group); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// LoadGroup загружает группу из источника TLE и сохраняет её в кэш.
+// Если группа уже в каталоге и источник поддерживает условные запросы, запрос
+// выполняется условным; на ErrNotModified группа сохраняется и обновляется только
+// время проверки (см. GroupMeta). При ошибке сети использует данные из файлового кэша, если он есть.
+func (s *TLEStore) LoadGroup(ctx context.Context, group SatelliteGroup) error {
+	tles, fetchErr := s.fetchGroup(ctx, group)
+	if fetchErr == nil {
+		s.replaceGroup(group, tles)
+		s.touchGroup(group, true)
+
+		if err := s.saveGroupToCache(ctx, group, tles); err != nil && !errors.Is(err, ErrCacheDisabled) {
+			s.logger.Warn("failed to save TLE cache", slogKeyGroup, string(group), slogKeyErr, err)
+		}
+
+		return nil
+	}
+
+	if errors.Is(fetchErr, ErrNotModified) && s.hasGroup(group) {
+		s.touchGroup(group, false)
+
+		return nil
+	}
+
+	tles, cacheErr := s.loadGroupFromCache(ctx, group)
+	if cacheErr != nil {
+		return fmt.Errorf("loading group %s: %w", group, fetchErr)
+	}
+
+	s.logger.Warn("using cached TLE after fetch failure", slogKeyGroup, string(group), slogKeyErr, fetchErr)
+	s.replaceGroup(group, tles)
+
+	return nil
+}
+
+// fetchGroup запрашивает группу у источника: условно, если у хранилища есть данные группы
+// и валидаторы для них, иначе безусловно.
+func (s *TLEStore) fetchGroup(ctx context.Context, group SatelliteGroup) ([]*TLE, error) {
+	src, ok := s.client.(validatingTLESource)
+	if !ok {
+		return s.client.FetchGroup(ctx, group)
+	}
+
+	s.mu.RLock()
+	_, loaded := s.byGroup[group]
+	meta := s.meta[group]
+	s.mu.RUnlock()
+
+	if !loaded || (meta.ETag == "" && meta.LastModified == "") {
+		return s.client.FetchGroup(ctx, group)
+	}
+
+	return src.FetchGroupIfModified(ctx, group, meta.ETag, meta.LastModified)
+}
+
+// GroupMeta возвращает сведения о свежести группы; false — группа ещё не загружалась из источника.
+func (s *TLEStore) GroupMeta(group SatelliteGroup) (CacheMeta, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	meta, ok := s.meta[group]
+
+	return meta, ok
+}
+
+// hasGroup сообщает, загружена ли группа в каталог.
+func (s *TLEStore) hasGroup(group SatelliteGroup) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.byGroup[group]
+
+	return ok
+}
+
+// touchGroup отмечает успешную проверку группы у источника. При refreshValidators
+// валидаторы HTTP кэша берутся из источника (данные были загружены заново).
+func (s *TLEStore) touchGroup(group SatelliteGroup, refreshValidators bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	meta := s.meta[group]
+	meta.UpdatedAt = time.Now()
+
+	if refreshValidators {
+		meta.ETag, meta.LastModified = "", ""
+
+		if src, ok := s.client.(validatingTLESource); ok {
+			meta.ETag, meta.LastModified = src.GroupValidators(group)
+		}
+	}
+
+	s.meta[group] = meta
+}
+
+// replaceGroup заменяет состав группы и добавляет её TLE в каталог.
+func (s *TLEStore) replaceGroup(group SatelliteGroup, tles []*TLE) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]int, 0, len(tles))
+	for _, tle := range tles {
+		s.addInternal(tle)
+		ids = append(ids, tle.NoradID)
+	}
+
+	s.byGroup[group] = ids
+}
+
+// Add добавляет или заменяет TLE в каталоге.
+func (s *TLEStore) Add(tle *TLE) {
+	if tle == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addInternal(tle)
+}
+
+// Remove удаляет спутник из каталога и из индексов групп и имён (включая псевдонимы).
+// Возвращает false, если спутника нет в каталоге.
+func (s *TLEStore) Remove(noradID int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.removeInternal(map[int]struct{}{noradID: {}}) > 0
+}
+
+// RemoveGroup исключает группу из хранилища и удаляет из каталога её спутники,
+// не входящие в другие загруженные группы. Возвращает число удалённых спутников.
+func (s *TLEStore) RemoveGroup(group SatelliteGroup) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := s.byGroup[group]
+	delete(s.byGroup, group)
+	delete(s.meta, group)
+
+	remove := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		remove[id] = struct{}{}
+	}
+
+	for _, other := range s.byGroup {
+		for _, id := range other {
+			delete(remove, id)
+		}
+	}
+
+	return s.removeInternal(remove)
+}
+
+// PruneStale удаляет спутники, эпоха TLE которых старше maxAgeDays суток.
+// Возвращает число удалённых спутников.
+func (s *TLEStore) PruneStale(maxAgeDays float64) int {
+	cutoff := time.Now().Add(-time.Duration(maxAgeDays * float64(24*time.Hour)))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	remove := make(map[int]struct{})
+
+	for id, tle := range s.catalog {
+		if tle.Epoch.Before(cutoff) {
+			remove[id] = struct{}{}
+		}
+	}
+
+	return s.removeInternal(remove)
+}
+
+// removeInternal удаляет спутники из каталога и вычищает их NORAD ID из индексов
+// за один проход. Вызывающий должен держать s.mu. Возвращает число удалённых спутников.
+func (s *TLEStore) removeInternal(ids map[int]struct{}) int {
+	removed := 0
+
+	for id := range ids {
+		if _, ok := s.catalog[id]; ok {
+			delete(s.catalog, id)
+			delete(s.metadata, id)
+			removed++
+		}
+	}
+
+	if removed == 0 {
+		return 0
+	}
+
+	for name, id := range s.byName {
+		if _, ok := ids[id]; ok {
+			delete(s.byName, name)
+		}
+	}
+
+	// Опустевшая группа исключается вместе с валидаторами HTTP кэша, иначе следующий
+	// условный запрос получит 304 и группа так и останется пустой.
+	for group, members := range s.byGroup {
+		members = slices.DeleteFunc(members, func(id int) bool {
+			_, ok := ids[id]
+			return ok
+		})
+
+		if len(members) == 0 {
+			delete(s.byGroup, group)
+			delete(s.meta, group)
+
+			continue
+		}
+
+		s.byGroup[group] = members
+	}
+
+	return removed
+}
+
+// addInternal добавляет TLE без блокировки. Вызывающий должен держать s.mu.
+// При переименовании спутника (например, «OBJECT A» → настоящее имя) прежнее имя
+// остаётся в индексе byName как псевдоним, чтобы поиск по нему продолжал работать.
+func (s *TLEStore) addInternal(tle *TLE) {
+	if prev, ok := s.catalog[tle.NoradID]; ok && prev.Name != "" && tle.Name != "" &&
+		normalizeName(prev.Name) != normalizeName(tle.Name) {
+		s.logger.Info("satellite renamed",
+			slogKeyNorad, tle.NoradID, slogKeyOldName, prev.Name, slogKeyNewName, tle.Name)
+	}
+
+	s.catalog[tle.NoradID] = tle
+
+	if tle.Name != "" {
+		s.byName[normalizeName(tle.Name)] = tle.NoradID
+	}
+}
+
+// Get возвращает TLE по NORAD ID.
+func (s *TLEStore) Get(noradID int) (*TLE, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tle, ok := s.catalog[noradID]
+
+	return tle, ok
+}
+
+// GetMetadata возвращает сведения SatNOGS о спутнике. false — метаданные не загружались
+// (см. WithMetadata) или спутника нет в SatNOGS DB.
+func (s *TLEStore) GetMetadata(noradID int) (*SatelliteMetadata, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	meta, ok := s.metadata[noradID]
+
+	return meta, ok
+}
+
+// LoadMetadata загружает из SatNOGS DB сведения о спутниках каталога
+// и заменяет ими ранее загруженные. Спутники без записи в SatNOGS пропускаются.
+func (s *TLEStore) LoadMetadata(ctx context.Context) error {
+	all, err := s.satnogs.FetchAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	metadata := make(map[int]*SatelliteMetadata, len(s.catalog))
+
+	for id := range s.catalog {
+		if meta, ok := all[id]; ok {
+			metadata[id] = meta
+		}
+	}
+
+	s.metadata = metadata
+
+	return nil
+}
+
+// FindByFrequency возвращает спутники с загруженными метаданными (см. WithMetadata),
+// у которых хотя бы один нисходящий канал пересекается с диапазоном [minHz, maxHz].
+// Если заданы modes, учитываются только каналы с одной из этих модуляций (без учёта
+// регистра, например "FM" или "BPSK"). Результат отсортирован по наименьшей
+// подходящей частоте, при равных частотах — по NORAD ID.
+func (s *TLEStore) FindByFrequency(minHz, maxHz float64, modes ...string) []*TLE {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type match struct {
+		tle    *TLE
+		freqHz float64
+	}
+
+	var matches []match
+
+	for id, meta := range s.metadata {
+		tle, ok := s.catalog[id]
+		if !ok {
+			continue
+		}
+
+		best := math.Inf(1)
+
+		for _, downlink := range meta.Downlinks {
+			low, high := float64(downlink.LowHz), float64(downlink.HighHz)
+			if high < minHz || low > maxHz || !matchesMode(downlink.Mode, modes) {
+				continue
+			}
+
+			best = math.Min(best, math.Max(low, minHz))
+		}
+
+		if !math.IsInf(best, 1) {
+			matches = append(matches, match{tle: tle, freqHz: best})
+		}
+	}
+
+	slices.SortFunc(matches, func(a, b match) int {
+		if c := cmp.Compare(a.freqHz, b.freqHz); c != 0 {
+			return c
+		}
+
+		return a.tle.NoradID - b.tle.NoradID
+	})
+
+	tles := make([]*TLE, 0, len(matches))
+	for _, m := range matches {
+		tles = append(tles, m.tle)
+	}
+
+	return tles
+}
+
+// matchesMode сообщает, входит ли модуляция в список modes; пустой список допускает любую.
+func matchesMode(mode string, modes []string) bool {
+	if len(modes) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(modes, func(m string) bool { return strings.EqualFold(m, mode) })
+}
+
+// GetByName возвращает TLE по имени спутника (без учёта регистра).
+func (s *TLEStore) GetByName(name string) (*TLE, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	id, ok := s.byName[normalizeName(name)]
+	if !ok {
+		return nil, false
+	}
+
+	tle, ok := s.catalog[id]
+
+	return tle, ok
+}
+
+// GetByGroup возвращает TLE спутников группы.
+func (s *TLEStore) GetByGroup(group SatelliteGroup) []*TLE {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := s.byGroup[group]
+	tles := make([]*TLE, 0, len(ids))
+
+	for _, id := range ids {
+		if tle, ok := s.catalog[id]; ok {
+			tles = append(tles, tle)
+		}
+	}
+
+	return tles
+}
+
+// All возвращает все TLE каталога в произвольном порядке.
+func (s *TLEStore) All() []*TLE {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tles := make([]*TLE, 0, len(s.catalog))
+	for _, tle := range s.catalog {
+		tles = append(tles, tle)
+	}
+
+	return tles
+}
+
+// CatalogSort — порядок сортировки каталога в List.
+type CatalogSort int
+
+// Порядки сортировки каталога.
+const (
+	SortByNoradID  CatalogSort = iota // По NORAD ID.
+	SortByName                        // По имени без учёта регистра.
+	SortByEpoch                       // По эпохе TLE, от старых к свежим.
+	SortByAltitude                    // По средней высоте орбиты, от низких к высоким.
+)
+
+// List возвращает страницу каталога, отсортированного по sortBy, и общее число спутников.
+// Сортировка устойчива: при равных ключах спутники идут по возрастанию NORAD ID.
+// offset за пределами каталога даёт пустую страницу; limit <= 0 — до конца каталога.
+func (s *TLEStore) List(sortBy CatalogSort, offset, limit int) ([]*TLE, int) {
+	tles := s.All()
+	total := len(tles)
+
+	slices.SortFunc(tles, func(a, b *TLE) int { return a.NoradID - b.NoradID })
+	slices.SortStableFunc(tles, catalogCompare(sortBy))
+
+	offset = max(offset, 0)
+	if offset >= total {
+		return []*TLE{}, total
+	}
+
+	end := total
+	if limit > 0 {
+		end = offset + min(limit, total-offset)
+	}
+
+	return tles[offset:end], total
+}
+
+// catalogCompare возвращает функцию сравнения TLE для порядка sortBy.
+func catalogCompare(sortBy CatalogSort) func(a, b *TLE) int {
+	switch sortBy {
+	case SortByName:
+		return func(a, b *TLE) int { return strings.Compare(normalizeName(a.Name), normalizeName(b.Name)) }
+	case SortByEpoch:
+		return func(a, b *TLE) int { return a.Epoch.Compare(b.Epoch) }
+	case SortByAltitude:
+		// Средняя высота (a − R) монотонна по большой полуоси.
+		return func(a, b *TLE) int { return cmp.Compare(a.SemiMajorAxis(), b.SemiMajorAxis()) }
+	case SortByNoradID:
+		return func(a, b *TLE) int { return a.NoradID - b.NoradID }
+	default:
+		return func(a, b *TLE) int { return a.NoradID - b.NoradID }
+	}
+}
+
+// Count возвращает количество спутников в каталоге.
+func (s *TLEStore) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.catalog)
+}
+
+// cachePath возвращает путь к файлу кэша группы.
+func (s *TLEStore) cachePath(group SatelliteGroup) string {
+	return filepath.Join(s.cacheDir, string(group)+cacheFileExt)
+}
+
+// saveGroupToCache сохраняет TLE группы в StoreBackend, если он задан,
+// иначе в файл кэша в 3-line формате.
+func (s *TLEStore) saveGroupToCache(ctx context.Context, group SatelliteGroup, tles []*TLE) error {
+	if s.backend != nil {
+		return s.backend.SaveGroup(ctx, group, tles)
+	}
+
+	if s.cacheDir == "" {
+		return ErrCacheDisabled
+	}
+
+	if err := os.MkdirAll(s.cacheDir, cacheDirPerm); err != nil {
+		return fmt.Errorf("creating cache dir: %w", err)
+	}
+
+	var sb strings.Builder
+	for _, tle := range tles {
+		sb.WriteString(tle.String())
+		sb.WriteString("\n")
+	}
+
+	if err := os.WriteFile(s.cachePath(group), []byte(sb.String()), cacheFilePerm); err != nil {
+		return fmt.Errorf("writing cache: %w", err)
+	}
+
+	return nil
+}
+
+// loadGroupFromCache читает TLE группы из StoreBackend, если он задан,
+// иначе читает и парсит файл кэша.
+// При включённом WithParsedCache результат разбора файла переиспользуется,
+// пока время модификации и размер файла не изменились.
+func (s *TLEStore) loadGroupFromCache(ctx context.Context, group SatelliteGroup) ([]*TLE, error) {
+	if s.backend != nil {
+		return s.backend.LoadGroup(ctx, group)
+	}
+
+	if s.cacheDir == "" {
+		return nil, ErrCacheDisabled
+	}
+
+	path := s.cachePath(group)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cache: %w", err)
+	}
+
+	if s.parsedCacheEnabled {
+		s.parsedMu.Lock()
+		entry, ok := s.parsedCache[group]
+		s.parsedMu.Unlock()
+
+		if ok && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+			return entry.tles, nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cache: %w", err)
+	}
+
+	tles, err := s.parseBatch(string(data))
+	if err != nil {
+		return nil, fmt.Errorf(errMsgParsingTLE, err)
+	}
+
+	if s.parsedCacheEnabled {
+		s.parsedMu.Lock()
+		s.parsedCache[group] = parsedCacheEntry{modTime: info.ModTime(), size: info.Size(), tles: tles}
+		s.parsedMu.Unlock()
+	}
+
+	return tles, nil
+}
+
+// normalizeName приводит имя спутника к ключу индекса byName.
+func normalizeName(name string) string {
+	return strings.ToUpper(strings.TrimSpace(name))
+}
+
+// RegimeHistogram возвращает количество спутников каталога по классам орбит.
+func (s *TLEStore) RegimeHistogram() map[OrbitRegime]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	histogram := make(map[OrbitRegime]int)
+	for _, tle := range s.catalog {
+		histogram[tle.Regime()]++
+	}
+
+	return histogram
+}
+
+// GetByOrbitClass возвращает спутники каталога заданного класса орбиты (см. TLE.OrbitClass)
+// в порядке возрастания NORAD ID.
+func (s *TLEStore) GetByOrbitClass(class OrbitClass) []*TLE {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var tles []*TLE
+
+	for _, tle := range s.catalog {
+		if tle.OrbitClass() == class {
+			tles = append(tles, tle)
+		}
+	}
+
+	slices.SortFunc(tles, func(a, b *TLE) int { return a.NoradID - b.NoradID })
+
+	return tles
+}
+
+// GetReachable возвращает спутники, которые могут подняться над горизонтом наблюдателя
+// на широте observerLatDeg, в порядке возрастания NORAD ID. Подспутниковая точка
+// не уходит дальше широты i' = min(i, 180° − i), а наблюдатель видит спутник
+// на земном центральном угле до λ от неё (см. FootprintCentralAngle при угле места 0°,
+// радиус орбиты в апогее — наибольшая зона). Спутник достижим, если i' + λ ≥ |широта|.
+// Фильтр отсекает спутники, для которых расчёт пролётов заведомо бесполезен.
+func (s *TLEStore) GetReachable(observerLatDeg float64) []*TLE {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var tles []*TLE
+
+	for _, tle := range s.catalog {
+		if tle.reachableFrom(observerLatDeg) {
+			tles = append(tles, tle)
+		}
+	}
+
+	slices.SortFunc(tles, func(a, b *TLE) int { return a.NoradID - b.NoradID })
+
+	return tles
+}
+
+// reachableFrom сообщает, может ли спутник подняться над горизонтом на широте latDeg (см. GetReachable).
+func (tle *TLE) reachableFrom(latDeg float64) bool {
+	maxLatDeg := math.Min(tle.Inclination, 180-tle.Inclination)
+	marginDeg := FootprintCentralAngle(tle.SemiMajorAxis()*(1+tle.Eccentricity), 0) * Rad2Deg
+
+	return maxLatDeg+marginDeg >= math.Abs(latDeg)
+}
+
+// ProximityResult описывает спутник рядом с заданным и расстояние до него.
+type ProximityResult struct {
+	NoradID        int     `json:"norad_id"`
+	Name           string  `json:"name"`
+	RangeKm        float64 `json:"range_km"`          // Расстояние в ECI, км.
+	RelVelocityKmS float64 `json:"rel_velocity_km_s"` // Модуль относительной скорости, км/с.
+}
+
+// proximityHeap — max-heap по расстоянию, хранящий n ближайших спутников.
+type proximityHeap []ProximityResult
+
+func (h proximityHeap) Len() int           { return len(h) }
+func (h proximityHeap) Less(i, j int) bool { return h[i].RangeKm > h[j].RangeKm }
+func (h proximityHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+// Push добавляет элемент (интерфейс heap.Interface).
+func (h *proximityHeap) Push(x any) { *h = append(*h, x.(ProximityResult)) }
+
+// Pop извлекает последний элемент (интерфейс heap.Interface).
+func (h *proximityHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+
+	return item
+}
+
+// NearestTo возвращает до n спутников каталога, ближайших к спутнику noradID в момент t,
+// по возрастанию расстояния. Спутники, которые не удалось пропагировать
+// (например, сошедшие с орбиты), пропускаются. Для отбора используется
+// ограниченная куча размера n, поэтому весь каталог не сортируется.
+func (s *TLEStore) NearestTo(noradID int, t time.Time, n int) ([]ProximityResult, error) {
+	target, ok := s.Get(noradID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrNotInCatalog, noradID)
+	}
+
+	targetProp, err := NewPropagator(target)
+	if err != nil {
+		return nil, err
+	}
+
+	targetPos, err := targetProp.Propagate(t)
+	if err != nil {
+		return nil, err
+	}
+
+	if n <= 0 {
+		return []ProximityResult{}, nil
+	}
+
+	h := make(proximityHeap, 0, n)
+
+	for _, tle := range s.All() {
+		if tle.NoradID == noradID {
+			continue
+		}
+
+		prop, err := NewPropagator(tle)
+		if err != nil {
+			continue
+		}
+
+		pos, err := prop.Propagate(t)
+		if err != nil {
+			continue
+		}
+
+		result := ProximityResult{
+			NoradID:        tle.NoradID,
+			Name:           tle.Name,
+			RangeKm:        eciVec(pos).sub(eciVec(targetPos)).norm(),
+			RelVelocityKmS: eciVelocity(pos).sub(eciVelocity(targetPos)).norm(),
+		}
+
+		switch {
+		case h.Len() < n:
+			heap.Push(&h, result)
+		case result.RangeKm < h[0].RangeKm:
+			h[0] = result
+			heap.Fix(&h, 0)
+		}
+	}
+
+	results := make([]ProximityResult, h.Len())
+	for i := len(results) - 1; i >= 0; i-- {
+		results[i] = heap.Pop(&h).(ProximityResult)
+	}
+
+	return results, nil
+}
+
+// SkyDensity распределяет спутники каталога, находящиеся в момент t не ниже minElDeg,
+// по сетке азимут/угол места: grid[az][el], где азимут [0, 360) делится на azBins
+// равных секторов, а угол места [minElDeg, 90] — на elBins поясов. Используется
+// для радарной диаграммы плотности неба. Пропагация каталога выполняется параллельно;
+// спутники, которые не удалось пропагировать, пропускаются.
+// При azBins или elBins меньше 1 возвращает nil.
+func (s *TLEStore) SkyDensity(obs *Observer, t time.Time, azBins, elBins int, minElDeg float64) [][]int {
+	if obs == nil || azBins < 1 || elBins < 1 || minElDeg >= 90 {
+		return nil
+	}
+
+	tles := s.All()
+	jobs := make(chan *TLE)
+	grid := newSkyGrid(azBins, elBins)
+	// Все спутники рассматриваются на один момент, поэтому GMST общий.
+	gmst := GMST(t)
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for range min(runtime.GOMAXPROCS(0), len(tles)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// Каждый обработчик накапливает свою сетку, чтобы не блокироваться на каждом спутнике.
+			local := newSkyGrid(azBins, elBins)
+
+			for tle := range jobs {
+				prop, err := NewPropagator(tle)
+				if err != nil {
+					continue
+				}
+
+				pos, err := prop.Propagate(t)
+				if err != nil {
+					continue
+				}
+
+				aer := obs.aerWithGMST(pos, gmst)
+				if az, el, ok := skyBin(aer.AzDeg(), aer.ElDeg(), azBins, elBins, minElDeg); ok {
+					local[az][el]++
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			for az := range grid {
+				for el := range grid[az] {
+					grid[az][el] += local[az][el]
+				}
+			}
+		}()
+	}
+
+	for _, tle := range tles {
+		jobs <- tle
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return grid
+}
+
+// newSkyGrid создаёт пустую сетку azBins × elBins.
+func newSkyGrid(azBins, elBins int) [][]int {
+	grid := make([][]int, azBins)
+	for az := range grid {
+		grid[az] = make([]int, elBins)
+	}
+
+	return grid
+}
+
+// skyBin возвращает индексы ячейки сетки SkyDensity для направления azDeg/elDeg;
+// ok = false, если угол места ниже minElDeg.
+func skyBin(azDeg, elDeg float64, azBins, elBins int, minElDeg float64) (az, el int, ok bool) {
+	if elDeg < minElDeg {
+		return 0, 0, false
+	}
+
+	az = int(normalizeDegrees(azDeg) / 360 * float64(azBins))
+	el = int((elDeg - minElDeg) / (90 - minElDeg) * float64(elBins))
+
+	// Граничные значения (азимут, округлённый до 360, и зенит) попадают в последнюю ячейку.
+	return min(az, azBins-1), min(el, elBins-1), true
+}
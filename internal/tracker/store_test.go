@@ -0,0 +1,881 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newCountingTLEServer создаёт mock сервер Celestrak, отдающий ISS и считающий запросы.
+func newCountingTLEServer(t *testing.T, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(issTLE))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newTestStore создаёт хранилище с mock клиентом Celestrak.
+func newTestStore(serverURL string, opts ...StoreOption) *TLEStore {
+	client := NewCelestrakClient(
+		WithBaseURL(serverURL),
+		WithRateLimit(0),
+		WithMaxRetries(0),
+	)
+
+	return NewTLEStore(append([]StoreOption{WithCelestrakClient(client)}, opts...)...)
+}
+
+// fakeTLESource — источник TLE в памяти для тестов хранилища без HTTP сервера.
+type fakeTLESource struct {
+	groups map[SatelliteGroup][]*TLE
+	err    error
+}
+
+// FetchGroup возвращает заранее заданную группу или ошибку.
+func (f *fakeTLESource) FetchGroup(_ context.Context, group SatelliteGroup) ([]*TLE, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.groups[group], nil
+}
+
+// FetchByNoradID ищет спутник во всех группах.
+func (f *fakeTLESource) FetchByNoradID(_ context.Context, noradID int) (*TLE, error) {
+	for _, tles := range f.groups {
+		for _, tle := range tles {
+			if tle.NoradID == noradID {
+				return tle, nil
+			}
+		}
+	}
+
+	return nil, ErrNotInCatalog
+}
+
+// TestTLEStore_WithTLESource проверяет загрузку групп из подставленного источника.
+func TestTLEStore_WithTLESource(t *testing.T) {
+	t.Parallel()
+
+	iss, err := ParseTLE([]string{"ISS (ZARYA)", issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	source := &fakeTLESource{groups: map[SatelliteGroup][]*TLE{GroupStations: {iss}}}
+	store := NewTLEStore(WithTLESource(source))
+
+	if err := store.LoadGroup(context.Background(), GroupStations); err != nil {
+		t.Fatalf("LoadGroup() error = %v", err)
+	}
+
+	if _, ok := store.Get(25544); !ok {
+		t.Error("Get(25544) not found after LoadGroup")
+	}
+
+	errFetch := errors.New("source unavailable")
+	source.err = errFetch
+
+	if err := store.LoadGroup(context.Background(), GroupStations); !errors.Is(err, errFetch) {
+		t.Errorf("LoadGroup() error = %v, want source error", err)
+	}
+
+	// WithCelestrakClient(nil) сохраняет прежнее поведение: используется клиент по умолчанию.
+	if _, ok := NewTLEStore(WithCelestrakClient(nil)).client.(*CelestrakClient); !ok {
+		t.Error("WithCelestrakClient(nil) should fall back to default CelestrakClient")
+	}
+}
+
+// TestTLEStore_StartLoadsGroups проверяет начальную загрузку и индексы.
+func TestTLEStore_StartLoadsGroups(t *testing.T) {
+	var requests atomic.Int32
+	server := newCountingTLEServer(t, &requests)
+
+	store := newTestStore(server.URL, WithAutoUpdate(false))
+	defer store.Stop()
+
+	if err := store.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if store.Count() != 1 {
+		t.Fatalf("Count() = %d, want 1", store.Count())
+	}
+
+	if _, ok := store.Get(25544); !ok {
+		t.Error("Get(25544) not found")
+	}
+
+	if tle, ok := store.GetByName("iss (zarya)"); !ok || tle.NoradID != 25544 {
+		t.Error("GetByName() should be case-insensitive")
+	}
+
+	if got := store.GetByGroup(GroupStations); len(got) != 1 {
+		t.Errorf("GetByGroup() returned %d TLEs, want 1", len(got))
+	}
+
+	if err := store.Start(context.Background()); err == nil {
+		t.Error("second Start() expected error")
+	}
+}
+
+// TestTLEStore_AutoUpdateDisabled проверяет, что без автообновления
+// повторная загрузка по расписанию не выполняется.
+func TestTLEStore_AutoUpdateDisabled(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []StoreOption
+	}{
+		{name: "WithAutoUpdate(false)", opts: []StoreOption{WithAutoUpdate(false), WithUpdateInterval(20 * time.Millisecond)}},
+		{name: "zero interval", opts: []StoreOption{WithUpdateInterval(0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := newCountingTLEServer(t, &requests)
+
+			store := newTestStore(server.URL, tt.opts...)
+
+			if err := store.Start(context.Background()); err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+
+			time.Sleep(100 * time.Millisecond)
+			store.Stop()
+
+			if got := requests.Load(); got != 1 {
+				t.Errorf("requests = %d, want 1 (no scheduled reload)", got)
+			}
+
+			// Ручная загрузка остаётся доступной.
+			if err := store.LoadGroup(context.Background(), GroupStations); err != nil {
+				t.Fatalf("LoadGroup() error = %v", err)
+			}
+
+			if got := requests.Load(); got != 2 {
+				t.Errorf("requests after LoadGroup = %d, want 2", got)
+			}
+		})
+	}
+}
+
+// blockingTLESource — источник TLE, который отвечает только после закрытия release.
+type blockingTLESource struct {
+	fakeTLESource
+
+	entered chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+// FetchGroup сообщает о начале запроса и ждёт release.
+func (b *blockingTLESource) FetchGroup(ctx context.Context, group SatelliteGroup) ([]*TLE, error) {
+	b.once.Do(func() { close(b.entered) })
+	<-b.release
+
+	return b.fakeTLESource.FetchGroup(ctx, group)
+}
+
+// TestTLEStore_StopDuringStart проверяет, что Stop во время начальной загрузки дожидается Start
+// и не оставляет запущенной горутины обновления (гонку wg.Add/wg.Wait ловит go test -race).
+func TestTLEStore_StopDuringStart(t *testing.T) {
+	t.Parallel()
+
+	source := &blockingTLESource{
+		fakeTLESource: fakeTLESource{groups: map[SatelliteGroup][]*TLE{GroupStations: {createTestTLE()}}},
+		entered:       make(chan struct{}),
+		release:       make(chan struct{}),
+	}
+
+	store := NewTLEStore(
+		WithTLESource(source),
+		WithGroups(GroupStations),
+		WithUpdateInterval(time.Millisecond),
+	)
+
+	started := make(chan error, 1)
+
+	go func() {
+		started <- store.Start(context.Background())
+	}()
+
+	<-source.entered
+
+	stopped := make(chan struct{})
+
+	go func() {
+		store.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		t.Fatal("Stop() returned before initial load finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(source.release)
+
+	if err := <-started; err != nil {
+		t.Errorf("Start() error = %v", err)
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop() did not return after Start finished")
+	}
+}
+
+// TestTLEStore_AutoUpdateEnabled проверяет фоновое обновление по интервалу.
+func TestTLEStore_AutoUpdateEnabled(t *testing.T) {
+	var requests atomic.Int32
+	server := newCountingTLEServer(t, &requests)
+
+	store := newTestStore(server.URL, WithUpdateInterval(20*time.Millisecond))
+
+	if err := store.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	store.Stop()
+
+	if got := requests.Load(); got < 2 {
+		t.Errorf("requests = %d, want at least 2 (scheduled reload)", got)
+	}
+
+	// Повторный Stop не должен паниковать или блокироваться.
+	store.Stop()
+}
+
+// TestTLEStore_CacheFallback проверяет загрузку из кэша при недоступности сети.
+func TestTLEStore_CacheFallback(t *testing.T) {
+	var requests atomic.Int32
+	server := newCountingTLEServer(t, &requests)
+	cacheDir := t.TempDir()
+
+	store := newTestStore(server.URL, WithCacheDir(cacheDir))
+	if err := store.LoadGroup(context.Background(), GroupStations); err != nil {
+		t.Fatalf("LoadGroup() error = %v", err)
+	}
+
+	server.Close()
+
+	offline := newTestStore(server.URL, WithCacheDir(cacheDir))
+	if err := offline.LoadGroup(context.Background(), GroupStations); err != nil {
+		t.Fatalf("LoadGroup() from cache error = %v", err)
+	}
+
+	if _, ok := offline.Get(25544); !ok {
+		t.Error("Get(25544) not found after cache fallback")
+	}
+}
+
+// TestTLEStore_NotModified проверяет, что ответ 304 на условный запрос сохраняет
+// загруженный каталог без ошибки и обновляет только время проверки группы.
+func TestTLEStore_NotModified(t *testing.T) {
+	t.Parallel()
+
+	const etag = `"iss-v1"`
+
+	var requests, notModified atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.Header.Get("If-None-Match") == etag {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT")
+		_, _ = w.Write([]byte(issTLE))
+	}))
+	t.Cleanup(server.Close)
+
+	store := newTestStore(server.URL)
+	ctx := context.Background()
+
+	if err := store.LoadGroup(ctx, GroupStations); err != nil {
+		t.Fatalf("LoadGroup() error = %v", err)
+	}
+
+	first, ok := store.GroupMeta(GroupStations)
+	if !ok {
+		t.Fatal("GroupMeta() not found after load")
+	}
+
+	if first.ETag != etag || first.LastModified == "" {
+		t.Errorf("GroupMeta() validators = %q, %q, want ETag %q and Last-Modified", first.ETag, first.LastModified, etag)
+	}
+
+	time.Sleep(time.Millisecond)
+
+	if err := store.LoadGroup(ctx, GroupStations); err != nil {
+		t.Fatalf("LoadGroup() after 304 error = %v", err)
+	}
+
+	if got := notModified.Load(); got != 1 {
+		t.Fatalf("304 responses = %d, want 1 (requests = %d)", got, requests.Load())
+	}
+
+	if store.Count() != 1 {
+		t.Errorf("Count() = %d, want 1", store.Count())
+	}
+
+	if _, ok := store.Get(25544); !ok {
+		t.Error("Get(25544) not found after 304")
+	}
+
+	second, _ := store.GroupMeta(GroupStations)
+	if !second.UpdatedAt.After(first.UpdatedAt) {
+		t.Errorf("UpdatedAt = %v, want after %v", second.UpdatedAt, first.UpdatedAt)
+	}
+
+	if second.ETag != etag {
+		t.Errorf("ETag after 304 = %q, want %q", second.ETag, etag)
+	}
+	// После удаления группы данных для 304 нет — запрос снова безусловный.
+	store.RemoveGroup(GroupStations)
+
+	if err := store.LoadGroup(ctx, GroupStations); err != nil {
+		t.Fatalf("LoadGroup() after RemoveGroup error = %v", err)
+	}
+
+	if store.Count() != 1 || notModified.Load() != 1 {
+		t.Errorf("after reload Count() = %d, 304 responses = %d, want 1 and 1", store.Count(), notModified.Load())
+	}
+
+	// Удаление последнего спутника группы тоже сбрасывает её валидаторы.
+	store.Remove(25544)
+
+	if _, ok := store.GroupMeta(GroupStations); ok {
+		t.Error("GroupMeta() found after removing every satellite of the group")
+	}
+
+	if err := store.LoadGroup(ctx, GroupStations); err != nil {
+		t.Fatalf("LoadGroup() after Remove error = %v", err)
+	}
+
+	if store.Count() != 1 || notModified.Load() != 1 {
+		t.Errorf("after Remove and reload Count() = %d, 304 responses = %d, want 1 and 1", store.Count(), notModified.Load())
+	}
+}
+
+// memStoreBackend — постоянное хранилище групп в памяти для тестов.
+type memStoreBackend struct {
+	mu     sync.Mutex
+	groups map[SatelliteGroup][]*TLE
+}
+
+// SaveGroup сохраняет копию состава группы.
+func (m *memStoreBackend) SaveGroup(_ context.Context, group SatelliteGroup, tles []*TLE) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.groups[group] = append([]*TLE(nil), tles...)
+
+	return nil
+}
+
+// LoadGroup возвращает сохранённую группу или ErrGroupNotStored.
+func (m *memStoreBackend) LoadGroup(_ context.Context, group SatelliteGroup) ([]*TLE, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tles, ok := m.groups[group]
+	if !ok {
+		return nil, ErrGroupNotStored
+	}
+
+	return tles, nil
+}
+
+// TestTLEStore_StoreBackend проверяет, что при заданном StoreBackend группы сохраняются в него
+// вместо файлового кэша и читаются из него при недоступности источника.
+func TestTLEStore_StoreBackend(t *testing.T) {
+	t.Parallel()
+
+	backend := &memStoreBackend{groups: make(map[SatelliteGroup][]*TLE)}
+	cacheDir := t.TempDir()
+
+	online := NewTLEStore(
+		WithTLESource(&fakeTLESource{groups: map[SatelliteGroup][]*TLE{GroupStations: {createTestTLE()}}}),
+		WithStoreBackend(backend),
+		WithCacheDir(cacheDir),
+	)
+	if err := online.LoadGroup(context.Background(), GroupStations); err != nil {
+		t.Fatalf("LoadGroup() error = %v", err)
+	}
+
+	if len(backend.groups[GroupStations]) != 1 {
+		t.Fatalf("backend stations = %d TLEs, want 1", len(backend.groups[GroupStations]))
+	}
+
+	if _, err := os.Stat(online.cachePath(GroupStations)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("file cache written with StoreBackend set: Stat() error = %v", err)
+	}
+
+	offline := NewTLEStore(
+		WithTLESource(&fakeTLESource{err: ErrCelestrakServerError}),
+		WithStoreBackend(backend),
+	)
+	if err := offline.LoadGroup(context.Background(), GroupStations); err != nil {
+		t.Fatalf("LoadGroup() from backend error = %v", err)
+	}
+
+	if _, ok := offline.Get(25544); !ok {
+		t.Error("Get(25544) not found after backend fallback")
+	}
+
+	if err := offline.LoadGroup(context.Background(), GroupWeather); !errors.Is(err, ErrCelestrakServerError) {
+		t.Errorf("LoadGroup(%s) error = %v, want fetch error", GroupWeather, err)
+	}
+}
+
+// TestTLEStore_ParsedCache проверяет, что неизменённый файл кэша парсится один раз,
+// а изменение времени модификации сбрасывает разобранный кэш.
+func TestTLEStore_ParsedCache(t *testing.T) {
+	var requests atomic.Int32
+	server := newCountingTLEServer(t, &requests)
+	cacheDir := t.TempDir()
+
+	store := newTestStore(server.URL, WithCacheDir(cacheDir))
+	if err := store.LoadGroup(context.Background(), GroupStations); err != nil {
+		t.Fatalf("LoadGroup() error = %v", err)
+	}
+
+	var parses atomic.Int32
+
+	cached := newTestStore(server.URL, WithCacheDir(cacheDir), WithParsedCache(true))
+	cached.parseBatch = func(data string) ([]*TLE, error) {
+		parses.Add(1)
+		return ParseTLEBatch(data)
+	}
+
+	for range 2 {
+		tles, err := cached.loadGroupFromCache(context.Background(), GroupStations)
+		if err != nil {
+			t.Fatalf("loadGroupFromCache() error = %v", err)
+		}
+
+		if len(tles) != 1 {
+			t.Fatalf("loadGroupFromCache() returned %d TLEs, want 1", len(tles))
+		}
+	}
+
+	if got := parses.Load(); got != 1 {
+		t.Errorf("parses = %d, want 1 for unchanged file", got)
+	}
+
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(cached.cachePath(GroupStations), later, later); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	if _, err := cached.loadGroupFromCache(context.Background(), GroupStations); err != nil {
+		t.Fatalf("loadGroupFromCache() after mtime change error = %v", err)
+	}
+
+	if got := parses.Load(); got != 2 {
+		t.Errorf("parses after mtime change = %d, want 2", got)
+	}
+}
+
+// issVariant возвращает копию TLE ISS с другим NORAD ID и средней аномалией.
+func issVariant(t *testing.T, noradID, meanAnomaly string) *TLE {
+	t.Helper()
+
+	line1 := makeTLELine("1 " + noradID + "U 98067A   24001.50000000  .00016717  00000-0  10270-3 0  999")
+	line2 := makeTLELine("2 " + noradID + "  51.6400 247.4627 0006703 130.5360 " + meanAnomaly + " 15.4981557142340")
+
+	tle, err := ParseTLE([]string{"VARIANT " + noradID, line1, line2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	return tle
+}
+
+// TestTLEStore_NearestTo проверяет отбор ближайших спутников по расстоянию.
+func TestTLEStore_NearestTo(t *testing.T) {
+	t.Parallel()
+
+	iss, err := ParseTLE([]string{"ISS (ZARYA)", issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	store := NewTLEStore()
+	store.Add(iss)
+	store.Add(issVariant(t, "90001", "335.0288")) // ~10° по орбите, ~1200 км.
+	store.Add(issVariant(t, "90002", "326.0288")) // ~1° по орбите, ~120 км.
+	store.Add(issVariant(t, "90003", "145.0288")) // Противоположная сторона орбиты.
+
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	results, err := store.NearestTo(25544, at, 2)
+	if err != nil {
+		t.Fatalf("NearestTo() error = %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("NearestTo() returned %d results, want 2", len(results))
+	}
+
+	if results[0].NoradID != 90002 || results[1].NoradID != 90001 {
+		t.Errorf("NearestTo() order = %d, %d, want 90002, 90001", results[0].NoradID, results[1].NoradID)
+	}
+
+	if results[0].RangeKm < 100 || results[0].RangeKm > 140 {
+		t.Errorf("nearest range = %.1f km, want ~120 km", results[0].RangeKm)
+	}
+
+	// В одной плоскости с почти одинаковой скоростью относительная скорость мала.
+	if results[0].RelVelocityKmS > 0.5 {
+		t.Errorf("nearest relative velocity = %.3f km/s, want < 0.5", results[0].RelVelocityKmS)
+	}
+
+	if _, err := store.NearestTo(12345, at, 2); !errors.Is(err, ErrNotInCatalog) {
+		t.Errorf("NearestTo(unknown) error = %v, want ErrNotInCatalog", err)
+	}
+}
+
+// TestTLEStore_Rename проверяет, что после переименования спутник находится
+// и по новому, и по прежнему имени, а переименование попадает в лог.
+func TestTLEStore_Rename(t *testing.T) {
+	t.Parallel()
+
+	var logs strings.Builder
+
+	store := NewTLEStore(WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+
+	before, err := ParseTLE([]string{"OBJECT A", issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	after, err := ParseTLE([]string{"ISS (ZARYA)", issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	store.Add(before)
+	store.Add(after)
+
+	for _, name := range []string{"ISS (ZARYA)", "OBJECT A"} {
+		tle, ok := store.GetByName(name)
+		if !ok || tle != after {
+			t.Errorf("GetByName(%q) = %v, %v, want current TLE", name, tle, ok)
+		}
+	}
+
+	if !strings.Contains(logs.String(), "satellite renamed") || !strings.Contains(logs.String(), "old_name=\"OBJECT A\"") {
+		t.Errorf("rename not logged, got: %s", logs.String())
+	}
+}
+
+// assertStoreIndexesConsistent проверяет, что индексы групп и имён не ссылаются
+// на отсутствующие в каталоге спутники.
+func assertStoreIndexesConsistent(t *testing.T, store *TLEStore) {
+	t.Helper()
+
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	for name, id := range store.byName {
+		if _, ok := store.catalog[id]; !ok {
+			t.Errorf("byName[%q] = %d points to missing catalog entry", name, id)
+		}
+	}
+
+	for group, ids := range store.byGroup {
+		for _, id := range ids {
+			if _, ok := store.catalog[id]; !ok {
+				t.Errorf("byGroup[%s] contains missing NORAD %d", group, id)
+			}
+		}
+	}
+}
+
+// TestTLEStore_Remove проверяет удаление спутника, группы и устаревших TLE
+// с очисткой индексов.
+func TestTLEStore_Remove(t *testing.T) {
+	t.Parallel()
+
+	newStore := func(t *testing.T) *TLEStore {
+		t.Helper()
+
+		store := NewTLEStore()
+		store.replaceGroup(GroupStations, []*TLE{issVariant(t, "25544", "229.6000"), issVariant(t, "40001", "229.6000")})
+		store.replaceGroup(GroupAmateur, []*TLE{issVariant(t, "40001", "229.6000"), issVariant(t, "40002", "229.6000")})
+
+		// Переименование оставляет псевдоним в byName.
+		renamed := issVariant(t, "25544", "229.6000")
+		renamed.Name = "ISS (ZARYA)"
+		store.Add(renamed)
+
+		return store
+	}
+
+	t.Run("remove", func(t *testing.T) {
+		t.Parallel()
+
+		store := newStore(t)
+
+		if !store.Remove(25544) {
+			t.Fatal("Remove(25544) = false, want true")
+		}
+
+		if store.Remove(25544) {
+			t.Error("second Remove(25544) = true, want false")
+		}
+
+		for _, name := range []string{"VARIANT 25544", "ISS (ZARYA)"} {
+			if _, ok := store.GetByName(name); ok {
+				t.Errorf("GetByName(%q) found after Remove", name)
+			}
+		}
+
+		if got := len(store.GetByGroup(GroupStations)); got != 1 {
+			t.Errorf("stations size = %d, want 1", got)
+		}
+
+		assertStoreIndexesConsistent(t, store)
+	})
+
+	t.Run("remove group keeps shared satellites", func(t *testing.T) {
+		t.Parallel()
+
+		store := newStore(t)
+
+		if got := store.RemoveGroup(GroupAmateur); got != 1 {
+			t.Errorf("RemoveGroup() = %d, want 1 (40001 is also in stations)", got)
+		}
+
+		if _, ok := store.Get(40001); !ok {
+			t.Error("Get(40001) not found, shared satellite must stay")
+		}
+
+		if _, ok := store.Get(40002); ok {
+			t.Error("Get(40002) found after RemoveGroup")
+		}
+
+		if store.Count() != 2 {
+			t.Errorf("Count() = %d, want 2", store.Count())
+		}
+
+		assertStoreIndexesConsistent(t, store)
+	})
+
+	t.Run("prune stale", func(t *testing.T) {
+		t.Parallel()
+
+		store := newStore(t)
+
+		fresh := issVariant(t, "40003", "229.6000")
+		fresh.Epoch = time.Now().Add(-time.Hour)
+		store.Add(fresh)
+
+		if got := store.PruneStale(30); got != 3 {
+			t.Errorf("PruneStale(30) = %d, want 3", got)
+		}
+
+		if _, ok := store.Get(40003); !ok || store.Count() != 1 {
+			t.Errorf("after PruneStale Count() = %d, want only fresh 40003", store.Count())
+		}
+
+		assertStoreIndexesConsistent(t, store)
+	})
+}
+
+// TestTLEStore_List проверяет сортировку по высоте, пагинацию и общее число спутников.
+func TestTLEStore_List(t *testing.T) {
+	t.Parallel()
+
+	store := NewTLEStore()
+
+	// NORAD ID → mean motion (оборотов/сутки): чем больше, тем ниже орбита.
+	meanMotions := map[string]float64{"40001": 14.2, "40002": 15.9, "40003": 15.5, "40004": 12.0, "40005": 15.9}
+	for id, n := range meanMotions {
+		tle := issVariant(t, id, "229.6000")
+		tle.MeanMotion = n
+		store.Add(tle)
+	}
+
+	page, total := store.List(SortByAltitude, 0, 3)
+	if total != len(meanMotions) {
+		t.Errorf("List() total = %d, want %d", total, len(meanMotions))
+	}
+
+	// Равные высоты 40002 и 40005 упорядочены по NORAD ID.
+	want := []int{40002, 40005, 40003}
+	if got := noradIDs(page); !reflect.DeepEqual(got, want) {
+		t.Errorf("List(SortByAltitude, 0, 3) = %v, want %v", got, want)
+	}
+
+	next, _ := store.List(SortByAltitude, 3, 3)
+	if got := noradIDs(next); !reflect.DeepEqual(got, []int{40001, 40004}) {
+		t.Errorf("List(SortByAltitude, 3, 3) = %v, want [40001 40004]", got)
+	}
+
+	if empty, total := store.List(SortByNoradID, 10, 3); len(empty) != 0 || total != len(meanMotions) {
+		t.Errorf("List() past end = %d TLEs, total %d", len(empty), total)
+	}
+
+	// offset+limit переполнил бы int.
+	if rest, _ := store.List(SortByNoradID, 2, math.MaxInt); len(rest) != len(meanMotions)-2 {
+		t.Errorf("List(SortByNoradID, 2, MaxInt) = %d TLEs, want %d", len(rest), len(meanMotions)-2)
+	}
+}
+
+// noradIDs возвращает NORAD ID списка TLE.
+func noradIDs(tles []*TLE) []int {
+	ids := make([]int, 0, len(tles))
+	for _, tle := range tles {
+		ids = append(ids, tle.NoradID)
+	}
+
+	return ids
+}
+
+// TestTLEStore_GetByOrbitClass проверяет выборку каталога по классу орбиты,
+// в том числе распознавание HEO для орбиты типа «Молния».
+func TestTLEStore_GetByOrbitClass(t *testing.T) {
+	t.Parallel()
+
+	molniya := &TLE{NoradID: 40296, Name: "MOLNIYA 2-10", MeanMotion: 2.0064, Eccentricity: 0.73, Inclination: 62.8}
+	if got := molniya.OrbitClass(); got != RegimeHEO {
+		t.Fatalf("OrbitClass(Molniya) = %v, want HEO", got)
+	}
+
+	store := NewTLEStore()
+	for _, tle := range []*TLE{regimeTestLEO, regimeTestMEO, regimeTestGEO, regimeTestHEO, molniya} {
+		store.Add(tle)
+	}
+
+	heo := store.GetByOrbitClass(RegimeHEO)
+	if len(heo) != 2 || heo[0].NoradID != regimeTestHEO.NoradID || heo[1].NoradID != molniya.NoradID {
+		t.Errorf("GetByOrbitClass(HEO) = %v, want [%d %d]", noradIDs(heo), regimeTestHEO.NoradID, molniya.NoradID)
+	}
+
+	if geo := store.GetByOrbitClass(RegimeGEO); len(geo) != 1 || geo[0].NoradID != regimeTestGEO.NoradID {
+		t.Errorf("GetByOrbitClass(GEO) = %v, want [%d]", noradIDs(geo), regimeTestGEO.NoradID)
+	}
+
+	if unknown := store.GetByOrbitClass(RegimeUnknown); len(unknown) != 0 {
+		t.Errorf("GetByOrbitClass(Unknown) = %v, want empty", noradIDs(unknown))
+	}
+}
+
+// TestTLEStore_GetReachable проверяет отсечение спутников, не поднимающихся
+// над горизонтом на широте наблюдателя.
+func TestTLEStore_GetReachable(t *testing.T) {
+	t.Parallel()
+
+	store := NewTLEStore()
+	store.Add(regimeTestLEO)                                               // МКС, i = 51.6°.
+	store.Add(&TLE{NoradID: 41866, MeanMotion: 1.0027, Inclination: 0.05}) // GEO, зона ~81°.
+	store.Add(&TLE{NoradID: 43013, MeanMotion: 14.2, Inclination: 98.7})   // Солнечно-синхронная.
+	store.Add(&TLE{NoradID: 99001, MeanMotion: 15.5, Inclination: 130})    // Ретроградная, i' = 50°.
+
+	tests := []struct {
+		name   string
+		latDeg float64
+		want   []int
+	}{
+		{name: "50N", latDeg: 50, want: []int{25544, 41866, 43013, 99001}},
+		{name: "75N excludes ISS", latDeg: 75, want: []int{41866, 43013}},
+		{name: "75S is symmetric", latDeg: -75, want: []int{41866, 43013}},
+		{name: "pole", latDeg: 90, want: []int{43013}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := noradIDs(store.GetReachable(tt.latDeg)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetReachable(%v) = %v, want %v", tt.latDeg, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTLEStore_SkyDensity проверяет распределение спутников над горизонтом по сетке az/el.
+func TestTLEStore_SkyDensity(t *testing.T) {
+	t.Parallel()
+
+	iss, err := ParseTLE([]string{"ISS (ZARYA)", issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	store := NewTLEStore()
+	store.Add(iss)
+	store.Add(issVariant(t, "90001", "335.0288"))
+	store.Add(issVariant(t, "90002", "326.0288"))
+	store.Add(issVariant(t, "90003", "145.0288"))
+
+	const (
+		azBins = 12
+		elBins = 6
+	)
+
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	want := newSkyGrid(azBins, elBins)
+	visible := 0
+
+	for _, tle := range store.All() {
+		prop, err := NewPropagator(tle)
+		if err != nil {
+			t.Fatalf("NewPropagator() error = %v", err)
+		}
+
+		pos, err := prop.Propagate(at)
+		if err != nil {
+			t.Fatalf("Propagate() error = %v", err)
+		}
+
+		aer := passTestMoscow.GetAER(pos)
+		if aer.ElDeg() < 0 {
+			continue
+		}
+
+		visible++
+		want[int(aer.AzDeg()/30)][int(aer.ElDeg()/15)]++
+	}
+
+	if visible == 0 || visible == store.Count() {
+		t.Fatalf("visible = %d of %d, test needs some satellites on both sides of the horizon", visible, store.Count())
+	}
+
+	got := store.SkyDensity(passTestMoscow, at, azBins, elBins, 0)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SkyDensity() = %v, want %v", got, want)
+	}
+
+	if got := store.SkyDensity(passTestMoscow, at, 0, elBins, 0); got != nil {
+		t.Errorf("SkyDensity(azBins=0) = %v, want nil", got)
+	}
+}
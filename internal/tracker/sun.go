@@ -0,0 +1,190 @@
+package tracker
+
+import (
+	"math"
+	"time"
+)
+
+// Константы солнечной эфемериды.
+const (
+	// AstronomicalUnitKm — астрономическая единица, км.
+	AstronomicalUnitKm = 149597870.7
+
+	// j2000JulianDay — юлианская дата эпохи J2000.0.
+	j2000JulianDay = 2451545.0
+)
+
+// SunPositionECI возвращает положение Солнца в экваториальной геоцентрической
+// системе (совместимой с ECI/TEME с точностью маловажной для видимости), км.
+// Используется упрощённая эфемерида Astronomical Almanac (точность ~0.01°).
+func SunPositionECI(t time.Time) *ECIPosition {
+	n := JulianDay(t) - j2000JulianDay
+
+	// Средняя долгота и средняя аномалия Солнца, градусы.
+	meanLon := 280.460 + 0.9856474*n
+	meanAnomaly := (357.528 + 0.9856003*n) * Deg2Rad
+
+	// Эклиптическая долгота и наклон эклиптики.
+	eclLon := (meanLon + 1.915*math.Sin(meanAnomaly) + 0.020*math.Sin(2*meanAnomaly)) * Deg2Rad
+	obliquity := (23.439 - 0.0000004*n) * Deg2Rad
+
+	// Расстояние до Солнца, а.е.
+	r := (1.00014 - 0.01671*math.Cos(meanAnomaly) - 0.00014*math.Cos(2*meanAnomaly)) * AstronomicalUnitKm
+
+	return &ECIPosition{
+		X:    r * math.Cos(eclLon),
+		Y:    r * math.Cos(obliquity) * math.Sin(eclLon),
+		Z:    r * math.Sin(obliquity) * math.Sin(eclLon),
+		Time: t,
+	}
+}
+
+// SubsolarPoint возвращает подсолнечную точку — место на поверхности Земли,
+// где Солнце в зените в момент t. Широта геоцентрическая (равна склонению Солнца),
+// долгота получена поворотом на GMST. Высота равна 0. Углы в радианах.
+func SubsolarPoint(t time.Time) *LLA {
+	sun := ECIToECEF(SunPositionECI(t))
+
+	return &LLA{
+		Lat: math.Atan2(sun.Z, math.Hypot(sun.X, sun.Y)),
+		Lon: math.Atan2(sun.Y, sun.X),
+	}
+}
+
+// Terminator возвращает линию терминатора (граница дня и ночи) в момент t:
+// большой круг на 90° от подсолнечной точки из points точек, разбитый
+// на сегменты по антимеридиану для отрисовки на карте.
+func Terminator(t time.Time, points int) [][]TrackPoint {
+	if points < 3 {
+		return nil
+	}
+
+	sub := SubsolarPoint(t)
+	center := TrackPoint{Lat: sub.LatDeg(), Lon: sub.LonDeg(), Time: t}
+
+	return footprintRing(center, math.Pi/2, points)
+}
+
+// SunGlintAngle возвращает угол (градусы) между направлением зеркального отражения
+// Солнца от поверхности в точке targetLLA и направлением из этой точки на спутник.
+// Малый угол (единицы градусов) означает риск солнечного блика на снимке
+// водной поверхности или льда. Поверхность считается плоской, нормаль — геодезическая вертикаль.
+func SunGlintAngle(sat *ECIPosition, targetLLA *LLA, t time.Time) float64 {
+	if sat == nil || targetLLA == nil {
+		return math.NaN()
+	}
+
+	targetECEF := LLAToECEF(targetLLA)
+	targetECEF.Time = t
+	target := eciVec(ECEFToECI(targetECEF))
+
+	// Геодезическая нормаль в ECEF, повёрнутая в ECI.
+	normalECEF := &ECEFPosition{
+		X:    math.Cos(targetLLA.Lat) * math.Cos(targetLLA.Lon),
+		Y:    math.Cos(targetLLA.Lat) * math.Sin(targetLLA.Lon),
+		Z:    math.Sin(targetLLA.Lat),
+		Time: t,
+	}
+	normal := eciVec(ECEFToECI(normalECEF))
+
+	// Направление на Солнце и зеркально отражённый луч: r = 2(s·n)n − s.
+	sun := eciVec(SunPositionECI(t)).sub(target).unit()
+	reflected := normal.scale(2 * sun.dot(normal)).sub(sun)
+
+	toSat := eciVec(sat).sub(target)
+
+	return angleBetween(reflected, toSat) * Rad2Deg
+}
+
+// SolarIncidenceNadir возвращает угол падения солнечных лучей (градусы) на верхнюю
+// панель спутника с ориентацией в надир: угол между направлением со спутника
+// на Солнце и его зенитной осью (радиус-вектором). 0° — Солнце в зените спутника
+// (максимальная мощность), больше 90° — панель не освещена. Тень Земли не учитывается.
+func SolarIncidenceNadir(sat *ECIPosition, t time.Time) float64 {
+	if sat == nil {
+		return math.NaN()
+	}
+
+	zenith := eciVec(sat)
+	toSun := eciVec(SunPositionECI(t)).sub(zenith)
+
+	return angleBetween(toSun, zenith) * Rad2Deg
+}
+
+// IsSunlit возвращает true, если спутник освещён Солнцем.
+// Используется цилиндрическая модель тени: спутник в тени, если он находится
+// на ночной стороне Земли и его расстояние до оси Земля–Солнце меньше радиуса Земли.
+func IsSunlit(eci *ECIPosition) bool {
+	if eci == nil {
+		return false
+	}
+
+	sunDir := eciVec(SunPositionECI(eci.Time)).unit()
+	sat := eciVec(eci)
+
+	// Проекция на направление Солнца: положительная — дневная сторона.
+	along := sat.dot(sunDir)
+	if along >= 0 {
+		return true
+	}
+
+	perpendicular := sat.sub(sunDir.scale(along)).norm()
+
+	return perpendicular > WGS84A
+}
+
+// Illumination — освещённость спутника с учётом конической модели тени Земли.
+type Illumination int
+
+// Состояния освещённости.
+const (
+	IlluminationSunlit   Illumination = iota // Солнечный диск виден полностью.
+	IlluminationPenumbra                     // Полутень: Земля закрывает часть диска.
+	IlluminationUmbra                        // Тень: диск закрыт полностью.
+)
+
+// String возвращает название состояния освещённости.
+func (i Illumination) String() string {
+	switch i {
+	case IlluminationSunlit:
+		return "sunlit"
+	case IlluminationPenumbra:
+		return "penumbra"
+	case IlluminationUmbra:
+		return "umbra"
+	default:
+		return "unknown"
+	}
+}
+
+// IlluminationState определяет освещённость спутника по конической модели тени:
+// сравнивается угол между направлениями со спутника на центр Земли и на Солнце
+// с видимыми угловыми радиусами Земли и Солнца. В отличие от IsSunlit различает
+// полутень, что важно для расчёта энергобаланса на входе и выходе из тени.
+func IlluminationState(eci *ECIPosition) Illumination {
+	if eci == nil {
+		return IlluminationUmbra
+	}
+
+	sat := eciVec(eci)
+	toSun := eciVec(SunPositionECI(eci.Time)).sub(sat)
+	toEarth := sat.scale(-1)
+
+	earthRadius := math.Asin(math.Min(1, WGS84A/sat.norm()))
+	sunRadius := math.Asin(SunRadiusKm / toSun.norm())
+	separation := angleBetween(toEarth, toSun)
+
+	switch {
+	case separation >= earthRadius+sunRadius:
+		return IlluminationSunlit
+	case separation > earthRadius-sunRadius:
+		return IlluminationPenumbra
+	default:
+		return IlluminationUmbra
+	}
+}
+
+// SunElevationDeg возвращает угол места Солнца для наблюдателя в градусах.
+func (obs *Observer) SunElevationDeg(t time.Time) float64 {
+	return obs.GetAER(SunPositionECI(t)).ElDeg()
+}
@@ -0,0 +1,187 @@
+package tracker
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestSunPositionECI проверяет расстояние и склонение Солнца на солнцестояние.
+func TestSunPositionECI(t *testing.T) {
+	solstice := time.Date(2024, 6, 20, 20, 51, 0, 0, time.UTC)
+	sun := SunPositionECI(solstice)
+
+	if dist := sun.Magnitude() / AstronomicalUnitKm; dist < 1.01 || dist > 1.02 {
+		t.Errorf("Sun distance = %.4f AU, want ~1.016 in June", dist)
+	}
+
+	declination := eciVec(sun).unit().Z
+	if !almostEqual(declination, 0.3978, 0.001) { // sin(23.44°).
+		t.Errorf("sin(declination) = %.4f, want ~0.3978", declination)
+	}
+}
+
+// TestSunGlintAngle проверяет малый угол блика в зеркальном направлении.
+func TestSunGlintAngle(t *testing.T) {
+	testTime := time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+	target := NewLLAFromDegrees(10, 5, 0)
+
+	targetECEF := LLAToECEF(target)
+	targetECEF.Time = testTime
+	targetECI := eciVec(ECEFToECI(targetECEF))
+
+	normalECEF := &ECEFPosition{X: targetECEF.X, Y: targetECEF.Y, Z: targetECEF.Z, Time: testTime}
+	normal := eciVec(ECEFToECI(normalECEF)).unit()
+	sun := eciVec(SunPositionECI(testTime)).sub(targetECI).unit()
+	reflected := normal.scale(2 * sun.dot(normal)).sub(sun)
+
+	// Спутник ровно в зеркальном направлении на расстоянии 700 км.
+	specular := targetECI.add(reflected.scale(700))
+	sat := &ECIPosition{X: specular.X, Y: specular.Y, Z: specular.Z, Time: testTime}
+
+	// Небольшое отличие нормали (геоцентрическая vs геодезическая) даёт погрешность < 1°.
+	if angle := SunGlintAngle(sat, target, testTime); angle > 1 {
+		t.Errorf("SunGlintAngle() in specular direction = %.3f°, want < 1°", angle)
+	}
+
+	// Спутник в противоположной от отражения стороне неба.
+	away := targetECI.add(normal.scale(700)).sub(reflected.scale(500))
+	satAway := &ECIPosition{X: away.X, Y: away.Y, Z: away.Z, Time: testTime}
+
+	if angle := SunGlintAngle(satAway, target, testTime); angle < 30 {
+		t.Errorf("SunGlintAngle() away from specular = %.3f°, want > 30°", angle)
+	}
+}
+
+// TestIsSunlit проверяет цилиндрическую модель тени.
+func TestIsSunlit(t *testing.T) {
+	testTime := time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+	sunDir := eciVec(SunPositionECI(testTime)).unit()
+
+	const radius = 6371 + 420.0
+
+	dayside := sunDir.scale(radius)
+	nightside := sunDir.scale(-radius)
+
+	if !IsSunlit(&ECIPosition{X: dayside.X, Y: dayside.Y, Z: dayside.Z, Time: testTime}) {
+		t.Error("satellite between Earth and Sun should be sunlit")
+	}
+
+	if IsSunlit(&ECIPosition{X: nightside.X, Y: nightside.Y, Z: nightside.Z, Time: testTime}) {
+		t.Error("satellite directly behind Earth should be in shadow")
+	}
+
+	// Над полюсом в равноденствие — вне цилиндра тени.
+	if !IsSunlit(&ECIPosition{Z: radius + 500, Time: testTime}) {
+		t.Error("satellite high above the pole at equinox should be sunlit")
+	}
+}
+
+// TestSolarIncidenceNadir проверяет угол падения на верхнюю панель спутника.
+func TestSolarIncidenceNadir(t *testing.T) {
+	testTime := time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC)
+	sunDir := eciVec(SunPositionECI(testTime)).unit()
+
+	const radius = 6371 + 550.0
+
+	tests := []struct {
+		name string
+		pos  vec3
+		want float64
+	}{
+		{name: "sun at satellite zenith", pos: sunDir.scale(radius), want: 0},
+		{name: "sun at satellite nadir", pos: sunDir.scale(-radius), want: 180},
+		{name: "sun on the horizon", pos: sunDir.cross(vec3{Z: 1}).unit().scale(radius), want: 90},
+	}
+
+	for _, tt := range tests {
+		sat := &ECIPosition{X: tt.pos.X, Y: tt.pos.Y, Z: tt.pos.Z, Time: testTime}
+		if got := SolarIncidenceNadir(sat, testTime); !almostEqual(got, tt.want, 0.01) {
+			t.Errorf("%s: SolarIncidenceNadir() = %.4f°, want %.0f°", tt.name, got, tt.want)
+		}
+	}
+
+	if !math.IsNaN(SolarIncidenceNadir(nil, testTime)) {
+		t.Error("SolarIncidenceNadir(nil) should return NaN")
+	}
+}
+
+// TestSubsolarPoint проверяет подсолнечную точку в полдень UTC в день равноденствия.
+func TestSubsolarPoint(t *testing.T) {
+	t.Parallel()
+
+	// 20 марта 2024, 12:00 UTC: склонение ~0.2°, уравнение времени ~−7.5 мин (~1.9° к востоку).
+	sub := SubsolarPoint(time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC))
+
+	if math.Abs(sub.LatDeg()) > 0.5 {
+		t.Errorf("subsolar latitude = %.3f°, want ~0°", sub.LatDeg())
+	}
+
+	if math.Abs(sub.LonDeg()) > 3 {
+		t.Errorf("subsolar longitude = %.3f°, want ~0°", sub.LonDeg())
+	}
+
+	// В солнцестояние Солнце над тропиком Рака.
+	if lat := SubsolarPoint(time.Date(2024, 6, 20, 20, 51, 0, 0, time.UTC)).LatDeg(); !almostEqual(lat, 23.44, 0.05) {
+		t.Errorf("solstice subsolar latitude = %.3f°, want ~23.44°", lat)
+	}
+}
+
+// TestTerminator проверяет, что точки терминатора отстоят от подсолнечной точки на 90°.
+func TestTerminator(t *testing.T) {
+	t.Parallel()
+
+	testTime := time.Date(2024, 6, 20, 20, 51, 0, 0, time.UTC)
+	sub := SubsolarPoint(testTime)
+	subDir := surfaceDir(sub.LatDeg(), sub.LonDeg())
+
+	segments := Terminator(testTime, 180)
+	if len(segments) == 0 {
+		t.Fatal("Terminator() returned no segments")
+	}
+
+	for _, seg := range segments {
+		for _, p := range seg {
+			// Граничные точки на ±180° интерполируются линейно, отсюда допуск.
+			if got := angleBetween(subDir, surfaceDir(p.Lat, p.Lon)) * Rad2Deg; !almostEqual(got, 90, 0.05) {
+				t.Errorf("terminator point (%.2f, %.2f) is %.4f° from sub-solar point", p.Lat, p.Lon, got)
+			}
+		}
+	}
+
+	if Terminator(testTime, 2) != nil {
+		t.Error("Terminator() with fewer than 3 points should return nil")
+	}
+}
+
+// TestIlluminationState проверяет освещённость МКС в местный полдень, в местную
+// полночь и на границе тени.
+func TestIlluminationState(t *testing.T) {
+	t.Parallel()
+
+	testTime := time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+	sunDir := eciVec(SunPositionECI(testTime)).unit()
+
+	const radius = 6371 + 420.0
+
+	// Граница цилиндра тени: расстояние до оси Земля–Солнце равно радиусу Земли.
+	side := sunDir.cross(vec3{Z: 1}).unit()
+	edge := sunDir.scale(-math.Sqrt(radius*radius - WGS84A*WGS84A)).add(side.scale(WGS84A))
+
+	tests := []struct {
+		name string
+		pos  vec3
+		want Illumination
+	}{
+		{name: "local noon", pos: sunDir.scale(radius), want: IlluminationSunlit},
+		{name: "local midnight", pos: sunDir.scale(-radius), want: IlluminationUmbra},
+		{name: "shadow edge", pos: edge, want: IlluminationPenumbra},
+	}
+
+	for _, tt := range tests {
+		sat := &ECIPosition{X: tt.pos.X, Y: tt.pos.Y, Z: tt.pos.Z, Time: testTime}
+		if got := IlluminationState(sat); got != tt.want {
+			t.Errorf("%s: IlluminationState() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
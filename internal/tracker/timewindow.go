@@ -0,0 +1,50 @@
+package tracker
+
+import (
+	"sort"
+	"time"
+)
+
+// TimeWindow — интервал времени [Start, End].
+type TimeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration возвращает длительность интервала.
+func (w TimeWindow) Duration() time.Duration {
+	return w.End.Sub(w.Start)
+}
+
+// Contains проверяет, что момент t лежит внутри интервала (включая границы).
+func (w TimeWindow) Contains(t time.Time) bool {
+	return !t.Before(w.Start) && !t.After(w.End)
+}
+
+// MergeTimeWindows объединяет пересекающиеся и соприкасающиеся интервалы.
+// Пустые и вырожденные интервалы (End раньше Start) отбрасываются.
+// Результат упорядочен по началу; входной слайс не изменяется.
+func MergeTimeWindows(windows []TimeWindow) []TimeWindow {
+	sorted := make([]TimeWindow, 0, len(windows))
+
+	for _, w := range windows {
+		if w.End.After(w.Start) {
+			sorted = append(sorted, w)
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	var merged []TimeWindow
+
+	for _, w := range sorted {
+		if n := len(merged); n > 0 && !w.Start.After(merged[n-1].End) {
+			merged[n-1].End = maxTime(merged[n-1].End, w.End)
+			continue
+		}
+
+		merged = append(merged, w)
+	}
+
+	return merged
+}
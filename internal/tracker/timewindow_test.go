@@ -0,0 +1,37 @@
+package tracker
+
+import (
+	"testing"
+	"time"
+)
+
+// TestMergeTimeWindows проверяет объединение пересекающихся и соприкасающихся интервалов.
+func TestMergeTimeWindows(t *testing.T) {
+	t.Parallel()
+
+	at := func(minutes int) time.Time { return passTestStart.Add(time.Duration(minutes) * time.Minute) }
+
+	got := MergeTimeWindows([]TimeWindow{
+		{Start: at(30), End: at(40)},
+		{Start: at(0), End: at(10)},
+		{Start: at(5), End: at(15)},  // Пересекается с первым.
+		{Start: at(15), End: at(20)}, // Соприкасается.
+		{Start: at(50), End: at(45)}, // Вырожденный.
+	})
+
+	want := []TimeWindow{{Start: at(0), End: at(20)}, {Start: at(30), End: at(40)}}
+
+	if len(got) != len(want) {
+		t.Fatalf("MergeTimeWindows() = %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+			t.Errorf("window[%d] = %v-%v, want %v-%v", i, got[i].Start, got[i].End, want[i].Start, want[i].End)
+		}
+	}
+
+	if !got[0].Contains(at(20)) || got[0].Contains(at(25)) {
+		t.Error("Contains() should include the end boundary and exclude later times")
+	}
+}
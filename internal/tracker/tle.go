@@ -19,8 +19,61 @@ var (
 	ErrNoradIDMismatch   = errors.New("NORAD ID mismatch between lines")
 	ErrInvalidAlpha5     = errors.New("invalid Alpha-5 NORAD ID format")
 	ErrEpochTooShort     = errors.New("epoch string too short")
+	ErrSuspiciousEpoch   = errors.New("suspicious TLE epoch")
+	ErrImplausibleTLE    = errors.New("implausible TLE elements")
 )
 
+// Пределы правдоподобной эпохи TLE по умолчанию.
+const (
+	// DefaultMaxEpochFuture — насколько эпоха может опережать опорное время.
+	DefaultMaxEpochFuture = 24 * time.Hour
+
+	// DefaultMaxEpochAge — максимальный возраст эпохи (10 лет).
+	DefaultMaxEpochAge = 10 * 365 * 24 * time.Hour
+)
+
+// parseConfig — настройки ParseTLEWithOptions.
+type parseConfig struct {
+	epochCheck     bool
+	strict         bool
+	now            func() time.Time
+	maxEpochFuture time.Duration
+	maxEpochAge    time.Duration
+}
+
+// ParseOption функция настройки парсинга TLE.
+type ParseOption func(*parseConfig)
+
+// WithEpochCheck включает проверку правдоподобности эпохи относительно часов now
+// (nil — time.Now). Пределы — DefaultMaxEpochFuture и DefaultMaxEpochAge,
+// если не заданы через WithEpochLimits.
+func WithEpochCheck(now func() time.Time) ParseOption {
+	return func(c *parseConfig) {
+		c.epochCheck = true
+		if now != nil {
+			c.now = now
+		}
+	}
+}
+
+// WithEpochLimits устанавливает пределы проверки эпохи: максимальное опережение
+// опорного времени и максимальный возраст.
+func WithEpochLimits(maxFuture, maxAge time.Duration) ParseOption {
+	return func(c *parseConfig) {
+		c.maxEpochFuture = maxFuture
+		c.maxEpochAge = maxAge
+	}
+}
+
+// WithStrictValidation включает проверку физической правдоподобности элементов
+// (см. TLE.Validate). Строки с верной контрольной суммой, но бессмысленными
+// элементами, в строгом режиме отклоняются.
+func WithStrictValidation() ParseOption {
+	return func(c *parseConfig) {
+		c.strict = true
+	}
+}
+
 // alpha5Map маппинг букв Alpha-5 формата на числовые префиксы.
 // Alpha-5 используется для NORAD ID > 99999 (например, Starlink).
 // Буквы I и O не используются (путаются с 1 и 0).
@@ -75,7 +128,7 @@ func ParseTLE(lines []string) (*TLE, error) {
 	var name, line1, line2 string
 
 	// Определяем формат по первому символу первой строки
-	firstLine := strings.TrimSpace(lines[idxLine0])
+	firstLine := normalizeTLELine(lines[idxLine0])
 	if len(firstLine) == 0 {
 		return nil, fmt.Errorf("%w: first line is empty", ErrInvalidTLEFormat)
 	}
@@ -84,7 +137,7 @@ func ParseTLE(lines []string) (*TLE, error) {
 	case '1':
 		// 2-line формат: Line1, Line2
 		line1 = firstLine
-		line2 = strings.TrimSpace(lines[idxLine1])
+		line2 = normalizeTLELine(lines[idxLine1])
 
 	case '2':
 		// Некорректный порядок строк
@@ -96,60 +149,202 @@ func ParseTLE(lines []string) (*TLE, error) {
 			return nil, fmt.Errorf("%w: 3-line format requires 3 lines, got %d", ErrInvalidTLEFormat, len(lines))
 		}
 		name = firstLine
-		line1 = strings.TrimSpace(lines[idxLine1])
-		line2 = strings.TrimSpace(lines[idxLine2])
+		line1 = normalizeTLELine(lines[idxLine1])
+		line2 = normalizeTLELine(lines[idxLine2])
 	}
 
 	return parseTLELines(name, line1, line2)
 }
 
+// ParseTLEWithOptions парсит TLE как ParseTLE с дополнительными проверками.
+// Если эпоха не прошла проверку WithEpochCheck, возвращается разобранный TLE
+// вместе с ошибкой ErrSuspiciousEpoch: вызывающий может её проигнорировать.
+// Неправдоподобные элементы в режиме WithStrictValidation возвращают nil и ErrImplausibleTLE.
+func ParseTLEWithOptions(lines []string, opts ...ParseOption) (*TLE, error) {
+	cfg := parseConfig{
+		now:            time.Now,
+		maxEpochFuture: DefaultMaxEpochFuture,
+		maxEpochAge:    DefaultMaxEpochAge,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tle, err := ParseTLE(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.strict {
+		if err := tle.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.epochCheck {
+		if err := tle.ValidateEpoch(cfg.now(), cfg.maxEpochFuture, cfg.maxEpochAge); err != nil {
+			return tle, err
+		}
+	}
+
+	return tle, nil
+}
+
 // ParseTLEBatch парсит несколько TLE из одной строки.
 // TLE разделяются пустыми строками или идут подряд (3-line формат).
+// Первая некорректная запись прерывает разбор; для загрузки больших каталогов
+// с пропуском повреждённых записей см. ParseTLEBatchLenient.
 func ParseTLEBatch(data string) ([]*TLE, error) {
-	lines := strings.Split(data, "\n")
 	var tles []*TLE
-	currentLines := make([]string, 0, 3) // Обычно 3 строки (имя + line1 + line2)
 
-	for i := range lines {
-		trimmed := strings.TrimSpace(lines[i])
+	for _, rec := range splitTLEBatch(data) {
+		tle, err := ParseTLE(rec.lines)
+		if err != nil {
+			return nil, fmt.Errorf(errMsgParsingTLE, err)
+		}
+
+		tles = append(tles, tle)
+	}
+
+	return tles, nil
+}
+
+// BatchError описывает запись пакета TLE, которую не удалось разобрать.
+type BatchError struct {
+	Line int   // Номер первой строки записи во входных данных (с 1).
+	Err  error // Причина ошибки.
+}
+
+// Error реализует интерфейс error.
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("TLE at line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap возвращает причину ошибки для errors.Is/errors.As.
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// BatchResult — результат нестрогого разбора пакета TLE.
+type BatchResult struct {
+	TLEs   []*TLE        // Успешно разобранные записи в порядке следования.
+	Errors []*BatchError // Пропущенные записи.
+}
+
+// ParseTLEBatchLenient разбирает пакет TLE так же, как ParseTLEBatch, но не прерывается
+// на некорректных записях: они пропускаются и попадают в BatchResult.Errors
+// с номером строки. Одна повреждённая запись в каталоге Celestrak из тысяч объектов
+// не мешает загрузить остальные.
+func ParseTLEBatchLenient(data string) BatchResult {
+	var result BatchResult
+
+	for _, rec := range splitTLEBatch(data) {
+		tle, err := ParseTLE(rec.lines)
+		if err != nil {
+			result.Errors = append(result.Errors, &BatchError{Line: rec.line, Err: err})
+			continue
+		}
+
+		result.TLEs = append(result.TLEs, tle)
+	}
+
+	return result
+}
+
+// tleBatchRecord — строки одной записи пакета TLE и номер её первой строки (с 1).
+type tleBatchRecord struct {
+	line  int
+	lines []string
+}
+
+// splitTLEBatch делит пакет на записи TLE: по пустым строкам или по завершённой
+// структуре 2-line/3-line записи (см. tryParseTLE). Содержимое строк не проверяется;
+// незавершённая запись (например, Line1 без Line2) выделяется отдельно, и следующие
+// записи разбираются как обычно.
+func splitTLEBatch(data string) []tleBatchRecord {
+	var (
+		records []tleBatchRecord
+		current tleBatchRecord
+	)
+
+	flush := func() {
+		records = append(records, current)
+		current = tleBatchRecord{}
+	}
+
+	for i, line := range strings.Split(data, "\n") {
+		trimmed := normalizeTLELine(line)
 
 		// Пустая строка — возможный разделитель
 		if trimmed == "" {
-			if len(currentLines) >= 2 {
-				tle, err := ParseTLE(currentLines)
-				if err != nil {
-					return nil, fmt.Errorf(errMsgParsingTLE, err)
-				}
-				tles = append(tles, tle)
-				currentLines = nil
+			if len(current.lines) >= 2 {
+				flush()
 			}
 
 			continue
 		}
 
-		currentLines = append(currentLines, trimmed)
+		// Ресинхронизация: начало новой записи (имя или Line1) при незавершённой текущей
+		// отделяет обрывок в отдельную запись, которая станет ошибкой разбора.
+		if startsNewTLERecord(current.lines, trimmed) {
+			flush()
+		}
+
+		if len(current.lines) == 0 {
+			current.line = i + 1
+		}
+
+		current.lines = append(current.lines, trimmed)
 
 		// Проверяем, готов ли TLE к парсингу
-		if tle := tryParseTLE(currentLines); tle != nil {
-			parsed, err := ParseTLE(currentLines)
-			if err != nil {
-				return nil, fmt.Errorf("parsing TLE: %w", err)
-			}
-			tles = append(tles, parsed)
-			currentLines = nil
+		if tryParseTLE(current.lines) != nil {
+			flush()
 		}
 	}
 
 	// Обработка последнего TLE
-	if len(currentLines) >= 2 {
-		tle, err := ParseTLE(currentLines)
-		if err != nil {
-			return nil, fmt.Errorf(errMsgParsingTLE, err)
-		}
-		tles = append(tles, tle)
+	if len(current.lines) >= 2 {
+		flush()
 	}
 
-	return tles, nil
+	return records
+}
+
+// startsNewTLERecord сообщает, что строка line начинает новую запись при непустой
+// незавершённой записи lines: имя спутника или Line1 после чего-либо, кроме одного имени,
+// либо переполнение записи сверх 3 строк.
+func startsNewTLERecord(lines []string, line string) bool {
+	if len(lines) == 0 {
+		return false
+	}
+
+	if len(lines) >= 3 {
+		return true
+	}
+
+	switch {
+	case isTLEDataLine(line, '1'):
+		return len(lines) > 1 || isTLEDataLine(lines[0], '1') || isTLEDataLine(lines[0], '2')
+	case isTLEDataLine(line, '2'):
+		return false
+	default:
+		return true
+	}
+}
+
+// isTLEDataLine проверяет, что строка похожа на строку данных TLE с номером num.
+func isTLEDataLine(line string, num byte) bool {
+	return len(line) >= 2 && line[0] == num && line[1] == ' '
+}
+
+// utf8BOM — маркер порядка байтов UTF-8, которым некоторые редакторы начинают файл.
+const utf8BOM = "\uFEFF"
+
+// normalizeTLELine удаляет BOM в начале строки и окружающие пробельные символы,
+// включая \r от окончаний строк CRLF, которые иначе сдвигают колонки и контрольную сумму.
+func normalizeTLELine(line string) string {
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), utf8BOM))
 }
 
 // tryParseTLE проверяет, можно ли распарсить накопленные строки как TLE.
@@ -251,7 +446,7 @@ func parseLine1(tle *TLE, line string) error {
 	noradStr := strings.TrimSpace(line[2:7])
 	tle.NoradID, err = parseNoradID(noradStr)
 	if err != nil {
-		return fmt.Errorf("NORAD ID: %w", err)
+		return newParseFieldError("NORAD ID", 1, 3, 7, line, err)
 	}
 
 	// Classification (col 8)
@@ -264,14 +459,13 @@ func parseLine1(tle *TLE, line string) error {
 	epochStr := strings.TrimSpace(line[18:32])
 	tle.Epoch, err = parseEpoch(epochStr)
 	if err != nil {
-		return fmt.Errorf("epoch: %w", err)
+		return newParseFieldError("epoch", 1, 19, 32, line, err)
 	}
 
 	// Mean Motion Dot (cols 34-43): включая знак
-	meanMotionDotStr := strings.TrimSpace(line[33:43])
-	tle.MeanMotionDot, err = strconv.ParseFloat(meanMotionDotStr, 64)
+	tle.MeanMotionDot, err = parseFloatField(line, 1, "mean motion dot", 34, 43)
 	if err != nil {
-		return fmt.Errorf("mean motion dot: %w", err)
+		return err
 	}
 
 	// Mean Motion Dot2 (cols 45-52): научная нотация TLE
@@ -318,46 +512,41 @@ func parseLine2(tle *TLE, line string) error {
 	var err error
 
 	// Inclination (cols 9-16)
-	inclStr := strings.TrimSpace(line[8:16])
-	tle.Inclination, err = strconv.ParseFloat(inclStr, 64)
+	tle.Inclination, err = parseFloatField(line, 2, "inclination", 9, 16)
 	if err != nil {
-		return fmt.Errorf("inclination: %w", err)
+		return err
 	}
 
 	// RAAN (cols 18-25)
-	raanStr := strings.TrimSpace(line[17:25])
-	tle.RAAN, err = strconv.ParseFloat(raanStr, 64)
+	tle.RAAN, err = parseFloatField(line, 2, "RAAN", 18, 25)
 	if err != nil {
-		return fmt.Errorf("RAAN: %w", err)
+		return err
 	}
 
 	// Eccentricity (cols 27-33): без десятичной точки, подразумевается 0.
 	eccStr := strings.TrimSpace(line[26:33])
 	eccInt, err := strconv.ParseFloat("0."+eccStr, 64)
 	if err != nil {
-		return fmt.Errorf("eccentricity: %w", err)
+		return newParseFieldError("eccentricity", 2, 27, 33, line, err)
 	}
 	tle.Eccentricity = eccInt
 
 	// Argument of Perigee (cols 35-42)
-	argPeriStr := strings.TrimSpace(line[34:42])
-	tle.ArgOfPerigee, err = strconv.ParseFloat(argPeriStr, 64)
+	tle.ArgOfPerigee, err = parseFloatField(line, 2, "argument of perigee", 35, 42)
 	if err != nil {
-		return fmt.Errorf("argument of perigee: %w", err)
+		return err
 	}
 
 	// Mean Anomaly (cols 44-51)
-	meanAnomStr := strings.TrimSpace(line[43:51])
-	tle.MeanAnomaly, err = strconv.ParseFloat(meanAnomStr, 64)
+	tle.MeanAnomaly, err = parseFloatField(line, 2, "mean anomaly", 44, 51)
 	if err != nil {
-		return fmt.Errorf("mean anomaly: %w", err)
+		return err
 	}
 
 	// Mean Motion (cols 53-63)
-	meanMotionStr := strings.TrimSpace(line[52:63])
-	tle.MeanMotion, err = strconv.ParseFloat(meanMotionStr, 64)
+	tle.MeanMotion, err = parseFloatField(line, 2, "mean motion", 53, 63)
 	if err != nil {
-		return fmt.Errorf("mean motion: %w", err)
+		return err
 	}
 
 	// Revolution Number (cols 64-68)
@@ -371,6 +560,52 @@ func parseLine2(tle *TLE, line string) error {
 	return nil
 }
 
+// ParseFieldError описывает ошибку разбора конкретного поля TLE.
+// Колонки нумеруются с 1 включительно, как в спецификации формата,
+// что позволяет редактору TLE подсветить некорректный фрагмент.
+type ParseFieldError struct {
+	Field    string // Название поля (например, "inclination").
+	Line     int    // Номер строки TLE (1 или 2).
+	StartCol int    // Первая колонка поля.
+	EndCol   int    // Последняя колонка поля.
+	Raw      string // Исходное содержимое колонок без обрезки пробелов.
+	Err      error  // Исходная ошибка.
+}
+
+// Error возвращает описание ошибки с указанием поля и колонок.
+func (e *ParseFieldError) Error() string {
+	return fmt.Sprintf("%s (line %d, cols %d-%d, %q): %v", e.Field, e.Line, e.StartCol, e.EndCol, e.Raw, e.Err)
+}
+
+// Unwrap возвращает исходную ошибку.
+func (e *ParseFieldError) Unwrap() error {
+	return e.Err
+}
+
+// newParseFieldError создаёт ParseFieldError для колонок [startCol, endCol] строки line.
+func newParseFieldError(field string, lineNo, startCol, endCol int, line string, err error) *ParseFieldError {
+	return &ParseFieldError{
+		Field:    field,
+		Line:     lineNo,
+		StartCol: startCol,
+		EndCol:   endCol,
+		Raw:      line[startCol-1 : endCol],
+		Err:      err,
+	}
+}
+
+// parseFloatField парсит число из колонок [startCol, endCol] строки TLE.
+func parseFloatField(line string, lineNo int, field string, startCol, endCol int) (float64, error) {
+	raw := line[startCol-1 : endCol]
+
+	val, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil {
+		return 0, newParseFieldError(field, lineNo, startCol, endCol, line, err)
+	}
+
+	return val, nil
+}
+
 // validateChecksum проверяет контрольную сумму строки TLE по алгоритму Modulo-10.
 // Алгоритм: сумма всех цифр + 1 за каждый минус, mod 10 = последняя цифра.
 func validateChecksum(line string) bool {
@@ -578,6 +813,70 @@ func (tle *TLE) Age() time.Duration {
 	return time.Since(tle.Epoch)
 }
 
+// ValidateEpoch проверяет, что эпоха не опережает now больше чем на maxFuture
+// и не старше maxAge. Эпохи на десятилетия вперёд или назад обычно
+// означают ошибку ввода в источнике данных. Возвращает ErrSuspiciousEpoch.
+func (tle *TLE) ValidateEpoch(now time.Time, maxFuture, maxAge time.Duration) error {
+	ahead := tle.Epoch.Sub(now)
+
+	if ahead > maxFuture {
+		return fmt.Errorf("%w: epoch %s is %v ahead of reference time", ErrSuspiciousEpoch,
+			tle.Epoch.Format(time.RFC3339), ahead.Round(time.Hour))
+	}
+
+	if -ahead > maxAge {
+		return fmt.Errorf("%w: epoch %s is %v old", ErrSuspiciousEpoch,
+			tle.Epoch.Format(time.RFC3339), (-ahead).Round(time.Hour))
+	}
+
+	return nil
+}
+
+// validateMaxMeanMotion — верхний предел среднего движения для Validate, оборотов/сутки.
+// Период короче 80 минут невозможен ни для какой орбиты над поверхностью; эвристика
+// QualityFlags строже (maxPlausibleMeanMotion) и отсеивает уже сходящие с орбиты объекты.
+const validateMaxMeanMotion = 18.0
+
+// Validate проверяет физическую правдоподобность элементов: контрольная сумма
+// не защищает от мусора вроде e = 0.9999 или нулевого mean motion. Проверяются
+// диапазоны e, i, mean motion и углов, а также перигей над поверхностью Земли.
+// Возвращает ErrImplausibleTLE с именем первого поля вне допустимого диапазона
+// и ErrNilTLE для nil.
+func (tle *TLE) Validate() error {
+	if tle == nil {
+		return ErrNilTLE
+	}
+
+	// Сравнения записаны так, чтобы NaN тоже отклонялся.
+	switch {
+	case !(tle.Eccentricity >= 0 && tle.Eccentricity < 1):
+		return fmt.Errorf("%w: eccentricity %g outside [0, 1)", ErrImplausibleTLE, tle.Eccentricity)
+	case !(tle.Inclination >= 0 && tle.Inclination <= maxPlausibleInclination):
+		return fmt.Errorf("%w: inclination %g outside [0, 180]", ErrImplausibleTLE, tle.Inclination)
+	case !(tle.MeanMotion >= minPlausibleMeanMotion && tle.MeanMotion <= validateMaxMeanMotion):
+		return fmt.Errorf("%w: mean motion %g outside [%g, %g] rev/day", ErrImplausibleTLE,
+			tle.MeanMotion, minPlausibleMeanMotion, validateMaxMeanMotion)
+	case tle.Perigee() <= 0:
+		// Формально допустимый e ≈ 1 у низкой орбиты уводит перигей под поверхность.
+		return fmt.Errorf("%w: perigee %.0f km below Earth's surface", ErrImplausibleTLE, tle.Perigee())
+	}
+
+	for _, angle := range []struct {
+		name  string
+		value float64
+	}{
+		{name: "RAAN", value: tle.RAAN},
+		{name: "argument of perigee", value: tle.ArgOfPerigee},
+		{name: "mean anomaly", value: tle.MeanAnomaly},
+	} {
+		if !(angle.value >= 0 && angle.value < 360) {
+			return fmt.Errorf("%w: %s %g outside [0, 360)", ErrImplausibleTLE, angle.name, angle.value)
+		}
+	}
+
+	return nil
+}
+
 // IsStale возвращает true если TLE старше указанного количества дней.
 func (tle *TLE) IsStale(maxAgeDays float64) bool {
 	ageDays := tle.Age().Hours() / 24
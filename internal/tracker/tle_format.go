@@ -0,0 +1,245 @@
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ErrFieldOverflow возвращается, если значение поля не помещается в колонки формата TLE.
+var ErrFieldOverflow = errors.New("TLE field does not fit its column width")
+
+// Пределы полей формата TLE.
+const (
+	maxNumericNoradID = 99999
+	maxAlpha5NoradID  = 339999
+	maxTLEExponent    = 9
+	tleMantissaDigits = 1e5
+	tleEccentricityK  = 1e7
+	minTLEYear        = 1957
+	maxTLEYear        = 2056
+)
+
+// ToLines формирует строки Line 1 и Line 2 по полям TLE в каноническом формате
+// с фиксированными колонками (см. parseLine1 и parseLine2) и пересчитанной
+// контрольной суммой. В отличие от String, не использует сохранённые Line1/Line2,
+// поэтому подходит для TLE, созданных или изменённых программно.
+// NORAD ID больше 99999 кодируется в формате Alpha-5.
+// Если значение не помещается в свои колонки, возвращает ErrFieldOverflow.
+func (tle *TLE) ToLines() (string, string, error) {
+	if tle == nil {
+		return "", "", ErrNilTLE
+	}
+
+	norad, err := formatNoradID(tle.NoradID)
+	if err != nil {
+		return "", "", err
+	}
+
+	line1, err := formatLine1(tle, norad)
+	if err != nil {
+		return "", "", fmt.Errorf("formatting Line1: %w", err)
+	}
+
+	line2, err := formatLine2(tle, norad)
+	if err != nil {
+		return "", "", fmt.Errorf("formatting Line2: %w", err)
+	}
+
+	return line1, line2, nil
+}
+
+// formatLine1 формирует Line 1 с контрольной суммой.
+func formatLine1(tle *TLE, norad string) (string, error) {
+	classification := tle.Classification
+	if classification == "" {
+		classification = "U"
+	}
+
+	epoch, err := formatEpoch(tle.Epoch)
+	if err != nil {
+		return "", err
+	}
+
+	meanMotionDot, err := formatMeanMotionDot(tle.MeanMotionDot)
+	if err != nil {
+		return "", err
+	}
+
+	meanMotionDot2, err := formatExponent("mean motion dot2", tle.MeanMotionDot2)
+	if err != nil {
+		return "", err
+	}
+
+	bstar, err := formatExponent("BSTAR", tle.Bstar)
+	if err != nil {
+		return "", err
+	}
+
+	var w columnWriter
+
+	w.field("line number", "1 ", 2)
+	w.field("NORAD ID", norad, 5)
+	w.field("classification", classification, 1)
+	w.field("international designator", fmt.Sprintf(" %-8s ", tle.IntlDesignator), 10)
+	w.field("epoch", epoch+" ", 15)
+	w.field("mean motion dot", meanMotionDot+" ", 11)
+	w.field("mean motion dot2", meanMotionDot2+" ", 9)
+	w.field("BSTAR", bstar+" ", 9)
+	w.field("ephemeris type", fmt.Sprintf("%d ", tle.EphemerisType), 2)
+	w.field("element set number", fmt.Sprintf("%4d", tle.ElementSetNo), 4)
+
+	return w.line()
+}
+
+// formatLine2 формирует Line 2 с контрольной суммой.
+func formatLine2(tle *TLE, norad string) (string, error) {
+	ecc := int(math.Round(tle.Eccentricity * tleEccentricityK))
+	if tle.Eccentricity < 0 || ecc >= tleEccentricityK {
+		return "", fmt.Errorf("%w: eccentricity %g", ErrFieldOverflow, tle.Eccentricity)
+	}
+
+	var w columnWriter
+
+	w.field("line number", "2 ", 2)
+	w.field("NORAD ID", norad+" ", 6)
+	w.field("inclination", fmt.Sprintf("%8.4f ", tle.Inclination), 9)
+	w.field("RAAN", fmt.Sprintf("%8.4f ", tle.RAAN), 9)
+	w.field("eccentricity", fmt.Sprintf("%07d ", ecc), 8)
+	w.field("argument of perigee", fmt.Sprintf("%8.4f ", tle.ArgOfPerigee), 9)
+	w.field("mean anomaly", fmt.Sprintf("%8.4f ", tle.MeanAnomaly), 9)
+	w.field("mean motion", fmt.Sprintf("%11.8f", tle.MeanMotion), 11)
+	w.field("revolution number", fmt.Sprintf("%5d", tle.RevNumber), 5)
+
+	return w.line()
+}
+
+// columnWriter собирает строку TLE из полей фиксированной ширины.
+// Первое переполнение запоминается, последующие поля игнорируются.
+type columnWriter struct {
+	b   strings.Builder
+	err error
+}
+
+// field дописывает значение, которое должно занимать ровно width колонок.
+func (w *columnWriter) field(name, value string, width int) {
+	if w.err != nil {
+		return
+	}
+
+	if len(value) != width {
+		w.err = fmt.Errorf("%w: %s %q exceeds %d columns", ErrFieldOverflow, name, strings.TrimSpace(value), width)
+		return
+	}
+
+	w.b.WriteString(value)
+}
+
+// line возвращает собранную строку с контрольной суммой или первую ошибку.
+func (w *columnWriter) line() (string, error) {
+	if w.err != nil {
+		return "", w.err
+	}
+
+	return withChecksum(w.b.String()), nil
+}
+
+// withChecksum дописывает контрольную сумму Modulo-10 к строке из 68 символов.
+func withChecksum(line string) string {
+	return fmt.Sprintf("%s%d", line, calculateChecksum(line))
+}
+
+// formatNoradID кодирует NORAD ID в 5 колонок (Alpha-5 для значений больше 99999).
+func formatNoradID(id int) (string, error) {
+	switch {
+	case id < 0 || id > maxAlpha5NoradID:
+		return "", fmt.Errorf("%w: NORAD ID %d", ErrFieldOverflow, id)
+	case id <= maxNumericNoradID:
+		return fmt.Sprintf("%05d", id), nil
+	}
+
+	prefix := id / 10000
+	for letter, value := range alpha5Map {
+		if value == prefix {
+			return fmt.Sprintf("%c%04d", letter, id%10000), nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: NORAD ID %d", ErrInvalidAlpha5, id)
+}
+
+// formatEpoch кодирует эпоху в формате YYDDD.DDDDDDDD (14 колонок).
+func formatEpoch(t time.Time) (string, error) {
+	epoch := t.UTC()
+
+	year := epoch.Year()
+	if year < minTLEYear || year > maxTLEYear {
+		return "", fmt.Errorf("%w: epoch year %d", ErrFieldOverflow, year)
+	}
+
+	startOfYear := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	dayOfYear := 1 + epoch.Sub(startOfYear).Hours()/24
+
+	return fmt.Sprintf("%02d%012.8f", year%100, dayOfYear), nil
+}
+
+// formatMeanMotionDot кодирует первую производную среднего движения (10 колонок)
+// в виде знака и дробной части без ведущего нуля: " .00016717".
+func formatMeanMotionDot(v float64) (string, error) {
+	sign := " "
+	if v < 0 {
+		sign = "-"
+	}
+
+	digits := fmt.Sprintf("%.8f", math.Abs(v))
+	if !strings.HasPrefix(digits, "0.") {
+		return "", fmt.Errorf("%w: mean motion dot %g", ErrFieldOverflow, v)
+	}
+
+	return sign + digits[1:], nil
+}
+
+// formatExponent кодирует число в экспоненциальной нотации TLE (8 колонок):
+// знак, 5 цифр мантиссы и порядок, например " 10270-3" = 0.10270e-3.
+// Формат обратен parseExponent.
+func formatExponent(field string, v float64) (string, error) {
+	if v == 0 {
+		return " 00000-0", nil
+	}
+
+	sign := " "
+	if v < 0 {
+		sign = "-"
+	}
+
+	abs := math.Abs(v)
+	exp := int(math.Floor(math.Log10(abs))) + 1
+	mantissa := int(math.Round(abs / math.Pow(10, float64(exp)) * tleMantissaDigits))
+
+	if mantissa >= tleMantissaDigits {
+		mantissa /= 10
+		exp++
+	}
+
+	if exp > maxTLEExponent || exp < -maxTLEExponent {
+		return "", fmt.Errorf("%w: %s %g", ErrFieldOverflow, field, v)
+	}
+
+	expSign := "+"
+	if exp < 0 {
+		expSign = "-"
+	}
+
+	return fmt.Sprintf("%s%05d%s%d", sign, mantissa, expSign, absInt(exp)), nil
+}
+
+// absInt возвращает модуль целого числа.
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
@@ -0,0 +1,130 @@
+package tracker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestTLE_ToLines_RoundTrip проверяет, что ParseTLE → ToLines → ParseTLE не теряет данных.
+func TestTLE_ToLines_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		line1 string
+		line2 string
+		exact bool // Исходные строки уже в каноническом виде.
+	}{
+		{name: "ISS", line1: issLine1, line2: issLine2, exact: true},
+		{name: "Meteor", line1: meteorLine1, line2: meteorLine2, exact: true},
+		{
+			name:  "Starlink Alpha-5",
+			line1: makeTLELine("1 A0001U 24001A   24001.50000000  .00000123  00000-0  12345-4 0  999"),
+			line2: makeTLELine("2 A0001  53.0000 123.4567 0001234  90.0000 270.0000 15.0000000000001"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tle, err := ParseTLE([]string{tt.line1, tt.line2})
+			if err != nil {
+				t.Fatalf("ParseTLE() error = %v", err)
+			}
+
+			line1, line2, err := tle.ToLines()
+			if err != nil {
+				t.Fatalf("ToLines() error = %v", err)
+			}
+
+			if tt.exact && (line1 != tt.line1 || line2 != tt.line2) {
+				t.Errorf("ToLines():\n got %q\n     %q\nwant %q\n     %q", line1, line2, tt.line1, tt.line2)
+			}
+
+			again, err := ParseTLE([]string{line1, line2})
+			if err != nil {
+				t.Fatalf("ParseTLE(ToLines()) error = %v", err)
+			}
+
+			// Выравнивание (например, ведущие нули номера витка) может отличаться
+			// от исходного, поэтому сравниваются разобранные поля.
+			again.Line1, again.Line2 = tle.Line1, tle.Line2
+			if *again != *tle {
+				t.Errorf("round trip mismatch:\n got %+v\nwant %+v", again, tle)
+			}
+		})
+	}
+}
+
+// TestTLE_ToLines_Modified проверяет формирование строк для программно изменённого TLE.
+func TestTLE_ToLines_Modified(t *testing.T) {
+	t.Parallel()
+
+	tle, err := ParseTLE([]string{issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	tle.Bstar = -2.5e-5
+	tle.MeanMotionDot2 = 1.2345e-9
+	tle.Epoch = time.Date(2024, 3, 1, 6, 0, 0, 0, time.UTC)
+
+	line1, line2, err := tle.ToLines()
+	if err != nil {
+		t.Fatalf("ToLines() error = %v", err)
+	}
+
+	if !validateChecksum(line1) || !validateChecksum(line2) {
+		t.Errorf("generated lines have invalid checksum:\n%s\n%s", line1, line2)
+	}
+
+	got, err := ParseTLE([]string{line1, line2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	if !almostEqual(got.Bstar, tle.Bstar, 1e-12) || !almostEqual(got.MeanMotionDot2, tle.MeanMotionDot2, 1e-18) {
+		t.Errorf("Bstar, MeanMotionDot2 = %g, %g, want %g, %g", got.Bstar, got.MeanMotionDot2, tle.Bstar, tle.MeanMotionDot2)
+	}
+
+	if !got.Epoch.Equal(tle.Epoch) {
+		t.Errorf("Epoch = %v, want %v", got.Epoch, tle.Epoch)
+	}
+}
+
+// TestTLE_ToLines_Overflow проверяет ошибку для значений, не помещающихся в колонки.
+func TestTLE_ToLines_Overflow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(*TLE)
+	}{
+		{name: "NORAD ID beyond Alpha-5", modify: func(tle *TLE) { tle.NoradID = 340000 }},
+		{name: "inclination", modify: func(tle *TLE) { tle.Inclination = 1000 }},
+		{name: "eccentricity", modify: func(tle *TLE) { tle.Eccentricity = 1 }},
+		{name: "mean motion dot", modify: func(tle *TLE) { tle.MeanMotionDot = 1.5 }},
+		{name: "BSTAR exponent", modify: func(tle *TLE) { tle.Bstar = 1e12 }},
+		{name: "revolution number", modify: func(tle *TLE) { tle.RevNumber = 123456 }},
+		{name: "epoch year", modify: func(tle *TLE) { tle.Epoch = time.Date(2060, 1, 1, 0, 0, 0, 0, time.UTC) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tle, err := ParseTLE([]string{issLine1, issLine2})
+			if err != nil {
+				t.Fatalf("ParseTLE() error = %v", err)
+			}
+
+			tt.modify(tle)
+
+			if _, _, err := tle.ToLines(); !errors.Is(err, ErrFieldOverflow) {
+				t.Errorf("ToLines() error = %v, want ErrFieldOverflow", err)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package tracker
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
@@ -35,6 +36,10 @@ var (
 	meteorLine1 = makeTLELine("1 40069U 14037A   24001.50000000  .00000123  00000-0  12345-4 0  999")
 	meteorLine2 = makeTLELine("2 40069  98.5200  45.6789 0001234 123.4567 236.7890 14.2098765432109")
 	meteorTLE   = "METEOR-M2\n" + meteorLine1 + "\n" + meteorLine2
+
+	// Molniya-подобная HEO орбита (e=0.7, i=63.4°, 2 оборота в сутки).
+	molniyaLine1 = makeTLELine("1 28163U 04005A   24001.50000000  .00000100  00000-0  10000-3 0  999")
+	molniyaLine2 = makeTLELine("2 28163  63.4000 120.0000 7000000 270.0000   0.0000  2.00600000 1234")
 )
 
 // TestValidateChecksum проверяет алгоритм Modulo-10.
@@ -270,6 +275,157 @@ func TestParseTLEBatch(t *testing.T) {
 	}
 }
 
+// TestParseTLE_CRLFAndBOM проверяет, что окончания строк CRLF и BOM в начале файла
+// не влияют на результат разбора.
+func TestParseTLE_CRLFAndBOM(t *testing.T) {
+	t.Parallel()
+
+	clean, err := ParseTLEBatch(issTLE + "\n" + meteorTLE)
+	if err != nil {
+		t.Fatalf("ParseTLEBatch(clean) error = %v", err)
+	}
+
+	crlf := strings.ReplaceAll(issTLE+"\n"+meteorTLE, "\n", "\r\n") + "\r\n"
+
+	tests := []struct {
+		name    string
+		data    string
+		twoLine bool // Первый TLE в 2-line формате, без имени.
+	}{
+		{name: "CRLF", data: crlf},
+		{name: "BOM", data: "\uFEFF" + issTLE + "\n" + meteorTLE},
+		{name: "BOM and CRLF", data: "\uFEFF" + crlf},
+		{name: "BOM before 2-line TLE", data: "\uFEFF" + issLine1 + "\r\n" + issLine2 + "\r\n\r\n" + meteorTLE, twoLine: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseTLEBatch(tt.data)
+			if err != nil {
+				t.Fatalf("ParseTLEBatch() error = %v", err)
+			}
+
+			if len(got) != len(clean) {
+				t.Fatalf("ParseTLEBatch() returned %d TLEs, want %d", len(got), len(clean))
+			}
+
+			for i := range got {
+				want := *clean[i]
+				if tt.twoLine && i == 0 {
+					want.Name = ""
+				}
+
+				if *got[i] != want {
+					t.Errorf("TLE %d = %+v, want %+v", i, *got[i], want)
+				}
+			}
+		})
+	}
+
+	single, err := ParseTLE([]string{"\uFEFFISS (ZARYA)\r", issLine1 + "\r", issLine2 + "\r"})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	if *single != *clean[0] {
+		t.Errorf("ParseTLE() = %+v, want %+v", *single, *clean[0])
+	}
+}
+
+// TestParseTLEBatchLenient проверяет, что запись с неверной контрольной суммой
+// пропускается с указанием строки, а остальные записи пакета разбираются.
+func TestParseTLEBatchLenient(t *testing.T) {
+	t.Parallel()
+
+	// Портим контрольную сумму Line1 HST.
+	badLine1 := hstLine1[:68] + strconv.Itoa((int(hstLine1[68]-'0')+1)%10)
+	batch := issTLE + "\n" + "HST\n" + badLine1 + "\n" + hstLine2 + "\n" + meteorTLE
+
+	if _, err := ParseTLEBatch(batch); !errors.Is(err, ErrInvalidChecksum) {
+		t.Errorf("ParseTLEBatch() error = %v, want ErrInvalidChecksum", err)
+	}
+
+	result := ParseTLEBatchLenient(batch)
+
+	if len(result.TLEs) != 2 || result.TLEs[0].NoradID != 25544 || result.TLEs[1].NoradID != 40069 {
+		t.Fatalf("ParseTLEBatchLenient() TLEs = %v, want ISS and METEOR-M2", result.TLEs)
+	}
+
+	if len(result.Errors) != 1 {
+		t.Fatalf("ParseTLEBatchLenient() returned %d errors, want 1", len(result.Errors))
+	}
+
+	if got := result.Errors[0]; got.Line != 4 || !errors.Is(got, ErrInvalidChecksum) {
+		t.Errorf("Errors[0] = %v (line %d), want ErrInvalidChecksum at line 4", got, got.Line)
+	}
+}
+
+// TestParseTLEBatchLenient_Resync проверяет, что обрывок записи не поглощает следующие
+// корректные записи пакета.
+func TestParseTLEBatchLenient_Resync(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		batch     string
+		wantNorad []int
+		wantLine  int
+	}{
+		{
+			name:      "Line1 без Line2",
+			batch:     hstLine1 + "\n" + issTLE + "\n" + hstLine1 + "\n" + hstLine2 + "\n" + meteorTLE,
+			wantNorad: []int{25544, 20580, 40069},
+			wantLine:  1,
+		},
+		{
+			name:      "Line1 без Line2 в 2-line потоке",
+			batch:     hstLine1 + "\n" + hstLine1 + "\n" + hstLine2,
+			wantNorad: []int{20580},
+			wantLine:  1,
+		},
+		{
+			name:      "имя без строк данных",
+			batch:     issTLE + "\nORPHAN\n" + meteorTLE,
+			wantNorad: []int{25544, 40069},
+			wantLine:  4,
+		},
+		{
+			name:      "Line2 без Line1",
+			batch:     issTLE + "\n" + hstLine2 + "\n" + meteorTLE,
+			wantNorad: []int{25544, 40069},
+			wantLine:  4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := ParseTLEBatchLenient(tt.batch)
+
+			if len(result.TLEs) != len(tt.wantNorad) {
+				t.Fatalf("ParseTLEBatchLenient() returned %d TLEs, want %d", len(result.TLEs), len(tt.wantNorad))
+			}
+
+			for i, want := range tt.wantNorad {
+				if result.TLEs[i].NoradID != want {
+					t.Errorf("TLEs[%d].NoradID = %d, want %d", i, result.TLEs[i].NoradID, want)
+				}
+			}
+
+			if len(result.Errors) != 1 {
+				t.Fatalf("ParseTLEBatchLenient() returned %d errors, want 1: %v", len(result.Errors), result.Errors)
+			}
+
+			if got := result.Errors[0].Line; got != tt.wantLine {
+				t.Errorf("Errors[0].Line = %d, want %d", got, tt.wantLine)
+			}
+		})
+	}
+}
+
 // TestParseExponent проверяет парсинг научной нотации TLE.
 func TestParseExponent(t *testing.T) {
 	tests := []struct {
@@ -411,3 +567,182 @@ func TestParseTLE_Alpha5_Starlink(t *testing.T) {
 		t.Errorf("Name = %q, want %q", tle.Name, "STARLINK-99999")
 	}
 }
+
+// TestParseTLE_FieldError проверяет диагностику колонок некорректного поля.
+func TestParseTLE_FieldError(t *testing.T) {
+	// Мусор в колонках наклонения (cols 9-16).
+	badLine2 := makeTLELine("2 25544  51.6X00 247.4627 0006703 130.5360 325.0288 15.4981557142340")
+
+	_, err := ParseTLE([]string{"ISS (ZARYA)", issLine1, badLine2})
+	if err == nil {
+		t.Fatal("ParseTLE() expected error")
+	}
+
+	var fieldErr *ParseFieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("error %v is not ParseFieldError", err)
+	}
+
+	if fieldErr.Field != "inclination" || fieldErr.Line != 2 {
+		t.Errorf("Field/Line = %q/%d, want inclination/2", fieldErr.Field, fieldErr.Line)
+	}
+
+	if fieldErr.StartCol != 9 || fieldErr.EndCol != 16 {
+		t.Errorf("cols = %d-%d, want 9-16", fieldErr.StartCol, fieldErr.EndCol)
+	}
+
+	if fieldErr.Raw != " 51.6X00" {
+		t.Errorf("Raw = %q, want %q", fieldErr.Raw, " 51.6X00")
+	}
+
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("error should wrap strconv.ErrSyntax, got %v", err)
+	}
+
+	// Ошибка в Line 1: mean motion dot (cols 34-43).
+	badLine1 := makeTLELine("1 25544U 98067A   24001.50000000  .0001X717  00000-0  10270-3 0  999")
+
+	_, err = ParseTLE([]string{badLine1, issLine2})
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("error %v is not ParseFieldError", err)
+	}
+
+	if fieldErr.Line != 1 || fieldErr.StartCol != 34 || fieldErr.EndCol != 43 {
+		t.Errorf("got line %d cols %d-%d, want line 1 cols 34-43", fieldErr.Line, fieldErr.StartCol, fieldErr.EndCol)
+	}
+}
+
+// TestParseTLEWithOptions_EpochCheck проверяет обнаружение неправдоподобных эпох.
+func TestParseTLEWithOptions_EpochCheck(t *testing.T) {
+	t.Parallel()
+
+	lines := []string{"ISS (ZARYA)", issLine1, issLine2}
+	epoch := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		opts    []ParseOption
+		wantErr bool
+	}{
+		{name: "fresh epoch", now: epoch.Add(2 * time.Hour), wantErr: false},
+		{name: "epoch a year in the future", now: epoch.AddDate(-1, 0, 0), wantErr: true},
+		{name: "epoch eleven years old", now: epoch.AddDate(11, 0, 0), wantErr: true},
+		{
+			name:    "custom limits",
+			now:     epoch.AddDate(0, 0, 40),
+			opts:    []ParseOption{WithEpochLimits(time.Hour, 30*24*time.Hour)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]ParseOption{WithEpochCheck(func() time.Time { return tt.now })}, tt.opts...)
+
+			tle, err := ParseTLEWithOptions(lines, opts...)
+			if tt.wantErr != errors.Is(err, ErrSuspiciousEpoch) {
+				t.Fatalf("ParseTLEWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			// TLE возвращается и при подозрительной эпохе — ошибку можно проигнорировать.
+			if tle == nil || tle.NoradID != 25544 {
+				t.Errorf("ParseTLEWithOptions() tle = %+v, want parsed ISS", tle)
+			}
+		})
+	}
+
+	// Без WithEpochCheck проверка не выполняется.
+	if _, err := ParseTLEWithOptions(lines); err != nil {
+		t.Errorf("ParseTLEWithOptions() without check error = %v", err)
+	}
+}
+
+// TestTLE_Validate проверяет отклонение физически неправдоподобных элементов.
+func TestTLE_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		modify func(*TLE)
+		field  string
+	}{
+		{name: "valid", modify: func(*TLE) {}},
+		{name: "eccentricity one", modify: func(tle *TLE) { tle.Eccentricity = 1 }, field: "eccentricity"},
+		{name: "eccentricity near one", modify: func(tle *TLE) { tle.Eccentricity = 0.9999 }, field: "perigee"},
+		{name: "negative eccentricity", modify: func(tle *TLE) { tle.Eccentricity = -0.1 }, field: "eccentricity"},
+		{name: "negative inclination", modify: func(tle *TLE) { tle.Inclination = -1 }, field: "inclination"},
+		{name: "inclination above 180", modify: func(tle *TLE) { tle.Inclination = 181 }, field: "inclination"},
+		{name: "zero mean motion", modify: func(tle *TLE) { tle.MeanMotion = 0 }, field: "mean motion"},
+		{name: "mean motion too high", modify: func(tle *TLE) { tle.MeanMotion = 25 }, field: "mean motion"},
+		// 18 об/сут проходит проверку диапазона, но перигей такой орбиты уже под поверхностью.
+		{name: "mean motion at bound", modify: func(tle *TLE) { tle.MeanMotion = 18 }, field: "perigee"},
+		{name: "mean motion above bound", modify: func(tle *TLE) { tle.MeanMotion = 18.01 }, field: "mean motion"},
+		{name: "NaN mean motion", modify: func(tle *TLE) { tle.MeanMotion = math.NaN() }, field: "mean motion"},
+		{name: "RAAN 360", modify: func(tle *TLE) { tle.RAAN = 360 }, field: "RAAN"},
+		{name: "negative argument of perigee", modify: func(tle *TLE) { tle.ArgOfPerigee = -5 }, field: "argument of perigee"},
+		{name: "mean anomaly above 360", modify: func(tle *TLE) { tle.MeanAnomaly = 400 }, field: "mean anomaly"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tle, err := ParseTLE([]string{issLine1, issLine2})
+			if err != nil {
+				t.Fatalf("ParseTLE() error = %v", err)
+			}
+
+			tt.modify(tle)
+
+			err = tle.Validate()
+			if tt.field == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, ErrImplausibleTLE) || !strings.Contains(err.Error(), tt.field) {
+				t.Errorf("Validate() error = %v, want ErrImplausibleTLE for %s", err, tt.field)
+			}
+		})
+	}
+
+	var nilTLE *TLE
+	if err := nilTLE.Validate(); !errors.Is(err, ErrNilTLE) {
+		t.Errorf("nil Validate() error = %v, want ErrNilTLE", err)
+	}
+}
+
+// TestParseTLEWithOptions_StrictValidation проверяет, что строгий режим отклоняет
+// строки с верной контрольной суммой и бессмысленными элементами.
+func TestParseTLEWithOptions_StrictValidation(t *testing.T) {
+	t.Parallel()
+
+	tle, err := ParseTLE([]string{issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	tle.Eccentricity = 0.9999
+
+	line1, line2, err := tle.ToLines()
+	if err != nil {
+		t.Fatalf("ToLines() error = %v", err)
+	}
+
+	if _, err := ParseTLE([]string{line1, line2}); err != nil {
+		t.Fatalf("ParseTLE() error = %v, want garbage accepted without strict mode", err)
+	}
+
+	got, err := ParseTLEWithOptions([]string{line1, line2}, WithStrictValidation())
+	if !errors.Is(err, ErrImplausibleTLE) || got != nil {
+		t.Errorf("ParseTLEWithOptions(strict) = %v, %v, want nil, ErrImplausibleTLE", got, err)
+	}
+
+	if _, err := ParseTLEWithOptions([]string{issLine1, issLine2}, WithStrictValidation()); err != nil {
+		t.Errorf("ParseTLEWithOptions(strict, ISS) error = %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrNilPropagator возвращается, если пропагатор не задан.
+var ErrNilPropagator = errors.New("propagator is nil")
+
+// Параметры поиска транзитов.
+const (
+	// SunRadiusKm — радиус фотосферы Солнца, км.
+	SunRadiusKm = 695700.0
+
+	// transitScanStep — шаг грубого поиска сближений со Солнцем.
+	transitScanStep = 5 * time.Second
+
+	// transitCandidateDeg — максимальное угловое расстояние локального минимума,
+	// при котором выполняется точное уточнение.
+	transitCandidateDeg = 10.0
+
+	// transitRefineTolerance — наихудшая допустимая точность уточнения центрального момента транзита.
+	transitRefineTolerance = 10 * time.Millisecond
+)
+
+// TransitEvent описывает прохождение спутника по диску Солнца.
+type TransitEvent struct {
+	Time          time.Time     // Центральный момент (минимальное угловое расстояние).
+	Duration      time.Duration // Оценка длительности прохождения по диску.
+	SeparationDeg float64       // Минимальное расстояние от центра диска, градусы.
+	SunRadiusDeg  float64       // Угловой радиус Солнца, градусы.
+	AzDeg         float64       // Азимут Солнца в центральный момент, градусы.
+	ElDeg         float64       // Угол места Солнца в центральный момент, градусы.
+}
+
+// SolarTransit находит моменты, когда спутник проходит по диску Солнца для наблюдателя.
+// Солнце и спутник должны быть над горизонтом. Центральный момент уточняется
+// с точностью ~10 мс (или точнее, если так задано в RefineOptions пропагатора),
+// поскольку ISS пересекает диск меньше чем за секунду.
+func (obs *Observer) SolarTransit(prop *Propagator, start, end time.Time) ([]TransitEvent, error) {
+	if obs == nil {
+		return nil, ErrNilObserver
+	}
+
+	if prop == nil {
+		return nil, ErrNilPropagator
+	}
+
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: start=%v end=%v", ErrInvalidWindow, start, end)
+	}
+
+	var (
+		events      []TransitEvent
+		prev, prev2 = math.Inf(1), math.Inf(1)
+	)
+
+	for t := start; !t.After(end); t = t.Add(transitScanStep) {
+		sep, err := obs.sunSeparationDeg(prop, t)
+		if err != nil {
+			return events, err
+		}
+
+		// Локальный минимум в предыдущей точке.
+		if prev < prev2 && prev <= sep && prev < transitCandidateDeg {
+			event, ok, err := obs.refineTransit(prop, t.Add(-2*transitScanStep), t)
+			if err != nil {
+				return events, err
+			}
+
+			if ok {
+				events = append(events, event)
+			}
+		}
+
+		prev2, prev = prev, sep
+	}
+
+	return events, nil
+}
+
+// refineTransit уточняет минимум углового расстояния на интервале [a, b]
+// и возвращает событие, если спутник проходит по диску Солнца. Допуск пропагатора
+// ограничивается сверху transitRefineTolerance: общий допуск (100 мс по умолчанию)
+// сопоставим с длительностью транзита.
+func (obs *Observer) refineTransit(prop *Propagator, a, b time.Time) (TransitEvent, bool, error) {
+	opts := prop.refine
+	opts.Tolerance = min(opts.Tolerance, transitRefineTolerance)
+
+	for iter := 0; !opts.converged(b.Sub(a), iter); iter++ {
+		third := b.Sub(a) / 3
+		m1, m2 := a.Add(third), b.Add(-third)
+
+		sep1, err := obs.sunSeparationDeg(prop, m1)
+		if err != nil {
+			return TransitEvent{}, false, err
+		}
+
+		sep2, err := obs.sunSeparationDeg(prop, m2)
+		if err != nil {
+			return TransitEvent{}, false, err
+		}
+
+		if sep1 < sep2 {
+			b = m2
+		} else {
+			a = m1
+		}
+	}
+
+	center := a.Add(b.Sub(a) / 2)
+
+	sep, err := obs.sunSeparationDeg(prop, center)
+	if err != nil {
+		return TransitEvent{}, false, err
+	}
+
+	sun := SunPositionECI(center)
+	sunAER := obs.GetAER(sun)
+	sunRadiusDeg := math.Asin(SunRadiusKm/sunAER.Range) * Rad2Deg
+
+	if sep > sunRadiusDeg {
+		return TransitEvent{}, false, nil
+	}
+
+	// Длительность — хорда диска, делённая на угловую скорость спутника относительно Солнца.
+	later, err := obs.sunSeparationDeg(prop, center.Add(time.Second))
+	if err != nil {
+		return TransitEvent{}, false, err
+	}
+
+	var duration time.Duration
+	if rate := math.Sqrt(math.Max(later*later-sep*sep, 0)); rate > 0 {
+		chord := 2 * math.Sqrt(sunRadiusDeg*sunRadiusDeg-sep*sep)
+		duration = time.Duration(chord / rate * float64(time.Second))
+	}
+
+	return TransitEvent{
+		Time:          center,
+		Duration:      duration,
+		SeparationDeg: sep,
+		SunRadiusDeg:  sunRadiusDeg,
+		AzDeg:         sunAER.AzDeg(),
+		ElDeg:         sunAER.ElDeg(),
+	}, true, nil
+}
+
+// sunSeparationDeg возвращает угловое расстояние между спутником и Солнцем в градусах.
+// Если спутник или Солнце под горизонтом, возвращает +Inf.
+func (obs *Observer) sunSeparationDeg(prop *Propagator, t time.Time) (float64, error) {
+	pos, err := prop.propagatePrecise(t)
+	if err != nil {
+		return 0, err
+	}
+
+	satAER := obs.GetAER(pos)
+	sunAER := obs.GetAER(SunPositionECI(t))
+
+	if satAER.El < 0 || sunAER.El < 0 {
+		return math.Inf(1), nil
+	}
+
+	return AngularSeparation(satAER, sunAER) * Rad2Deg, nil
+}
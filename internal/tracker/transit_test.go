@@ -0,0 +1,133 @@
+package tracker
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// transitObserverFor возвращает точку на поверхности Земли, для которой спутник
+// в момент t находится точно на линии Солнце–наблюдатель.
+func transitObserverFor(t *testing.T, prop *Propagator, at time.Time) *Observer {
+	t.Helper()
+
+	pos, err := prop.Propagate(at)
+	if err != nil {
+		t.Fatalf("Propagate() error = %v", err)
+	}
+
+	sat := ECIToECEF(pos)
+	sun := ECIToECEF(SunPositionECI(at))
+	satVec := vec3{X: sat.X, Y: sat.Y, Z: sat.Z}
+	dir := satVec.sub(vec3{X: sun.X, Y: sun.Y, Z: sun.Z}).unit()
+
+	// Пересечение луча с эллипсоидом: сжимаем ось Z, чтобы получить сферу радиуса WGS84A.
+	k := WGS84A / WGS84B
+	o := vec3{X: sat.X, Y: sat.Y, Z: sat.Z * k}
+	d := vec3{X: dir.X, Y: dir.Y, Z: dir.Z * k}
+
+	a := d.dot(d)
+	b := 2 * o.dot(d)
+	c := o.dot(o) - WGS84A*WGS84A
+
+	disc := b*b - 4*a*c
+	if disc < 0 {
+		return nil
+	}
+
+	s := (-b - math.Sqrt(disc)) / (2 * a)
+	if s <= 0 {
+		return nil
+	}
+
+	ground := satVec.add(dir.scale(s))
+	lla := ECEFToLLA(&ECEFPosition{X: ground.X, Y: ground.Y, Z: ground.Z, Time: at})
+
+	return NewObserver(lla.LatDeg(), lla.LonDeg(), lla.Alt)
+}
+
+// TestSolarTransit проверяет обнаружение транзита по диску Солнца
+// для наблюдателя, размещённого точно под линией Солнце–ISS.
+func TestSolarTransit(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+
+	var (
+		obs    *Observer
+		center time.Time
+	)
+
+	// Ищем момент, когда ISS находится над дневной стороной и луч от Солнца упирается в Землю.
+	for at := passTestStart; at.Before(passTestStart.Add(3 * time.Hour)); at = at.Add(time.Minute) {
+		candidate := transitObserverFor(t, prop, at)
+		if candidate == nil {
+			continue
+		}
+
+		if candidate.SunElevationDeg(at) > 20 {
+			obs, center = candidate, at
+
+			break
+		}
+	}
+
+	if obs == nil {
+		t.Fatal("failed to construct transit geometry")
+	}
+
+	events, err := obs.SolarTransit(prop, center.Add(-3*time.Minute), center.Add(3*time.Minute))
+	if err != nil {
+		t.Fatalf("SolarTransit() error = %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("SolarTransit() returned %d events, want 1", len(events))
+	}
+
+	event := events[0]
+
+	if diff := event.Time.Sub(center); diff < -time.Second || diff > time.Second {
+		t.Errorf("transit time = %v, want %v ±1s", event.Time, center)
+	}
+
+	if event.SeparationDeg > event.SunRadiusDeg/4 {
+		t.Errorf("SeparationDeg = %.4f, expected near disc center (radius %.4f)", event.SeparationDeg, event.SunRadiusDeg)
+	}
+
+	if !almostEqual(event.SunRadiusDeg, 0.27, 0.01) {
+		t.Errorf("SunRadiusDeg = %.4f, want ~0.27", event.SunRadiusDeg)
+	}
+
+	if event.Duration <= 0 || event.Duration > 5*time.Second {
+		t.Errorf("Duration = %v, expected sub-second to a few seconds", event.Duration)
+	}
+
+	// Грубый допуск пропагатора не ухудшает точность центрального момента хуже 10 мс.
+	coarse := prop.WithRefineOptions(RefineOptions{Tolerance: time.Second})
+
+	coarseEvents, err := obs.SolarTransit(coarse, center.Add(-3*time.Minute), center.Add(3*time.Minute))
+	if err != nil || len(coarseEvents) != 1 {
+		t.Fatalf("SolarTransit(coarse) = %d events, %v, want 1", len(coarseEvents), err)
+	}
+
+	if diff := absDuration(coarseEvents[0].Time.Sub(event.Time)); diff > 20*time.Millisecond {
+		t.Errorf("coarse transit time differs by %v, want within 20ms", diff)
+	}
+
+	// Сдвиг наблюдателя на 50 км убирает транзит.
+	shifted := NewObserver(obs.Lat+0.5, obs.Lon, obs.Alt)
+
+	events, err = shifted.SolarTransit(prop, center.Add(-3*time.Minute), center.Add(3*time.Minute))
+	if err != nil {
+		t.Fatalf("SolarTransit(shifted) error = %v", err)
+	}
+
+	if len(events) != 0 {
+		t.Errorf("SolarTransit(shifted) returned %d events, want 0", len(events))
+	}
+
+	if _, err := obs.SolarTransit(nil, center, center.Add(time.Minute)); err == nil {
+		t.Error("SolarTransit(nil propagator) expected error")
+	}
+}
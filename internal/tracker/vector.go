@@ -0,0 +1,69 @@
+package tracker
+
+import "math"
+
+// vec3 — трёхмерный вектор для геометрических расчётов (км или безразмерный).
+type vec3 struct {
+	X, Y, Z float64
+}
+
+// eciVec возвращает вектор позиции ECI.
+func eciVec(pos *ECIPosition) vec3 {
+	return vec3{pos.X, pos.Y, pos.Z}
+}
+
+// eciVelocity возвращает вектор скорости ECI.
+func eciVelocity(pos *ECIPosition) vec3 {
+	return vec3{pos.Vx, pos.Vy, pos.Vz}
+}
+
+// add возвращает сумму векторов.
+func (v vec3) add(o vec3) vec3 {
+	return vec3{v.X + o.X, v.Y + o.Y, v.Z + o.Z}
+}
+
+// sub возвращает разность векторов.
+func (v vec3) sub(o vec3) vec3 {
+	return vec3{v.X - o.X, v.Y - o.Y, v.Z - o.Z}
+}
+
+// scale возвращает вектор, умноженный на k.
+func (v vec3) scale(k float64) vec3 {
+	return vec3{v.X * k, v.Y * k, v.Z * k}
+}
+
+// dot возвращает скалярное произведение.
+func (v vec3) dot(o vec3) float64 {
+	return v.X*o.X + v.Y*o.Y + v.Z*o.Z
+}
+
+// cross возвращает векторное произведение.
+func (v vec3) cross(o vec3) vec3 {
+	return vec3{
+		v.Y*o.Z - v.Z*o.Y,
+		v.Z*o.X - v.X*o.Z,
+		v.X*o.Y - v.Y*o.X,
+	}
+}
+
+// norm возвращает длину вектора.
+func (v vec3) norm() float64 {
+	return math.Sqrt(v.dot(v))
+}
+
+// unit возвращает единичный вектор того же направления (нулевой для нулевого).
+func (v vec3) unit() vec3 {
+	n := v.norm()
+	if n == 0 {
+		return vec3{}
+	}
+
+	return v.scale(1 / n)
+}
+
+// angleBetween возвращает угол между векторами в радианах.
+func angleBetween(a, b vec3) float64 {
+	c := a.unit().dot(b.unit())
+
+	return math.Acos(math.Max(-1, math.Min(1, c)))
+}
@@ -0,0 +1,228 @@
+package tracker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotVisible возвращается, если спутник не становится визуально видимым в окне поиска.
+var ErrNotVisible = errors.New("satellite is not visible within search window")
+
+// ObserverDarkSunElevationDeg — угол места Солнца, ниже которого наблюдатель
+// считается в темноте (конец гражданских сумерек).
+const ObserverDarkSunElevationDeg = -6.0
+
+// IsVisibleAt проверяет условия визуальной видимости спутника в момент t:
+// угол места не ниже minElDeg, спутник освещён, у наблюдателя темно.
+func (p *Propagator) IsVisibleAt(obs *Observer, t time.Time, minElDeg float64) (bool, error) {
+	if p == nil {
+		return false, ErrNilTLE
+	}
+
+	if obs == nil {
+		return false, ErrNilObserver
+	}
+
+	pos, err := p.propagatePrecise(t)
+	if err != nil {
+		return false, err
+	}
+
+	if obs.GetAER(pos).ElDeg() < minElDeg {
+		return false, nil
+	}
+
+	if obs.SunElevationDeg(t) >= ObserverDarkSunElevationDeg {
+		return false, nil
+	}
+
+	return IsSunlit(pos), nil
+}
+
+// eclipseSampleStep — шаг интегрирования освещённости по витку.
+const eclipseSampleStep = 10 * time.Second
+
+// EclipseDurationPerOrbit возвращает суммарное время на Солнце и в тени Земли
+// за один орбитальный период, начиная с момента t (цилиндрическая модель тени, см. IsSunlit).
+// Сумма длительностей равна периоду. Определяет тепловой цикл спутника на витке.
+func (p *Propagator) EclipseDurationPerOrbit(t time.Time) (sunlit, eclipsed time.Duration, err error) {
+	if p == nil {
+		return 0, 0, ErrNilTLE
+	}
+
+	period := time.Duration(p.tle.OrbitalPeriod() * float64(time.Minute))
+	if period <= 0 {
+		return 0, 0, fmt.Errorf("%w: mean motion is zero", ErrInvalidTLEForPropagation)
+	}
+
+	end := t.Add(period)
+
+	for from := t; from.Before(end); from = from.Add(eclipseSampleStep) {
+		to := minTime(from.Add(eclipseSampleStep), end)
+		interval := to.Sub(from)
+
+		// Освещённость интервала определяется по его середине.
+		pos, err := p.propagatePrecise(from.Add(interval / 2))
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if IsSunlit(pos) {
+			sunlit += interval
+		} else {
+			eclipsed += interval
+		}
+	}
+
+	return sunlit, eclipsed, nil
+}
+
+// SunlitDuringPass возвращает интервалы пролёта, в течение которых спутник освещён
+// Солнцем (цилиндрическая модель тени, см. IsSunlit). Для оптической съёмки пригодна
+// только эта часть пролёта. Переходы свет/тень уточняются бисекцией.
+func (p *Propagator) SunlitDuringPass(obs *Observer, pass *Pass) (sunlitWindows []TimeWindow, err error) {
+	if p == nil {
+		return nil, ErrNilTLE
+	}
+
+	if obs == nil {
+		return nil, ErrNilObserver
+	}
+
+	if pass == nil || !pass.LOS.After(pass.AOS) {
+		return nil, ErrInvalidWindow
+	}
+
+	sunlitAt := func(t time.Time) (bool, error) {
+		pos, err := p.propagatePrecise(t)
+		if err != nil {
+			return false, err
+		}
+
+		return IsSunlit(pos), nil
+	}
+
+	lit, err := sunlitAt(pass.AOS)
+	if err != nil {
+		return nil, err
+	}
+
+	start := pass.AOS
+	prev := pass.AOS
+
+	for t := pass.AOS.Add(eclipseSampleStep); ; t = t.Add(eclipseSampleStep) {
+		t = minTime(t, pass.LOS)
+
+		cur, err := sunlitAt(t)
+		if err != nil {
+			return nil, err
+		}
+
+		if cur != lit {
+			edge, err := p.bisectIllumination(sunlitAt, prev, t, cur)
+			if err != nil {
+				return nil, err
+			}
+
+			if lit {
+				sunlitWindows = append(sunlitWindows, TimeWindow{Start: start, End: edge})
+			}
+
+			start, lit = edge, cur
+		}
+
+		if !t.Before(pass.LOS) {
+			break
+		}
+
+		prev = t
+	}
+
+	if lit {
+		sunlitWindows = append(sunlitWindows, TimeWindow{Start: start, End: pass.LOS})
+	}
+
+	return MergeTimeWindows(sunlitWindows), nil
+}
+
+// bisectIllumination уточняет момент смены освещённости между a и b;
+// в момент b освещённость равна after.
+func (p *Propagator) bisectIllumination(sunlitAt func(time.Time) (bool, error), a, b time.Time, after bool) (time.Time, error) {
+	for iter := 0; !p.refine.converged(b.Sub(a), iter); iter++ {
+		mid := a.Add(b.Sub(a) / 2)
+
+		lit, err := sunlitAt(mid)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if lit == after {
+			b = mid
+		} else {
+			a = mid
+		}
+	}
+
+	return b, nil
+}
+
+// NextVisibleInstant возвращает первый момент после after, когда спутник
+// визуально виден наблюдателю (см. IsVisibleAt). Не рассчитывает пролёт целиком,
+// поэтому дешевле полного поиска визуального пролёта. Поиск ограничен 48 часами.
+func (p *Propagator) NextVisibleInstant(obs *Observer, after time.Time, minElDeg float64) (time.Time, error) {
+	if p == nil {
+		return time.Time{}, ErrNilTLE
+	}
+
+	if obs == nil {
+		return time.Time{}, ErrNilObserver
+	}
+
+	visible, err := p.IsVisibleAt(obs, after, minElDeg)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if visible {
+		return after, nil
+	}
+
+	prev := after
+	until := after.Add(passSearchHorizon)
+
+	for t := after.Add(passCoarseStep); !t.After(until); t = t.Add(passCoarseStep) {
+		visible, err := p.IsVisibleAt(obs, t, minElDeg)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if visible {
+			return p.bisectVisible(obs, prev, t, minElDeg)
+		}
+
+		prev = t
+	}
+
+	return time.Time{}, fmt.Errorf("%w: between %v and %v", ErrNotVisible, after, until)
+}
+
+// bisectVisible уточняет момент начала видимости между a (не виден) и b (виден).
+func (p *Propagator) bisectVisible(obs *Observer, a, b time.Time, minElDeg float64) (time.Time, error) {
+	for iter := 0; !p.refine.converged(b.Sub(a), iter); iter++ {
+		mid := a.Add(b.Sub(a) / 2)
+
+		visible, err := p.IsVisibleAt(obs, mid, minElDeg)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if visible {
+			b = mid
+		} else {
+			a = mid
+		}
+	}
+
+	return b, nil
+}
@@ -0,0 +1,158 @@
+package tracker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestNextVisibleInstant проверяет, что найденный момент лежит внутри пролёта
+// и удовлетворяет условиям визуальной видимости.
+func TestNextVisibleInstant(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+
+	instant, err := prop.NextVisibleInstant(passTestMoscow, passTestStart, 10)
+	if err != nil {
+		t.Fatalf("NextVisibleInstant() error = %v", err)
+	}
+
+	pass, err := prop.NextPass(passTestMoscow, instant, 10)
+	if err != nil {
+		t.Fatalf("NextPass() error = %v", err)
+	}
+
+	if instant.Before(pass.AOS) || instant.After(pass.LOS) {
+		t.Errorf("instant %v outside pass [%v, %v]", instant, pass.AOS, pass.LOS)
+	}
+
+	visible, err := prop.IsVisibleAt(passTestMoscow, instant, 10)
+	if err != nil || !visible {
+		t.Errorf("IsVisibleAt(instant) = %v, %v, want true", visible, err)
+	}
+
+	if sunEl := passTestMoscow.SunElevationDeg(instant); sunEl >= ObserverDarkSunElevationDeg {
+		t.Errorf("Sun elevation at instant = %.2f°, want below %.0f°", sunEl, ObserverDarkSunElevationDeg)
+	}
+}
+
+// TestNextVisibleInstant_NotVisible проверяет ошибку, если видимости нет в окне поиска.
+func TestNextVisibleInstant_NotVisible(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+
+	// ISS (51.6°) никогда не поднимается над горизонтом Южного полюса.
+	southPole := NewObserver(-90, 0, 2.8)
+
+	_, err := prop.NextVisibleInstant(southPole, passTestStart, 10)
+	if !errors.Is(err, ErrNotVisible) {
+		t.Errorf("NextVisibleInstant() error = %v, want ErrNotVisible", err)
+	}
+}
+
+// TestPropagator_IsVisibleAt_Nil проверяет ошибки вместо паники при nil пропагаторе или наблюдателе.
+func TestPropagator_IsVisibleAt_Nil(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+
+	if _, err := prop.IsVisibleAt(nil, passTestStart, 10); !errors.Is(err, ErrNilObserver) {
+		t.Errorf("IsVisibleAt(nil observer) error = %v, want ErrNilObserver", err)
+	}
+
+	var nilProp *Propagator
+	if _, err := nilProp.IsVisibleAt(passTestMoscow, passTestStart, 10); !errors.Is(err, ErrNilTLE) {
+		t.Errorf("nil IsVisibleAt() error = %v, want ErrNilTLE", err)
+	}
+}
+
+// TestPropagator_EclipseDurationPerOrbit проверяет тень на витке ISS при малом угле бета.
+func TestPropagator_EclipseDurationPerOrbit(t *testing.T) {
+	t.Parallel()
+
+	tle, err := ParseTLE([]string{issLine1, issLine2})
+	if err != nil {
+		t.Fatalf("ParseTLE() error = %v", err)
+	}
+
+	prop, err := NewPropagator(tle)
+	if err != nil {
+		t.Fatalf("NewPropagator() error = %v", err)
+	}
+
+	sunlit, eclipsed, err := prop.EclipseDurationPerOrbit(passTestStart)
+	if err != nil {
+		t.Fatalf("EclipseDurationPerOrbit() error = %v", err)
+	}
+
+	period := time.Duration(tle.OrbitalPeriod() * float64(time.Minute))
+	if sunlit+eclipsed != period {
+		t.Errorf("sunlit %v + eclipsed %v = %v, want period %v", sunlit, eclipsed, sunlit+eclipsed, period)
+	}
+
+	// При малом угле бета LEO проводит в тени около трети витка.
+	if eclipsed < 25*time.Minute || eclipsed > 40*time.Minute {
+		t.Errorf("eclipsed = %v, expected 25-40 min", eclipsed)
+	}
+}
+
+// TestPropagator_SunlitDuringPass проверяет, что для пролёта через терминатор
+// освещённая часть — строгое подмножество пролёта.
+func TestPropagator_SunlitDuringPass(t *testing.T) {
+	t.Parallel()
+
+	prop := createTestPropagator(t)
+
+	passes, err := prop.PassesInWindow(passTestMoscow, passTestStart, passTestStart.Add(48*time.Hour), 0)
+	if err != nil {
+		t.Fatalf("PassesInWindow() error = %v", err)
+	}
+
+	var (
+		straddling *Pass
+		windows    []TimeWindow
+	)
+
+	for _, pass := range passes {
+		sunlit, err := prop.SunlitDuringPass(passTestMoscow, pass)
+		if err != nil {
+			t.Fatalf("SunlitDuringPass() error = %v", err)
+		}
+
+		var total time.Duration
+		for _, w := range sunlit {
+			total += w.Duration()
+		}
+
+		if total > 0 && total < pass.Duration() {
+			straddling, windows = pass, sunlit
+			break
+		}
+	}
+
+	if straddling == nil {
+		t.Fatal("no ISS pass over Moscow crossing the terminator within 48 h")
+	}
+
+	for i, w := range windows {
+		if w.Start.Before(straddling.AOS) || w.End.After(straddling.LOS) || w.Duration() >= straddling.Duration() {
+			t.Errorf("window[%d] %v-%v is not a strict subset of pass %v-%v", i, w.Start, w.End, straddling.AOS, straddling.LOS)
+		}
+
+		// В середине освещённого интервала спутник на Солнце.
+		pos, err := prop.propagatePrecise(w.Start.Add(w.Duration() / 2))
+		if err != nil {
+			t.Fatalf("propagatePrecise() error = %v", err)
+		}
+
+		if !IsSunlit(pos) {
+			t.Errorf("window[%d] midpoint is in shadow", i)
+		}
+	}
+
+	if _, err := prop.SunlitDuringPass(passTestMoscow, nil); err == nil {
+		t.Error("SunlitDuringPass(nil pass) should fail")
+	}
+}